@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -8,9 +9,22 @@ import (
 
 	"order-service/internal/application/usecase"
 	"order-service/internal/config"
+	domainauth "order-service/internal/domain/auth"
+	domaincatalog "order-service/internal/domain/catalog"
+	"order-service/internal/domain/entity"
+	domainexchange "order-service/internal/domain/exchange"
+	domaintax "order-service/internal/domain/tax"
+	"order-service/internal/infrastructure/auth"
+	"order-service/internal/infrastructure/catalog"
+	"order-service/internal/infrastructure/consumer"
 	"order-service/internal/infrastructure/database"
+	"order-service/internal/infrastructure/exchange"
+	"order-service/internal/infrastructure/outbox"
 	"order-service/internal/infrastructure/publisher"
+	"order-service/internal/infrastructure/tax"
 	"order-service/internal/interface/api"
+
+	"github.com/rabbitmq/amqp091-go"
 )
 
 func main() {
@@ -41,26 +55,153 @@ func main() {
 	defer queueConn.Close()
 
 	orderRepository := database.NewOrderRepositorySql(db)
-	rabbitPublisher, err := publisher.NewRabbitMQPublisher(queueConn, "orders_exchange", "order_created", "order_created_queue")
+	rabbitPublisher, err := publisher.NewRabbitMQPublisher(queueConn, func() (*amqp091.Connection, error) {
+		return config.InitQueue(cfg)
+	}, "orders_exchange")
 	if err != nil {
 		logger.Fatalf("Error creating RabbitMQ publisher: %v", err)
 	}
+	for _, eventType := range entity.EventTypes {
+		if err := rabbitPublisher.DeclareQueue(string(eventType)+"_queue", string(eventType)); err != nil {
+			logger.Fatalf("Error declaring queue for %s events: %v", eventType, err)
+		}
+	}
+
+	relay := outbox.NewRelay(database.NewOutboxRepositorySql(db), rabbitPublisher, outbox.RelayConfig{
+		PollInterval: cfg.OutboxPollInterval,
+		BatchSize:    cfg.OutboxBatchSize,
+		MaxAttempts:  cfg.OutboxMaxAttempts,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go relay.Run(ctx)
+
+	inventoryService := database.NewInventoryServiceSql(db)
+	updateOrderStatusUseCase := usecase.NewUpdateOrderStatusUseCase(orderRepository, inventoryService)
+	processingResultsConsumer, err := consumer.NewRabbitMQConsumer(func() (*amqp091.Connection, error) {
+		return config.InitQueue(cfg)
+	}, "order_processing_results", cfg.ConsumerMaxRetries, cfg.ConsumerPrefetch)
+	if err != nil {
+		logger.Fatalf("Error creating RabbitMQ consumer: %v", err)
+	}
+	defer processingResultsConsumer.Close()
+	go func() {
+		if err := processingResultsConsumer.Start(ctx, consumer.NewProcessingResultHandler(updateOrderStatusUseCase)); err != nil {
+			logger.Printf("Processing results consumer stopped: %v", err)
+		}
+	}()
+
+	var rateProvider domainexchange.RateProvider
+	if cfg.ExchangeRatesFile != "" {
+		staticRates, err := exchange.LoadStaticRateProvider(cfg.ExchangeRatesFile)
+		if err != nil {
+			logger.Fatalf("Error loading exchange rates: %v", err)
+		}
+		rateProvider = staticRates
+	}
+
+	var itemPricer *usecase.ItemPricer
+	priceMode, err := domaincatalog.ParsePriceMode(cfg.CatalogPriceMode)
+	if err != nil {
+		logger.Fatalf("Error configuring the product catalog: %v", err)
+	}
+	switch {
+	case cfg.CatalogURL != "":
+		itemPricer = usecase.NewItemPricer(catalog.NewHTTPProductCatalog(cfg.CatalogURL, cfg.CatalogTimeout), priceMode)
+	case cfg.CatalogFile != "":
+		staticCatalog, err := catalog.LoadStaticProductCatalog(cfg.CatalogFile)
+		if err != nil {
+			logger.Fatalf("Error loading product catalog: %v", err)
+		}
+		itemPricer = usecase.NewItemPricer(staticCatalog, priceMode)
+	}
+
+	var taxCalculator domaintax.Calculator
+	if cfg.TaxRulesFile != "" {
+		rulesCalculator, err := tax.LoadRulesCalculator(cfg.TaxRulesFile)
+		if err != nil {
+			logger.Fatalf("Error loading tax rules: %v", err)
+		}
+		taxCalculator = rulesCalculator
+	}
 
-	createOrderUseCase := usecase.NewCreateOrderUseCase(orderRepository, rabbitPublisher)
-	updateOrderUseCase := usecase.NewUpdateOrderUseCase(orderRepository)
-	cancelOrderUseCase := usecase.NewCancelOrderUseCase(orderRepository)
-	getOrderUseCase := usecase.NewGetOrderUseCase(orderRepository)
+	promotionRepository := database.NewPromotionRepositorySql(db)
+	customerRepository := database.NewCustomerRepositorySql(db)
+	apiKeyRepository := database.NewAPIKeyRepositorySql(db)
+
+	createOrderUseCase := usecase.NewCreateOrderUseCase(orderRepository, itemPricer, inventoryService, promotionRepository, taxCalculator, customerRepository)
+	updateOrderUseCase := usecase.NewUpdateOrderUseCase(orderRepository, itemPricer, inventoryService, promotionRepository, taxCalculator, customerRepository)
+	patchOrderUseCase := usecase.NewPatchOrderUseCase(orderRepository, itemPricer, inventoryService, promotionRepository, taxCalculator, customerRepository)
+	addOrderItemUseCase := usecase.NewAddOrderItemUseCase(orderRepository, itemPricer, inventoryService, promotionRepository, taxCalculator)
+	updateOrderItemUseCase := usecase.NewUpdateOrderItemUseCase(orderRepository, itemPricer, inventoryService, promotionRepository, taxCalculator)
+	removeOrderItemUseCase := usecase.NewRemoveOrderItemUseCase(orderRepository, inventoryService, promotionRepository, taxCalculator)
+	cancelOrderUseCase := usecase.NewCancelOrderUseCase(orderRepository, inventoryService, promotionRepository)
+	getOrderUseCase := usecase.NewGetOrderUseCase(orderRepository, rateProvider)
 	listOrderUseCase := usecase.NewListOrderUseCase(orderRepository)
+	getOrderTransitionsUseCase := usecase.NewGetOrderTransitionsUseCase(orderRepository)
+	getOrderHistoryUseCase := usecase.NewGetOrderHistoryUseCase(orderRepository)
+	idempotentCreateOrderUseCase := usecase.NewIdempotentCreateOrderUseCase(createOrderUseCase, database.NewIdempotencyRepositorySql(db), cfg.IdempotencyKeyTTL)
+	createCustomerUseCase := usecase.NewCreateCustomerUseCase(customerRepository)
+	getCustomerUseCase := usecase.NewGetCustomerUseCase(customerRepository)
+	listCustomersUseCase := usecase.NewListCustomersUseCase(customerRepository)
+	listCustomerOrdersUseCase := usecase.NewListCustomerOrdersUseCase(customerRepository, listOrderUseCase)
+	issueAPIKeyUseCase := usecase.NewIssueAPIKeyUseCase(apiKeyRepository)
+	listAPIKeysUseCase := usecase.NewListAPIKeysUseCase(apiKeyRepository)
+	getAPIKeyUseCase := usecase.NewGetAPIKeyUseCase(apiKeyRepository)
+	rotateAPIKeyUseCase := usecase.NewRotateAPIKeyUseCase(apiKeyRepository)
+	revokeAPIKeyUseCase := usecase.NewRevokeAPIKeyUseCase(apiKeyRepository)
+
+	handlers := api.NewAPI(api.UseCases{
+		CreateOrder:           createOrderUseCase,
+		UpdateOrder:           updateOrderUseCase,
+		CancelOrder:           cancelOrderUseCase,
+		GetOrder:              getOrderUseCase,
+		ListOrders:            listOrderUseCase,
+		GetOrderTransitions:   getOrderTransitionsUseCase,
+		GetOrderHistory:       getOrderHistoryUseCase,
+		IdempotentCreateOrder: idempotentCreateOrderUseCase,
+		PatchOrder:            patchOrderUseCase,
+		AddOrderItem:          addOrderItemUseCase,
+		UpdateOrderItem:       updateOrderItemUseCase,
+		RemoveOrderItem:       removeOrderItemUseCase,
+		CreateCustomer:        createCustomerUseCase,
+		GetCustomer:           getCustomerUseCase,
+		ListCustomers:         listCustomersUseCase,
+		ListCustomerOrders:    listCustomerOrdersUseCase,
+		IssueAPIKey:           issueAPIKeyUseCase,
+		ListAPIKeys:           listAPIKeysUseCase,
+		GetAPIKey:             getAPIKeyUseCase,
+		RotateAPIKey:          rotateAPIKeyUseCase,
+		RevokeAPIKey:          revokeAPIKeyUseCase,
+	})
 
-	handlers := api.NewAPI(
-		createOrderUseCase,
-		updateOrderUseCase,
-		cancelOrderUseCase,
-		getOrderUseCase,
-		listOrderUseCase,
-	)
+	var authenticators map[string]domainauth.Authenticator
+	if cfg.AuthDisabled {
+		logger.Println("Authentication is disabled, the API is open to anyone")
+	} else {
+		authenticators = map[string]domainauth.Authenticator{"ApiKey": auth.NewAPIKeyAuthenticator(apiKeyRepository)}
+		if cfg.JWTSecret != "" || cfg.JWKSFile != "" {
+			jwtConfig := auth.JWTConfig{Issuer: cfg.JWTIssuer, Audience: cfg.JWTAudience}
+			if cfg.JWTSecret != "" {
+				jwtConfig.HMACSecret = []byte(cfg.JWTSecret)
+			}
+			if cfg.JWKSFile != "" {
+				jwtConfig.Keys, err = auth.LoadJWKS(cfg.JWKSFile)
+				if err != nil {
+					logger.Fatalf("Error loading JWKS: %v", err)
+				}
+			}
+			jwtAuthenticator, err := auth.NewJWTAuthenticator(jwtConfig)
+			if err != nil {
+				logger.Fatalf("Error configuring authentication: %v", err)
+			}
+			authenticators["Bearer"] = jwtAuthenticator
+		} else {
+			logger.Println("No JWT secret or JWKS file configured, only API keys are accepted")
+		}
+	}
 
-	r := api.NewRouter(handlers)
+	r := api.NewRouter(handlers, authenticators)
 
 	logger.Println("Starting server on :8080...")
 	logger.Fatal(http.ListenAndServe(":8080", r))
@@ -0,0 +1,62 @@
+package dtos
+
+import (
+	"time"
+
+	"order-service/internal/domain/auth"
+)
+
+type APIKeyInput struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type RotateAPIKeyInput struct {
+	// GracePeriod is how long the rotated key keeps working, e.g. "24h". It stops at once without one.
+	GracePeriod string `json:"grace_period,omitempty"`
+}
+
+// GraceDuration returns the grace period of a validated input.
+func (i RotateAPIKeyInput) GraceDuration() time.Duration {
+	grace, _ := time.ParseDuration(i.GracePeriod)
+	return grace
+}
+
+type APIKeyOutput struct {
+	ID         string     `json:"api_key_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// IssuedAPIKeyOutput carries the key itself, which is only ever returned when it is issued.
+type IssuedAPIKeyOutput struct {
+	APIKeyOutput
+	Key string `json:"key"`
+}
+
+type ListAPIKeysOutput struct {
+	APIKeys []APIKeyOutput `json:"api_keys"`
+}
+
+func FromEntityToAPIKeyOutput(key *auth.APIKey) APIKeyOutput {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return APIKeyOutput{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package dtos
+
+import (
+	"time"
+
+	"order-service/internal/domain/entity"
+)
+
+type CustomerInput struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+type CustomerOutput struct {
+	ID        string    `json:"customer_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListCustomersInput struct {
+	Page int
+	Size int
+	Name string
+}
+
+type ListCustomersOutput struct {
+	Page      int              `json:"page"`
+	Size      int              `json:"size"`
+	Total     int              `json:"total"`
+	Customers []CustomerOutput `json:"customers"`
+}
+
+func FromEntityToCustomerOutput(customer *entity.Customer) CustomerOutput {
+	return CustomerOutput{
+		ID:        customer.ID,
+		Name:      customer.Name,
+		Email:     customer.Email,
+		CreatedAt: customer.CreatedAt,
+		UpdatedAt: customer.UpdatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"time"
+
+	"order-service/internal/domain/entity"
+)
+
+// Address is a postal address in requests and responses; Country is an ISO 3166-1 alpha-2 code.
+type Address struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country"`
+}
+
+// DeliveryWindow is the period the customer wants the order delivered in.
+type DeliveryWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// DeliveryDetails converts the addresses, delivery method and window of the input.
+func (i OrderInput) DeliveryDetails() entity.DeliveryDetails {
+	details := entity.DeliveryDetails{DeliveryMethod: entity.DeliveryMethod(i.DeliveryMethod)}
+	if i.ShippingAddress != nil {
+		address := entity.Address(*i.ShippingAddress)
+		details.ShippingAddress = &address
+	}
+	if i.BillingAddress != nil {
+		address := entity.Address(*i.BillingAddress)
+		details.BillingAddress = &address
+	}
+	if i.DeliveryWindow != nil {
+		window := entity.DeliveryWindow(*i.DeliveryWindow)
+		details.DeliveryWindow = &window
+	}
+	return details
+}
+
+func fromEntityAddress(address *entity.Address) *Address {
+	if address == nil {
+		return nil
+	}
+	output := Address(*address)
+	return &output
+}
+
+func fromEntityDeliveryWindow(window *entity.DeliveryWindow) *DeliveryWindow {
+	if window == nil {
+		return nil
+	}
+	output := DeliveryWindow(*window)
+	return &output
+}
@@ -1,16 +1,47 @@
 package dtos
 
+import "order-service/internal/domain/entity"
+
+// ItemInput is an order line. ID names an existing line of the order; line IDs are generated by the
+// server, and an ID that is not a line is read as the product of older clients. ProductID is the product, or SKU, of the line and Category its tax
+// category, which the product catalog overrides.
 type ItemInput struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
+	ID        string       `json:"id,omitempty"`
+	ProductID string       `json:"product_id,omitempty"`
+	Name      string       `json:"name"`
+	Category  string       `json:"category,omitempty"`
+	Quantity  int          `json:"quantity"`
+	Price     entity.Money `json:"price"`
+	Currency  string       `json:"currency,omitempty"`
+}
+
+// ItemUpdateInput changes some fields of an item; fields left out keep their value.
+type ItemUpdateInput struct {
+	Name     *string       `json:"name,omitempty"`
+	Quantity *int          `json:"quantity,omitempty"`
+	Price    *entity.Money `json:"price,omitempty"`
 }
 
+// ItemOutput is an order line. Total is before discounts, while Tax and TotalWithTax are worked out
+// on the amount due for the line after discounts.
 type ItemOutput struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
-	Total    float64 `json:"total"`
+	ID           string       `json:"id"`
+	ProductID    string       `json:"product_id,omitempty"`
+	Name         string       `json:"name"`
+	Category     string       `json:"category,omitempty"`
+	Quantity     int          `json:"quantity"`
+	Price        entity.Money `json:"price"`
+	Total        entity.Money `json:"total"`
+	Tax          entity.Money `json:"tax"`
+	TotalWithTax entity.Money `json:"total_with_tax"`
+}
+
+// ItemPatchDocument is an item inside an OrderPatchDocument, where its ID is the key.
+type ItemPatchDocument struct {
+	ProductID string       `json:"product_id,omitempty"`
+	Name      string       `json:"name"`
+	Category  string       `json:"category,omitempty"`
+	Quantity  int          `json:"quantity"`
+	Price     entity.Money `json:"price"`
+	Currency  string       `json:"currency,omitempty"`
 }
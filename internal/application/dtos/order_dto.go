@@ -1,41 +1,215 @@
 package dtos
 
-import "order-service/internal/domain/entity"
+import (
+	"encoding/json"
+	"time"
+
+	"order-service/internal/domain/entity"
+)
 
 type OrderInput struct {
-	CustomerName string      `json:"customer_name"`
-	Items        []ItemInput `json:"items"`
+	CustomerID      string          `json:"customer_id,omitempty"`
+	CustomerName    string          `json:"customer_name"`
+	Currency        string          `json:"currency,omitempty"`
+	Items           []ItemInput     `json:"items"`
+	CouponCode      string          `json:"coupon_code,omitempty"`
+	TaxJurisdiction string          `json:"tax_jurisdiction,omitempty"`
+	ShippingAddress *Address        `json:"shipping_address,omitempty"`
+	BillingAddress  *Address        `json:"billing_address,omitempty"`
+	DeliveryMethod  string          `json:"delivery_method,omitempty"`
+	DeliveryWindow  *DeliveryWindow `json:"delivery_window,omitempty"`
 }
 
 type OrderOutput struct {
-	ID           string       `json:"order_id"`
-	CustomerName string       `json:"customer_name"`
-	Items        []ItemOutput `json:"items"`
-	Total        float64      `json:"total"`
-	Status       string       `json:"status"`
+	ID              string           `json:"order_id"`
+	CustomerID      string           `json:"customer_id,omitempty"`
+	CustomerName    string           `json:"customer_name"`
+	Currency        string           `json:"currency"`
+	Items           []ItemOutput     `json:"items"`
+	CouponCode      string           `json:"coupon_code,omitempty"`
+	Discounts       []DiscountOutput `json:"discounts,omitempty"`
+	Subtotal        entity.Money     `json:"subtotal"`
+	DiscountTotal   entity.Money     `json:"discount_total"`
+	Total           entity.Money     `json:"total"`
+	TaxJurisdiction string           `json:"tax_jurisdiction,omitempty"`
+	Tax             entity.Money     `json:"tax"`
+	TotalWithTax    entity.Money     `json:"total_with_tax"`
+	ConvertedTotal  *ConvertedAmount `json:"converted_total,omitempty"`
+	ShippingAddress *Address         `json:"shipping_address,omitempty"`
+	BillingAddress  *Address         `json:"billing_address,omitempty"`
+	DeliveryMethod  string           `json:"delivery_method,omitempty"`
+	DeliveryWindow  *DeliveryWindow  `json:"delivery_window,omitempty"`
+	Status          string           `json:"status"`
+	Version         int64            `json:"version"`
+}
+
+// DiscountOutput is a discount on the order, or on one item when ItemID is set.
+type DiscountOutput struct {
+	Code        string       `json:"code"`
+	ItemID      string       `json:"item_id,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Amount      entity.Money `json:"amount"`
+}
+
+type ConvertedAmount struct {
+	Currency string       `json:"currency"`
+	Rate     string       `json:"rate"`
+	Total    entity.Money `json:"total"`
+}
+
+type OrderTransitionsOutput struct {
+	ID          string   `json:"order_id"`
+	Status      string   `json:"status"`
+	Transitions []string `json:"transitions"`
+}
+
+type StatusChangeOutput struct {
+	From      *string   `json:"from"`
+	To        string    `json:"to"`
+	ChangedBy string    `json:"changed_by,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type OrderHistoryOutput struct {
+	ID      string               `json:"order_id"`
+	History []StatusChangeOutput `json:"history"`
+}
+
+type OrderStatusInput struct {
+	OrderID string `json:"order_id"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type ListOrderInput struct {
+	Page          int
+	Size          int
+	Status        string
+	CustomerID    string
+	CustomerName  string
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	SortBy        string
+	SortDirection string
+	Keyset        bool
+	Cursor        string
 }
 
 type ListOrderOutput struct {
-	Orders []OrderOutput `json:"orders"`
+	Page       int           `json:"page"`
+	Size       int           `json:"size"`
+	Total      int           `json:"total"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	Orders     []OrderOutput `json:"orders"`
 }
 
 func FromEntityToOrderOutput(order *entity.Order) OrderOutput {
 	var items []ItemOutput
-	for _, item := range order.Items {
+	netTotals := order.NetItemTotals()
+	for i, item := range order.Items {
+		tax := entity.NewMoney(item.Tax.Amount, order.Currency)
 		items = append(items, ItemOutput{
-			ID:       item.ID,
-			Name:     item.Name,
-			Quantity: item.Quantity,
-			Price:    item.Price,
-			Total:    item.Total(),
+			ID:           item.ID,
+			ProductID:    item.ProductID,
+			Name:         item.Name,
+			Category:     item.Category,
+			Quantity:     item.Quantity,
+			Price:        item.Price,
+			Total:        item.Total(),
+			Tax:          tax,
+			TotalWithTax: netTotals[i].Add(tax),
 		})
 	}
 
+	var discounts []DiscountOutput
+	for _, discount := range order.Discounts {
+		discounts = append(discounts, DiscountOutput(discount))
+	}
+
 	return OrderOutput{
-		ID:           order.ID,
-		CustomerName: order.CustomerName,
-		Total:        order.Total(),
-		Status:       order.Status.String(),
-		Items:        items,
+		ID:              order.ID,
+		CustomerID:      order.CustomerID,
+		CustomerName:    order.CustomerName,
+		Currency:        order.Currency,
+		CouponCode:      order.CouponCode,
+		Discounts:       discounts,
+		Subtotal:        order.Subtotal(),
+		DiscountTotal:   order.DiscountTotal(),
+		Total:           order.Total(),
+		TaxJurisdiction: order.TaxJurisdiction,
+		Tax:             order.Tax(),
+		TotalWithTax:    order.TotalWithTax(),
+		ShippingAddress: fromEntityAddress(order.ShippingAddress),
+		BillingAddress:  fromEntityAddress(order.BillingAddress),
+		DeliveryMethod:  string(order.DeliveryMethod),
+		DeliveryWindow:  fromEntityDeliveryWindow(order.DeliveryWindow),
+		Status:          order.Status.String(),
+		Version:         order.Version,
+		Items:           items,
+	}
+}
+
+// UnmarshalOrderOutput decodes a stored order response, restoring the currency of its amounts,
+// which is carried once at order level in the JSON representation.
+func UnmarshalOrderOutput(data []byte) (OrderOutput, error) {
+	var output OrderOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return OrderOutput{}, err
+	}
+
+	output.Subtotal.Currency = output.Currency
+	output.DiscountTotal.Currency = output.Currency
+	output.Total.Currency = output.Currency
+	output.Tax.Currency = output.Currency
+	output.TotalWithTax.Currency = output.Currency
+	for i := range output.Discounts {
+		output.Discounts[i].Amount.Currency = output.Currency
+	}
+	for i := range output.Items {
+		output.Items[i].Price.Currency = output.Currency
+		output.Items[i].Total.Currency = output.Currency
+		output.Items[i].Tax.Currency = output.Currency
+		output.Items[i].TotalWithTax.Currency = output.Currency
+	}
+	if output.ConvertedTotal != nil {
+		output.ConvertedTotal.Total.Currency = output.ConvertedTotal.Currency
+	}
+
+	return output, nil
+}
+
+func FromEntityToOrderTransitionsOutput(order *entity.Order) OrderTransitionsOutput {
+	transitions := []string{}
+	for _, status := range order.AllowedTransitions() {
+		transitions = append(transitions, status.String())
+	}
+
+	return OrderTransitionsOutput{
+		ID:          order.ID,
+		Status:      order.Status.String(),
+		Transitions: transitions,
+	}
+}
+
+func FromEntityToOrderHistoryOutput(orderID string, history []entity.StatusChange) OrderHistoryOutput {
+	output := OrderHistoryOutput{
+		ID:      orderID,
+		History: []StatusChangeOutput{},
+	}
+	for _, change := range history {
+		var from *string
+		if change.From != nil {
+			status := change.From.String()
+			from = &status
+		}
+		output.History = append(output.History, StatusChangeOutput{
+			From:      from,
+			To:        change.To.String(),
+			ChangedBy: change.ChangedBy,
+			Reason:    change.Reason,
+			ChangedAt: change.ChangedAt,
+		})
 	}
+	return output
 }
@@ -0,0 +1,104 @@
+package dtos
+
+import (
+	"sort"
+
+	"order-service/internal/application/patch"
+	"order-service/internal/domain/entity"
+)
+
+// OrderPatchInput carries a patch document in one of the patch.Formats.
+type OrderPatchInput struct {
+	Format patch.Format
+	Patch  []byte
+}
+
+// OrderPatchDocument is the representation of an order that patches are applied to. Items are keyed
+// by their ID so that a patch can add, change or remove one item without resending the others.
+type OrderPatchDocument struct {
+	CustomerID      string                       `json:"customer_id,omitempty"`
+	CustomerName    string                       `json:"customer_name"`
+	Currency        string                       `json:"currency"`
+	Items           map[string]ItemPatchDocument `json:"items"`
+	CouponCode      string                       `json:"coupon_code,omitempty"`
+	TaxJurisdiction string                       `json:"tax_jurisdiction,omitempty"`
+	ShippingAddress *Address                     `json:"shipping_address,omitempty"`
+	BillingAddress  *Address                     `json:"billing_address,omitempty"`
+	DeliveryMethod  string                       `json:"delivery_method,omitempty"`
+	DeliveryWindow  *DeliveryWindow              `json:"delivery_window,omitempty"`
+}
+
+func FromEntityToOrderPatchDocument(order *entity.Order) OrderPatchDocument {
+	items := make(map[string]ItemPatchDocument, len(order.Items))
+	for _, item := range order.Items {
+		items[item.ID] = ItemPatchDocument{
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Category:  item.Category,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			Currency:  order.Currency,
+		}
+	}
+
+	return OrderPatchDocument{
+		CustomerID:      order.CustomerID,
+		CustomerName:    order.CustomerName,
+		Currency:        order.Currency,
+		Items:           items,
+		CouponCode:      order.CouponCode,
+		TaxJurisdiction: order.TaxJurisdiction,
+		ShippingAddress: fromEntityAddress(order.ShippingAddress),
+		BillingAddress:  fromEntityAddress(order.BillingAddress),
+		DeliveryMethod:  string(order.DeliveryMethod),
+		DeliveryWindow:  fromEntityDeliveryWindow(order.DeliveryWindow),
+	}
+}
+
+// ToOrderInput converts the patched document back to an order input. Items listed in itemOrder keep
+// that order and new items follow sorted by ID; the returned IDs give the item ID at each index.
+func (d OrderPatchDocument) ToOrderInput(itemOrder []string) (OrderInput, []string) {
+	ids := make([]string, 0, len(d.Items))
+	listed := make(map[string]bool, len(itemOrder))
+	for _, id := range itemOrder {
+		listed[id] = true
+		if _, ok := d.Items[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+
+	var added []string
+	for id := range d.Items {
+		if !listed[id] {
+			added = append(added, id)
+		}
+	}
+	sort.Strings(added)
+	ids = append(ids, added...)
+
+	input := OrderInput{
+		CustomerID:      d.CustomerID,
+		CustomerName:    d.CustomerName,
+		Currency:        d.Currency,
+		Items:           make([]ItemInput, 0, len(ids)),
+		CouponCode:      d.CouponCode,
+		TaxJurisdiction: d.TaxJurisdiction,
+		ShippingAddress: d.ShippingAddress,
+		BillingAddress:  d.BillingAddress,
+		DeliveryMethod:  d.DeliveryMethod,
+		DeliveryWindow:  d.DeliveryWindow,
+	}
+	for _, id := range ids {
+		item := d.Items[id]
+		input.Items = append(input.Items, ItemInput{
+			ID:        id,
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Category:  item.Category,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			Currency:  item.Currency,
+		})
+	}
+	return input, ids
+}
@@ -0,0 +1,290 @@
+package dtos
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/entity"
+)
+
+// Validate reports every invalid field of the order at once.
+func (i OrderInput) Validate() error {
+	var errs validation.Errors
+
+	// Orders of a known customer may leave the name out and are named after the customer.
+	if utf8.RuneCountInString(i.CustomerID) > validation.MaxCustomerIDLength {
+		errs.Add(validation.Pointer("customer_id"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxCustomerIDLength)
+	}
+	if i.CustomerID == "" || i.CustomerName != "" {
+		validateName(&errs, validation.Pointer("customer_name"), i.CustomerName)
+	}
+
+	if i.Currency != "" {
+		validateCurrency(&errs, validation.Pointer("currency"), i.Currency)
+	}
+
+	if utf8.RuneCountInString(i.CouponCode) > validation.MaxCouponCodeLength {
+		errs.Add(validation.Pointer("coupon_code"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxCouponCodeLength)
+	}
+	if utf8.RuneCountInString(i.TaxJurisdiction) > validation.MaxJurisdictionLength {
+		errs.Add(validation.Pointer("tax_jurisdiction"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxJurisdictionLength)
+	}
+
+	validateDelivery(&errs, i)
+
+	switch {
+	case len(i.Items) == 0:
+		errs.Add(validation.Pointer("items"), validation.CodeRequired, "must contain at least one item")
+	case len(i.Items) > validation.MaxItemsPerOrder:
+		errs.Add(validation.Pointer("items"), validation.CodeTooMany, "must not contain more than %d items", validation.MaxItemsPerOrder)
+	}
+
+	seen := make(map[string]int, len(i.Items))
+	for index, item := range i.Items {
+		item.validate(&errs, []any{"items", index}, i.Currency)
+
+		if item.ID == "" {
+			continue
+		}
+		if first, duplicated := seen[item.ID]; duplicated {
+			errs.Add(validation.Pointer("items", index, "id"), validation.CodeDuplicate, "duplicates the id of item %d", first)
+			continue
+		}
+		seen[item.ID] = index
+	}
+
+	return errs.Err()
+}
+
+// Validate reports every invalid field of a new customer.
+func (i CustomerInput) Validate() error {
+	var errs validation.Errors
+
+	validateName(&errs, validation.Pointer("name"), i.Name)
+	switch {
+	case i.Email == "":
+	case utf8.RuneCountInString(i.Email) > validation.MaxNameLength:
+		errs.Add(validation.Pointer("email"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxNameLength)
+	case !isEmailAddress(i.Email):
+		errs.Add(validation.Pointer("email"), validation.CodeInvalidFormat, "must be an email address such as john@example.com")
+	}
+
+	return errs.Err()
+}
+
+// Validate reports every invalid field of an API key to issue.
+func (i APIKeyInput) Validate() error {
+	var errs validation.Errors
+
+	validateName(&errs, validation.Pointer("name"), i.Name)
+	if len(i.Scopes) == 0 {
+		errs.Add(validation.Pointer("scopes"), validation.CodeRequired, "must contain at least one scope")
+	}
+	seen := make(map[string]int, len(i.Scopes))
+	for index, scope := range i.Scopes {
+		pointer := validation.Pointer("scopes", index)
+		if _, err := auth.ParseScope(scope); err != nil {
+			errs.Add(pointer, validation.CodeUnknownScope, "must be one of %s", joinScopes(auth.Scopes))
+			continue
+		}
+		if first, duplicated := seen[scope]; duplicated {
+			errs.Add(pointer, validation.CodeDuplicate, "duplicates scope %d", first)
+			continue
+		}
+		seen[scope] = index
+	}
+
+	return errs.Err()
+}
+
+// Validate reports an invalid grace period, which must be a duration such as 24h.
+func (i RotateAPIKeyInput) Validate() error {
+	var errs validation.Errors
+
+	if i.GracePeriod != "" {
+		pointer := validation.Pointer("grace_period")
+		grace, err := time.ParseDuration(i.GracePeriod)
+		switch {
+		case err != nil:
+			errs.Add(pointer, validation.CodeInvalidFormat, "must be a duration such as 24h")
+		case grace < 0:
+			errs.Add(pointer, validation.CodeTooSmall, "must not be negative")
+		case grace > validation.MaxAPIKeyGracePeriod:
+			errs.Add(pointer, validation.CodeTooLarge, "must not be longer than %s", validation.MaxAPIKeyGracePeriod)
+		}
+	}
+
+	return errs.Err()
+}
+
+func joinScopes(scopes []auth.Scope) string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+	return strings.Join(values, ", ")
+}
+
+// isEmailAddress accepts a bare address, without a display name or angle brackets.
+func isEmailAddress(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value
+}
+
+// Validate reports every invalid field of an item added on its own to an order in orderCurrency.
+func (i ItemInput) Validate(orderCurrency string) error {
+	var errs validation.Errors
+	i.validate(&errs, nil, orderCurrency)
+	return errs.Err()
+}
+
+// validate checks the item found at the base pointer tokens of the request body.
+func (i ItemInput) validate(errs *validation.Errors, base []any, orderCurrency string) {
+	field := func(name string) string {
+		return validation.Pointer(append(base[:len(base):len(base)], name)...)
+	}
+
+	if utf8.RuneCountInString(i.ID) > validation.MaxItemIDLength {
+		errs.Add(field("id"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxItemIDLength)
+	}
+	if utf8.RuneCountInString(i.ProductID) > validation.MaxProductIDLength {
+		errs.Add(field("product_id"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxProductIDLength)
+	}
+	if utf8.RuneCountInString(i.Category) > validation.MaxCategoryLength {
+		errs.Add(field("category"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxCategoryLength)
+	}
+
+	validateName(errs, field("name"), i.Name)
+	validateQuantity(errs, field("quantity"), i.Quantity)
+	validatePrice(errs, field("price"), i.Price)
+
+	// Without an order currency the items are checked against the currency of the stored order.
+	switch {
+	case i.Currency == "":
+	case validateCurrency(errs, field("currency"), i.Currency):
+	case orderCurrency != "" && i.Currency != orderCurrency:
+		errs.Add(field("currency"), validation.CodeCurrencyMismatch, "must match the order currency %s", orderCurrency)
+	}
+}
+
+// Validate reports every invalid field of a partial item update, which must change at least one field.
+func (i ItemUpdateInput) Validate() error {
+	var errs validation.Errors
+
+	if i.Name == nil && i.Quantity == nil && i.Price == nil {
+		errs.Add("", validation.CodeRequired, "must change at least one of name, quantity or price")
+	}
+	if i.Name != nil {
+		validateName(&errs, validation.Pointer("name"), *i.Name)
+	}
+	if i.Quantity != nil {
+		validateQuantity(&errs, validation.Pointer("quantity"), *i.Quantity)
+	}
+	if i.Price != nil {
+		validatePrice(&errs, validation.Pointer("price"), *i.Price)
+	}
+
+	return errs.Err()
+}
+
+// validateDelivery checks the addresses, delivery method and delivery window of an order.
+func validateDelivery(errs *validation.Errors, i OrderInput) {
+	if i.ShippingAddress != nil {
+		validateAddress(errs, "shipping_address", *i.ShippingAddress)
+	}
+	if i.BillingAddress != nil {
+		validateAddress(errs, "billing_address", *i.BillingAddress)
+	}
+
+	method := entity.DeliveryMethod(i.DeliveryMethod)
+	switch {
+	case method == "":
+	case !method.IsValid():
+		methods := make([]string, 0, len(entity.DeliveryMethods))
+		for _, supported := range entity.DeliveryMethods {
+			methods = append(methods, string(supported))
+		}
+		errs.Add(validation.Pointer("delivery_method"), validation.CodeUnsupportedDelivery, "must be one of %s", strings.Join(methods, ", "))
+	case method != entity.DeliveryPickup && i.ShippingAddress == nil:
+		errs.Add(validation.Pointer("shipping_address"), validation.CodeRequired, "must be set for %s delivery", method)
+	}
+
+	if window := i.DeliveryWindow; window != nil {
+		switch {
+		case window.Start.IsZero():
+			errs.Add(validation.Pointer("delivery_window", "start"), validation.CodeRequired, "must be set")
+		case window.End.IsZero():
+			errs.Add(validation.Pointer("delivery_window", "end"), validation.CodeRequired, "must be set")
+		case !window.Start.Before(window.End):
+			errs.Add(validation.Pointer("delivery_window", "end"), validation.CodeInvalidRange, "must be after the start of the window")
+		}
+	}
+}
+
+func validateAddress(errs *validation.Errors, name string, address Address) {
+	field := func(field string) string { return validation.Pointer(name, field) }
+
+	validateAddressLine(errs, field("line1"), address.Line1, true)
+	validateAddressLine(errs, field("line2"), address.Line2, false)
+	validateAddressLine(errs, field("city"), address.City, true)
+	if utf8.RuneCountInString(address.State) > validation.MaxStateLength {
+		errs.Add(field("state"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxStateLength)
+	}
+
+	if entity.ValidateCountry(address.Country) != nil {
+		errs.Add(field("country"), validation.CodeInvalidFormat, "must be an ISO 3166-1 alpha-2 code such as BR")
+		return
+	}
+	switch {
+	case utf8.RuneCountInString(address.PostalCode) > validation.MaxPostalCodeLength:
+		errs.Add(field("postal_code"), validation.CodeTooLong, "must not be longer than %d characters", validation.MaxPostalCodeLength)
+	case entity.ValidatePostalCode(address.Country, address.PostalCode) != nil:
+		errs.Add(field("postal_code"), validation.CodeInvalidFormat, "is not a valid postal code for %s", address.Country)
+	}
+}
+
+func validateAddressLine(errs *validation.Errors, pointer, line string, required bool) {
+	switch {
+	case required && strings.TrimSpace(line) == "":
+		errs.Add(pointer, validation.CodeRequired, "must not be empty")
+	case utf8.RuneCountInString(line) > validation.MaxNameLength:
+		errs.Add(pointer, validation.CodeTooLong, "must not be longer than %d characters", validation.MaxNameLength)
+	}
+}
+
+func validateQuantity(errs *validation.Errors, pointer string, quantity int) {
+	switch {
+	case quantity < 1:
+		errs.Add(pointer, validation.CodeTooSmall, "must be at least 1")
+	case quantity > validation.MaxItemQuantity:
+		errs.Add(pointer, validation.CodeTooLarge, "must not be greater than %d", validation.MaxItemQuantity)
+	}
+}
+
+func validatePrice(errs *validation.Errors, pointer string, price entity.Money) {
+	if !price.IsPositive() {
+		errs.Add(pointer, validation.CodeTooSmall, "must be greater than zero")
+	}
+}
+
+// validateCurrency records an error for unsupported currencies and reports whether it did.
+func validateCurrency(errs *validation.Errors, pointer, currency string) bool {
+	if entity.ValidateCurrency(currency) == nil {
+		return false
+	}
+	errs.Add(pointer, validation.CodeUnsupportedCurrency, "must be one of %s", strings.Join(entity.SupportedCurrencies, ", "))
+	return true
+}
+
+func validateName(errs *validation.Errors, pointer, name string) {
+	switch {
+	case strings.TrimSpace(name) == "":
+		errs.Add(pointer, validation.CodeRequired, "must not be empty")
+	case utf8.RuneCountInString(name) > validation.MaxNameLength:
+		errs.Add(pointer, validation.CodeTooLong, "must not be longer than %d characters", validation.MaxNameLength)
+	}
+}
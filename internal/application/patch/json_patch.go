@@ -0,0 +1,349 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+
+	value any
+}
+
+func decodeOperations(data []byte) ([]operation, error) {
+	var operations []operation
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return nil, fmt.Errorf("%w: a JSON Patch must be an array of operations: %v", ErrInvalidPatch, err)
+	}
+
+	for i := range operations {
+		op := &operations[i]
+		if op.Path == nil {
+			return nil, fmt.Errorf("%w: operation %d has no path", ErrInvalidPatch, i)
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, fmt.Errorf("%w: %s operation %d has no value", ErrInvalidPatch, op.Op, i)
+			}
+			value, err := decode(op.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+			}
+			op.value = value
+		case "move", "copy":
+			if op.From == nil {
+				return nil, fmt.Errorf("%w: %s operation %d has no from", ErrInvalidPatch, op.Op, i)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("%w: operation %d has unknown op %q", ErrInvalidPatch, i, op.Op)
+		}
+	}
+	return operations, nil
+}
+
+// applyOperations applies the operations in order; the patch is atomic, so any failure discards the result.
+func applyOperations(document any, operations []operation) (any, error) {
+	for _, op := range operations {
+		path, err := parsePointer(*op.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch op.Op {
+		case "add":
+			document, err = add(document, path, op.value)
+		case "remove":
+			document, _, err = remove(document, path)
+		case "replace":
+			document, err = replace(document, path, op.value)
+		case "move":
+			document, err = move(document, *op.From, path)
+		case "copy":
+			var from []string
+			var value any
+			if from, err = parsePointer(*op.From); err == nil {
+				value, err = get(document, from)
+			}
+			if err == nil {
+				document, err = add(document, path, clone(value))
+			}
+		case "test":
+			var value any
+			if value, err = get(document, path); err == nil && !equal(value, op.value) {
+				err = fmt.Errorf("%w: test failed at %q", ErrNotApplicable, *op.Path)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return document, nil
+}
+
+func replace(document any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	document, _, err := remove(document, path)
+	if err != nil {
+		return nil, err
+	}
+	return add(document, path, value)
+}
+
+func move(document any, fromPointer string, path []string) (any, error) {
+	from, err := parsePointer(fromPointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(path) > len(from) && isPrefix(from, path) {
+		return nil, fmt.Errorf("%w: cannot move %q into one of its children", ErrNotApplicable, fromPointer)
+	}
+
+	document, value, err := remove(document, from)
+	if err != nil {
+		return nil, err
+	}
+	return add(document, path, value)
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %q is not a JSON pointer", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(node any, path []string) (any, error) {
+	for i, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, missing(path[:i+1])
+			}
+			node = child
+		case []any:
+			index, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, missing(path[:i+1])
+			}
+			node = n[index]
+		default:
+			return nil, missing(path[:i+1])
+		}
+	}
+	return node, nil
+}
+
+// add returns node with value added at path. Arrays are rebuilt, so callers store the returned node.
+func add(node any, path []string, value any) (any, error) {
+	return addAt(node, path, 0, value)
+}
+
+func addAt(node any, path []string, at int, value any) (any, error) {
+	if at == len(path) {
+		return value, nil
+	}
+
+	token, last := path[at], at == len(path)-1
+	switch n := node.(type) {
+	case map[string]any:
+		if last {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, missing(path[:at+1])
+		}
+		updated, err := addAt(child, path, at+1, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+	case []any:
+		if last {
+			index := len(n)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(n)); err != nil {
+					return nil, missing(path[:at+1])
+				}
+			}
+			n = append(n, nil)
+			copy(n[index+1:], n[index:])
+			n[index] = value
+			return n, nil
+		}
+		index, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, missing(path[:at+1])
+		}
+		if n[index], err = addAt(n[index], path, at+1, value); err != nil {
+			return nil, err
+		}
+		return n, nil
+	default:
+		return nil, missing(path[:at+1])
+	}
+}
+
+// remove returns node without the value at path, along with the removed value.
+func remove(node any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: the whole document cannot be removed", ErrNotApplicable)
+	}
+	return removeAt(node, path, 0)
+}
+
+func removeAt(node any, path []string, at int) (any, any, error) {
+	token, last := path[at], at == len(path)-1
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, missing(path[:at+1])
+		}
+		if last {
+			delete(n, token)
+			return n, child, nil
+		}
+		updated, removed, err := removeAt(child, path, at+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = updated
+		return n, removed, nil
+	case []any:
+		index, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, nil, missing(path[:at+1])
+		}
+		if last {
+			removed := n[index]
+			return append(n[:index], n[index+1:]...), removed, nil
+		}
+		updated, removed, err := removeAt(n[index], path, at+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[index] = updated
+		return n, removed, nil
+	default:
+		return nil, nil, missing(path[:at+1])
+	}
+}
+
+// arrayIndex parses an array index token, which must not have leading zeros or exceed limit.
+func arrayIndex(token string, limit int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > limit {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return index, nil
+}
+
+func missing(path []string) error {
+	return fmt.Errorf("%w: %q does not exist", ErrNotApplicable, formatPointer(path))
+}
+
+func formatPointer(path []string) string {
+	var b strings.Builder
+	for _, token := range path {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+func isPrefix(prefix, path []string) bool {
+	for i, token := range prefix {
+		if path[i] != token {
+			return false
+		}
+	}
+	return true
+}
+
+func clone(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		cloned := make(map[string]any, len(v))
+		for key, child := range v {
+			cloned[key] = clone(child)
+		}
+		return cloned
+	case []any:
+		cloned := make([]any, len(v))
+		for i, child := range v {
+			cloned[i] = clone(child)
+		}
+		return cloned
+	default:
+		return v
+	}
+}
+
+// equal compares JSON values as RFC 6902 section 4.6 requires, treating numbers by their value.
+func equal(a, b any) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		xr, xok := new(big.Rat).SetString(string(x))
+		yr, yok := new(big.Rat).SetString(string(y))
+		return xok && yok && xr.Cmp(yr) == 0
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,104 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format identifies a patch document type by its media type.
+type Format string
+
+const (
+	// MergePatch is an RFC 7396 JSON Merge Patch.
+	MergePatch Format = "application/merge-patch+json"
+	// JSONPatch is an RFC 6902 JSON Patch.
+	JSONPatch Format = "application/json-patch+json"
+)
+
+// Formats lists the supported patch formats, e.g. for an Accept-Patch header.
+var Formats = []Format{MergePatch, JSONPatch}
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported patch format")
+	ErrInvalidPatch      = errors.New("invalid patch document")
+	ErrNotApplicable     = errors.New("patch cannot be applied")
+)
+
+// ParseFormat returns the patch format for a media type.
+func ParseFormat(mediaType string) (Format, error) {
+	for _, format := range Formats {
+		if Format(mediaType) == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, mediaType)
+}
+
+// Apply applies a patch document to a JSON document and returns the patched JSON document.
+func Apply(format Format, document, patch []byte) ([]byte, error) {
+	target, err := decode(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched any
+	switch format {
+	case MergePatch:
+		var mergePatch any
+		if mergePatch, err = decode(patch); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		patched = merge(target, mergePatch)
+	case JSONPatch:
+		var operations []operation
+		if operations, err = decodeOperations(patch); err != nil {
+			return nil, err
+		}
+		if patched, err = applyOperations(target, operations); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	return json.Marshal(patched)
+}
+
+// merge implements the MergePatch algorithm of RFC 7396, section 2.
+func merge(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = merge(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// decode unmarshals a single JSON value, keeping numbers as json.Number so they survive unchanged.
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
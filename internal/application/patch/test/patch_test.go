@@ -0,0 +1,108 @@
+package patch_test
+
+import (
+	"testing"
+
+	"order-service/internal/application/patch"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply_MergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{"replace value", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add value", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"remove value", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"replace array", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"nested object", `{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"x","d":null}}`, `{"a":{"b":"x"}}`},
+		{"object replaces scalar", `{"a":"b"}`, `{"a":{"c":"d"}}`, `{"a":{"c":"d"}}`},
+		{"non-object patch replaces document", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"keeps exact numbers", `{"price":12345678901234.56}`, `{"quantity":3}`, `{"price":12345678901234.56,"quantity":3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, err := patch.Apply(patch.MergePatch, []byte(tt.document), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(patched))
+		})
+	}
+}
+
+func TestApply_JSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{"add object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"append array element", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+		{"remove object member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"copy value", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/baz/bar","value":2}]`, `{"foo":{"bar":1},"baz":{"bar":2}}`},
+		{"test number by value", `{"baz":1}`, `[{"op":"test","path":"/baz","value":1.0},{"op":"add","path":"/ok","value":true}]`, `{"baz":1,"ok":true}`},
+		{"escaped pointer", `{"a/b":{"m~n":1}}`, `[{"op":"replace","path":"/a~1b/m~0n","value":null}]`, `{"a/b":{"m~n":null}}`},
+		{"replace document", `{"foo":"bar"}`, `[{"op":"replace","path":"","value":{"baz":"qux"}}]`, `{"baz":"qux"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, err := patch.Apply(patch.JSONPatch, []byte(tt.document), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(patched))
+		})
+	}
+}
+
+func TestApply_JSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		expected error
+	}{
+		{"not an array", `{"op":"add"}`, patch.ErrInvalidPatch},
+		{"unknown op", `[{"op":"merge","path":"/foo"}]`, patch.ErrInvalidPatch},
+		{"missing path", `[{"op":"remove"}]`, patch.ErrInvalidPatch},
+		{"missing value", `[{"op":"add","path":"/foo"}]`, patch.ErrInvalidPatch},
+		{"missing from", `[{"op":"move","path":"/foo"}]`, patch.ErrInvalidPatch},
+		{"invalid pointer", `[{"op":"remove","path":"foo"}]`, patch.ErrInvalidPatch},
+		{"remove missing member", `[{"op":"remove","path":"/missing"}]`, patch.ErrNotApplicable},
+		{"replace missing member", `[{"op":"replace","path":"/missing","value":1}]`, patch.ErrNotApplicable},
+		{"add below missing member", `[{"op":"add","path":"/missing/foo","value":1}]`, patch.ErrNotApplicable},
+		{"array index out of range", `[{"op":"add","path":"/list/5","value":1}]`, patch.ErrNotApplicable},
+		{"array index with leading zero", `[{"op":"remove","path":"/list/01"}]`, patch.ErrNotApplicable},
+		{"failed test", `[{"op":"test","path":"/foo","value":"baz"}]`, patch.ErrNotApplicable},
+		{"move into own child", `[{"op":"move","from":"/list","path":"/list/0"}]`, patch.ErrNotApplicable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := patch.Apply(patch.JSONPatch, []byte(`{"foo":"bar","list":[1,2]}`), []byte(tt.patch))
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestApply_InvalidMergePatch(t *testing.T) {
+	_, err := patch.Apply(patch.MergePatch, []byte(`{"foo":"bar"}`), []byte(`{"foo":`))
+	assert.ErrorIs(t, err, patch.ErrInvalidPatch)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := patch.ParseFormat("application/merge-patch+json")
+	require.NoError(t, err)
+	assert.Equal(t, patch.MergePatch, format)
+
+	_, err = patch.ParseFormat("application/json")
+	assert.ErrorIs(t, err, patch.ErrUnsupportedFormat)
+}
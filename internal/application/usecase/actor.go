@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/domain/auth"
+)
+
+// Actors recorded in the order status history for changes made by this service.
+const (
+	ActorAPI             = "api"
+	ActorOrderProcessing = "order_processing"
+)
+
+// actor names who made an API change for the status history: the subject of the caller, such as a
+// user or "api-key:<id>", or ActorAPI when the API runs without authentication.
+func actor(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok && principal.Subject != "" {
+		return principal.Subject
+	}
+	return ActorAPI
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+	"order-service/internal/domain/tax"
+)
+
+type AddOrderItemUseCase interface {
+	Execute(ctx context.Context, orderID string, expectedVersion int64, input dtos.ItemInput) (dtos.OrderOutput, error)
+}
+
+type addOrderItemUseCase struct {
+	orderRepository repository.OrderRepository
+	pricer          *ItemPricer
+	stock           stockReservations
+	coupons         coupons
+	taxes           taxes
+}
+
+// NewAddOrderItemUseCase builds the use case; pricer, inventoryService, promotionRepo and
+// taxCalculator may be nil when no product catalog, inventory, promotions or taxes are configured.
+func NewAddOrderItemUseCase(orderRepo repository.OrderRepository, pricer *ItemPricer, inventoryService inventory.InventoryService, promotionRepo repository.PromotionRepository, taxCalculator tax.Calculator) AddOrderItemUseCase {
+	return &addOrderItemUseCase{
+		orderRepository: orderRepo,
+		pricer:          pricer,
+		stock:           stockReservations{inventory: inventoryService},
+		coupons:         coupons{promotions: promotionRepo},
+		taxes:           taxes{calculator: taxCalculator},
+	}
+}
+
+// Execute appends an item to a pending order, generating its line ID when the input has none.
+func (u *addOrderItemUseCase) Execute(ctx context.Context, orderID string, expectedVersion int64, input dtos.ItemInput) (dtos.OrderOutput, error) {
+	order, err := findEditableOrder(ctx, u.orderRepository, orderID, expectedVersion)
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	adopted, err := adoptLegacyItemIDs([]dtos.ItemInput{input}, nil, func(int) string { return validation.Pointer("id") })
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	input = adopted[0]
+	if err := u.pricer.PriceItem(ctx, &input, order.Currency); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := input.Validate(order.Currency); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if len(order.Items) >= validation.MaxItemsPerOrder {
+		var errs validation.Errors
+		errs.Add("", validation.CodeTooMany, "order must not contain more than %d items", validation.MaxItemsPerOrder)
+		return dtos.OrderOutput{}, errs
+	}
+
+	items, err := buildItems([]dtos.ItemInput{input}, order.Currency, nil)
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	if err := order.AddItem(items[0]); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.coupons.apply(ctx, order, order.CouponCode); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.taxes.apply(ctx, order, order.TaxJurisdiction); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	err = saveOrder(ctx, u.orderRepository, order, u.stock.adjust(order))
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	return dtos.FromEntityToOrderOutput(order), nil
+}
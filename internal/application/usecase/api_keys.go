@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+func findAPIKey(ctx context.Context, apiKeyRepo repository.APIKeyRepository, id string) (*auth.APIKey, error) {
+	key, err := apiKeyRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// parseScopes converts the scopes of a validated input.
+func parseScopes(values []string) []auth.Scope {
+	scopes := make([]auth.Scope, 0, len(values))
+	for _, value := range values {
+		scope, _ := auth.ParseScope(value)
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
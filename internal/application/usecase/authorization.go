@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/entity"
+)
+
+// authorizeOrder checks that the caller may see and change an order. Calls without a principal,
+// such as those of the order processing consumer, reach every order.
+func authorizeOrder(ctx context.Context, order *entity.Order) error {
+	return authorizeCustomer(ctx, order.CustomerID)
+}
+
+// authorizeCustomer checks that the caller may act for a customer.
+func authorizeCustomer(ctx context.Context, customerID string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.CanActFor(customerID) {
+		return nil
+	}
+	return auth.ErrForbidden
+}
+
+// requireRole checks that the caller has a role. Calls without a principal are trusted.
+func requireRole(ctx context.Context, role auth.Role) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.HasRole(role) {
+		return nil
+	}
+	return auth.ErrForbidden
+}
+
+// requireScope checks that the caller may perform the operations of a scope. Calls without a
+// principal are trusted.
+func requireScope(ctx context.Context, scope auth.Scope) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.Allows(scope) {
+		return nil
+	}
+	return auth.ErrForbidden
+}
+
+// scopeCustomerID keeps customers to their own orders: a customer id left out of an order input or
+// list query becomes the customer of the caller, and the id of another customer is forbidden.
+func scopeCustomerID(ctx context.Context, customerID *string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.ActsForAllCustomers() {
+		return nil
+	}
+	if *customerID == "" {
+		*customerID = principal.CustomerID
+	}
+	return authorizeCustomer(ctx, *customerID)
+}
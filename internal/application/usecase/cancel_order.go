@@ -5,43 +5,63 @@ import (
 	"errors"
 
 	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
 	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
 	"order-service/internal/domain/repository"
 )
 
 type CancelOrderUseCase interface {
-	Execute(ctx context.Context, id string) (dtos.OrderOutput, error)
+	Execute(ctx context.Context, id string, expectedVersion int64, reason string) (dtos.OrderOutput, error)
 }
 
 type cancelOrderUseCase struct {
 	orderRepository repository.OrderRepository
+	stock           stockReservations
+	coupons         coupons
 }
 
-func NewCancelOrderUseCase(orderRepo repository.OrderRepository) CancelOrderUseCase {
+// NewCancelOrderUseCase builds the use case; inventoryService and promotionRepo may be nil
+// when no inventory or promotions are configured.
+func NewCancelOrderUseCase(orderRepo repository.OrderRepository, inventoryService inventory.InventoryService, promotionRepo repository.PromotionRepository) CancelOrderUseCase {
 	return &cancelOrderUseCase{
 		orderRepository: orderRepo,
+		stock:           stockReservations{inventory: inventoryService},
+		coupons:         coupons{promotions: promotionRepo},
 	}
 }
 
-func (u *cancelOrderUseCase) Execute(ctx context.Context, id string) (dtos.OrderOutput, error) {
+func (u *cancelOrderUseCase) Execute(ctx context.Context, id string, expectedVersion int64, reason string) (dtos.OrderOutput, error) {
+	if err := requireScope(ctx, auth.ScopeOrdersCancel); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
 	order, err := u.orderRepository.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return dtos.OrderOutput{}, errors.New("order not found")
+			return dtos.OrderOutput{}, ErrOrderNotFound
 		}
 		return dtos.OrderOutput{}, err
 	}
 
-	if order.Status != entity.Pending {
-		return dtos.OrderOutput{}, errors.New("only pending orders can be canceled")
+	if err := authorizeOrder(ctx, order); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := checkVersion(order, expectedVersion); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	// Canceling again must not release the coupon and stock a second time.
+	if order.Status == entity.Canceled {
+		return dtos.OrderOutput{}, ErrOrderAlreadyCanceled
 	}
 
-	err = order.SetStatus(entity.Canceled)
+	err = order.ChangeStatus(entity.Canceled, actor(ctx), reason)
 	if err != nil {
 		return dtos.OrderOutput{}, err
 	}
 
-	err = u.orderRepository.Save(ctx, order)
+	err = saveOrder(ctx, u.orderRepository, order, u.coupons.release(order), u.stock.release(order))
 	if err != nil {
 		return dtos.OrderOutput{}, err
 	}
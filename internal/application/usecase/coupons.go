@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+)
+
+// coupons applies the promotions behind coupon codes to orders. Without a promotion repository every
+// coupon code is unknown.
+type coupons struct {
+	promotions repository.PromotionRepository
+}
+
+// apply works the discounts of couponCode out again for the current items of a pending order. The
+// validity window is only checked when the code is new to the order, so later changes to an order
+// keep the promotion it was placed with.
+func (c coupons) apply(ctx context.Context, order *entity.Order, couponCode string) error {
+	if couponCode == "" {
+		return order.ApplyDiscounts("", nil)
+	}
+
+	var promotion *entity.Promotion
+	err := repository.ErrPromotionNotFound
+	if c.promotions != nil {
+		promotion, err = c.promotions.FindByCode(ctx, couponCode)
+	}
+	if errors.Is(err, repository.ErrPromotionNotFound) {
+		return couponError(validation.CodeUnknownCoupon, "is not a valid coupon code")
+	}
+	if err != nil {
+		return err
+	}
+
+	if couponCode != order.CouponCode && !promotion.ActiveAt(time.Now()) {
+		return couponError(validation.CodeCouponInactive, "is not valid at this time")
+	}
+
+	discounts, err := promotion.Discounts(order)
+	if errors.Is(err, entity.ErrPromotionNotApplicable) {
+		return couponError(validation.CodeCouponNotApplicable, "does not apply to the items of the order")
+	}
+	if err != nil {
+		return err
+	}
+
+	return order.ApplyDiscounts(couponCode, discounts)
+}
+
+// redeem moves the use of a promotion from the coupon code an order had before to its current one.
+func (c coupons) redeem(order *entity.Order, previousCode string) sideEffect {
+	code := order.CouponCode
+	if c.promotions == nil || code == previousCode {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		if err := c.change(ctx, order.ID, previousCode, code); err != nil {
+			if errors.Is(err, entity.ErrPromotionExhausted) {
+				return couponError(validation.CodeCouponExhausted, "has reached its usage limit")
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// release gives back the use of the promotion of a canceled order.
+func (c coupons) release(order *entity.Order) sideEffect {
+	code := order.CouponCode
+	if c.promotions == nil || code == "" {
+		return nil
+	}
+	return func(ctx context.Context) error { return c.promotions.Release(ctx, code, order.ID) }
+}
+
+func (c coupons) change(ctx context.Context, orderID, from, to string) error {
+	if to != "" {
+		if err := c.promotions.Redeem(ctx, to, orderID); err != nil {
+			return err
+		}
+	}
+	if from != "" {
+		return c.promotions.Release(ctx, from, orderID)
+	}
+	return nil
+}
+
+func couponError(code, message string) error {
+	var errs validation.Errors
+	errs.Add(validation.Pointer("coupon_code"), code, message)
+	return errs
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+)
+
+type CreateCustomerUseCase interface {
+	Execute(ctx context.Context, input dtos.CustomerInput) (dtos.CustomerOutput, error)
+}
+
+type createCustomerUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewCreateCustomerUseCase(customerRepo repository.CustomerRepository) CreateCustomerUseCase {
+	return &createCustomerUseCase{
+		customerRepository: customerRepo,
+	}
+}
+
+func (u *createCustomerUseCase) Execute(ctx context.Context, input dtos.CustomerInput) (dtos.CustomerOutput, error) {
+	if err := requireRole(ctx, auth.RoleSupport); err != nil {
+		return dtos.CustomerOutput{}, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return dtos.CustomerOutput{}, err
+	}
+
+	customer, err := entity.NewCustomer(generateID(), input.Name, input.Email)
+	if err != nil {
+		return dtos.CustomerOutput{}, err
+	}
+
+	if err := u.customerRepository.Save(ctx, customer); err != nil {
+		return dtos.CustomerOutput{}, err
+	}
+
+	return dtos.FromEntityToCustomerOutput(customer), nil
+}
@@ -2,12 +2,14 @@ package usecase
 
 import (
 	"context"
-	"errors"
 
 	"order-service/internal/application/dtos"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/auth"
 	"order-service/internal/domain/entity"
-	"order-service/internal/domain/publisher"
+	"order-service/internal/domain/inventory"
 	"order-service/internal/domain/repository"
+	"order-service/internal/domain/tax"
 
 	"github.com/google/uuid"
 )
@@ -18,41 +20,77 @@ type CreateOrderUseCase interface {
 
 type createOrderUseCase struct {
 	orderRepository repository.OrderRepository
-	orderPublisher  publisher.OrderPublisher
+	pricer          *ItemPricer
+	stock           stockReservations
+	coupons         coupons
+	taxes           taxes
+	customers       customers
 }
 
-func NewCreateOrderUseCase(orderRepo repository.OrderRepository, orderPub publisher.OrderPublisher) CreateOrderUseCase {
+// NewCreateOrderUseCase builds the use case; pricer, inventoryService, promotionRepo and
+// taxCalculator may be nil when no product catalog, inventory, promotions or taxes are configured,
+// and customerRepo when orders are not linked to customers.
+func NewCreateOrderUseCase(orderRepo repository.OrderRepository, pricer *ItemPricer, inventoryService inventory.InventoryService, promotionRepo repository.PromotionRepository, taxCalculator tax.Calculator, customerRepo repository.CustomerRepository) CreateOrderUseCase {
 	return &createOrderUseCase{
 		orderRepository: orderRepo,
-		orderPublisher:  orderPub,
+		pricer:          pricer,
+		stock:           stockReservations{inventory: inventoryService},
+		coupons:         coupons{promotions: promotionRepo},
+		taxes:           taxes{calculator: taxCalculator},
+		customers:       customers{customers: customerRepo},
 	}
 }
 
 func (u *createOrderUseCase) Execute(ctx context.Context, input dtos.OrderInput) (dtos.OrderOutput, error) {
-	if len(input.Items) == 0 {
-		return dtos.OrderOutput{}, errors.New("order must contain at least one item")
+	if err := requireScope(ctx, auth.ScopeOrdersWrite); err != nil {
+		return dtos.OrderOutput{}, err
 	}
 
-	var items []entity.Item
-	for _, itemInput := range input.Items {
-		item, err := entity.NewItem(itemInput.ID, itemInput.Name, itemInput.Quantity, itemInput.Price)
-		if err != nil {
-			return dtos.OrderOutput{}, err
-		}
-		items = append(items, *item)
+	if input.Currency == "" {
+		input.Currency = entity.DefaultCurrency
+	}
+	var err error
+	input.Items, err = adoptLegacyItemIDs(input.Items, nil, itemPointer("id"))
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.pricer.PriceOrder(ctx, &input, input.Currency); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := scopeCustomerID(ctx, &input.CustomerID); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := input.Validate(); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.customers.resolve(ctx, &input); err != nil {
+		return dtos.OrderOutput{}, err
 	}
 
-	newOrder, err := entity.NewOrder(generateID(), input.CustomerName, items)
+	items, err := buildItems(input.Items, input.Currency, nil)
 	if err != nil {
 		return dtos.OrderOutput{}, err
 	}
 
-	err = u.orderRepository.Save(ctx, newOrder)
+	newOrder, err := entity.NewOrder(generateID(), input.CustomerName, input.Currency, items)
 	if err != nil {
 		return dtos.OrderOutput{}, err
 	}
 
-	err = u.orderPublisher.PublishCreatedOrder(ctx, newOrder)
+	if err := newOrder.AssignCustomer(input.CustomerID); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := applyDeliveryDetails(newOrder, input); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.coupons.apply(ctx, newOrder, input.CouponCode); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.taxes.apply(ctx, newOrder, input.TaxJurisdiction); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	err = saveOrder(ctx, u.orderRepository, newOrder, u.coupons.redeem(newOrder, ""), u.stock.reserve(newOrder))
 	if err != nil {
 		return dtos.OrderOutput{}, err
 	}
@@ -60,6 +98,69 @@ func (u *createOrderUseCase) Execute(ctx context.Context, input dtos.OrderInput)
 	return dtos.FromEntityToOrderOutput(newOrder), nil
 }
 
+// adoptLegacyItemIDs handles item ids that are not lines of the order, lineIDs. Before items had line
+// IDs of their own, clients sent the product as the item id, so such an id is taken as the product_id
+// and cleared, leaving line IDs to the server. An id that differs from the product_id of its item is
+// rejected, with the error at pointer(index). The items are returned as a copy, leaving the caller's
+// input as it was sent.
+func adoptLegacyItemIDs(inputs []dtos.ItemInput, lineIDs map[string]bool, pointer func(index int) string) ([]dtos.ItemInput, error) {
+	items := append([]dtos.ItemInput(nil), inputs...)
+	var errs validation.Errors
+	for index := range items {
+		item := &items[index]
+		if item.ID == "" || lineIDs[item.ID] {
+			continue
+		}
+		switch {
+		case item.ProductID == "":
+			item.ProductID = item.ID
+		case item.ProductID != item.ID:
+			errs.Add(pointer(index), validation.CodeProductIDMismatch, "is assigned by the server; send the product in product_id")
+		}
+		item.ID = ""
+	}
+	return items, errs.Err()
+}
+
+// buildItems creates the order items, keeping the IDs of the lines of the order, lineIDs, and
+// generating the line IDs of new items.
+func buildItems(inputs []dtos.ItemInput, currency string, lineIDs map[string]bool) ([]entity.Item, error) {
+	var items []entity.Item
+	for _, itemInput := range inputs {
+		itemCurrency := itemInput.Currency
+		if itemCurrency == "" {
+			itemCurrency = currency
+		}
+
+		itemID := itemInput.ID
+		if !lineIDs[itemID] {
+			itemID = generateID()
+		}
+
+		item, err := entity.NewItem(itemID, itemInput.ProductID, itemInput.Name, itemInput.Quantity, entity.NewMoney(itemInput.Price.Amount, itemCurrency))
+		if err != nil {
+			return nil, err
+		}
+		item.Category = itemInput.Category
+		items = append(items, *item)
+	}
+	return items, nil
+}
+
+// lineIDsOf returns the IDs of the lines of an order.
+func lineIDsOf(order *entity.Order) map[string]bool {
+	lineIDs := make(map[string]bool, len(order.Items))
+	for _, item := range order.Items {
+		lineIDs[item.ID] = true
+	}
+	return lineIDs
+}
+
+// itemPointer points at the field of the item at index in the items of an order input.
+func itemPointer(field string) func(index int) string {
+	return func(index int) string { return validation.Pointer("items", index, field) }
+}
+
 func generateID() string {
 	return uuid.New().String()
 }
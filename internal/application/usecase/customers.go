@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+)
+
+// customers links orders to the customers placing them. Without a customer repository every
+// customer id is unknown.
+type customers struct {
+	customers repository.CustomerRepository
+}
+
+// resolve checks that the customer of a validated input exists and names the order after the
+// customer when the input leaves the customer name out.
+func (c customers) resolve(ctx context.Context, input *dtos.OrderInput) error {
+	if input.CustomerID == "" {
+		return nil
+	}
+
+	var customer *entity.Customer
+	err := repository.ErrCustomerNotFound
+	if c.customers != nil {
+		customer, err = c.customers.FindByID(ctx, input.CustomerID)
+	}
+	if errors.Is(err, repository.ErrCustomerNotFound) {
+		var errs validation.Errors
+		errs.Add(validation.Pointer("customer_id"), validation.CodeUnknownCustomer, "is not a known customer")
+		return errs
+	}
+	if err != nil {
+		return err
+	}
+
+	if input.CustomerName == "" {
+		input.CustomerName = customer.Name
+	}
+	return nil
+}
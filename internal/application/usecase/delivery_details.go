@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+)
+
+// applyDeliveryDetails gives a pending order the delivery details of a validated input. Delivery
+// windows must lie ahead when they are asked for, so a window is only checked when it changes.
+func applyDeliveryDetails(order *entity.Order, input dtos.OrderInput) error {
+	details := input.DeliveryDetails()
+	if window := details.DeliveryWindow; window != nil && !window.Equal(order.DeliveryWindow) && window.Start.Before(time.Now()) {
+		var errs validation.Errors
+		errs.Add(validation.Pointer("delivery_window", "start"), validation.CodeTooSmall, "must be in the future")
+		return errs
+	}
+	return order.UpdateDeliveryDetails(details)
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import "errors"
+
+var (
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrOrderNotEditable     = errors.New("order cannot be updated as it is not pending")
+	ErrOrderAlreadyCanceled = errors.New("order is already canceled")
+	ErrCustomerNotFound     = errors.New("customer not found")
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrAPIKeyInactive       = errors.New("api key is revoked or expired")
+)
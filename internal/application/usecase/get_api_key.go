@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+type GetAPIKeyUseCase interface {
+	Execute(ctx context.Context, id string) (dtos.APIKeyOutput, error)
+}
+
+type getAPIKeyUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func NewGetAPIKeyUseCase(apiKeyRepo repository.APIKeyRepository) GetAPIKeyUseCase {
+	return &getAPIKeyUseCase{
+		apiKeyRepository: apiKeyRepo,
+	}
+}
+
+func (u *getAPIKeyUseCase) Execute(ctx context.Context, id string) (dtos.APIKeyOutput, error) {
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return dtos.APIKeyOutput{}, err
+	}
+
+	key, err := findAPIKey(ctx, u.apiKeyRepository, id)
+	if err != nil {
+		return dtos.APIKeyOutput{}, err
+	}
+
+	return dtos.FromEntityToAPIKeyOutput(key), nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+type GetCustomerUseCase interface {
+	Execute(ctx context.Context, id string) (dtos.CustomerOutput, error)
+}
+
+type getCustomerUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewGetCustomerUseCase(customerRepo repository.CustomerRepository) GetCustomerUseCase {
+	return &getCustomerUseCase{
+		customerRepository: customerRepo,
+	}
+}
+
+func (u *getCustomerUseCase) Execute(ctx context.Context, id string) (dtos.CustomerOutput, error) {
+	if err := requireScope(ctx, auth.ScopeOrdersRead); err != nil {
+		return dtos.CustomerOutput{}, err
+	}
+	if err := authorizeCustomer(ctx, id); err != nil {
+		return dtos.CustomerOutput{}, err
+	}
+
+	customer, err := u.customerRepository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrCustomerNotFound) {
+			return dtos.CustomerOutput{}, ErrCustomerNotFound
+		}
+		return dtos.CustomerOutput{}, err
+	}
+
+	return dtos.FromEntityToCustomerOutput(customer), nil
+}
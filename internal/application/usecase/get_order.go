@@ -3,33 +3,80 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/exchange"
 	"order-service/internal/domain/repository"
 )
 
+var ErrConversionUnavailable = errors.New("currency conversion is not available")
+
 type GetOrderUseCase interface {
-	Execute(ctx context.Context, id string) (dtos.OrderOutput, error)
+	Execute(ctx context.Context, id string, currency string) (dtos.OrderOutput, error)
 }
 
 type getOrderUseCase struct {
 	orderRepository repository.OrderRepository
+	rateProvider    exchange.RateProvider
 }
 
-func NewGetOrderUseCase(orderRepo repository.OrderRepository) GetOrderUseCase {
+// NewGetOrderUseCase builds the use case; rateProvider may be nil when conversion is not configured.
+func NewGetOrderUseCase(orderRepo repository.OrderRepository, rateProvider exchange.RateProvider) GetOrderUseCase {
 	return &getOrderUseCase{
 		orderRepository: orderRepo,
+		rateProvider:    rateProvider,
 	}
 }
 
-func (u *getOrderUseCase) Execute(ctx context.Context, id string) (dtos.OrderOutput, error) {
+func (u *getOrderUseCase) Execute(ctx context.Context, id string, currency string) (dtos.OrderOutput, error) {
+	if err := requireScope(ctx, auth.ScopeOrdersRead); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	if currency != "" {
+		if err := entity.ValidateCurrency(currency); err != nil {
+			return dtos.OrderOutput{}, err
+		}
+		if u.rateProvider == nil {
+			return dtos.OrderOutput{}, ErrConversionUnavailable
+		}
+	}
+
 	order, err := u.orderRepository.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return dtos.OrderOutput{}, errors.New("order not found")
+			return dtos.OrderOutput{}, ErrOrderNotFound
 		}
 		return dtos.OrderOutput{}, err
 	}
+	if err := authorizeOrder(ctx, order); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	output := dtos.FromEntityToOrderOutput(order)
+	if currency == "" || currency == order.Currency {
+		return output, nil
+	}
+
+	rate, err := u.rateProvider.Rate(ctx, order.Currency, currency)
+	if err != nil {
+		return dtos.OrderOutput{}, fmt.Errorf("%w: %v", ErrConversionUnavailable, err)
+	}
+
+	output.ConvertedTotal = &dtos.ConvertedAmount{
+		Currency: currency,
+		Rate:     formatRate(rate),
+		Total:    order.Total().Convert(currency, rate),
+	}
+	return output, nil
+}
 
-	return dtos.FromEntityToOrderOutput(order), nil
+func formatRate(rate *big.Rat) string {
+	value := strings.TrimRight(rate.FloatString(6), "0")
+	return strings.TrimSuffix(value, ".")
 }
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+type GetOrderHistoryUseCase interface {
+	Execute(ctx context.Context, id string) (dtos.OrderHistoryOutput, error)
+}
+
+type getOrderHistoryUseCase struct {
+	orderRepository repository.OrderRepository
+}
+
+func NewGetOrderHistoryUseCase(orderRepo repository.OrderRepository) GetOrderHistoryUseCase {
+	return &getOrderHistoryUseCase{
+		orderRepository: orderRepo,
+	}
+}
+
+func (u *getOrderHistoryUseCase) Execute(ctx context.Context, id string) (dtos.OrderHistoryOutput, error) {
+	if err := requireScope(ctx, auth.ScopeOrdersRead); err != nil {
+		return dtos.OrderHistoryOutput{}, err
+	}
+
+	order, err := u.orderRepository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return dtos.OrderHistoryOutput{}, ErrOrderNotFound
+		}
+		return dtos.OrderHistoryOutput{}, err
+	}
+	if err := authorizeOrder(ctx, order); err != nil {
+		return dtos.OrderHistoryOutput{}, err
+	}
+
+	history, err := u.orderRepository.FindStatusHistory(ctx, order.ID)
+	if err != nil {
+		return dtos.OrderHistoryOutput{}, err
+	}
+
+	return dtos.FromEntityToOrderHistoryOutput(order.ID, history), nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+type GetOrderTransitionsUseCase interface {
+	Execute(ctx context.Context, id string) (dtos.OrderTransitionsOutput, error)
+}
+
+type getOrderTransitionsUseCase struct {
+	orderRepository repository.OrderRepository
+}
+
+func NewGetOrderTransitionsUseCase(orderRepo repository.OrderRepository) GetOrderTransitionsUseCase {
+	return &getOrderTransitionsUseCase{
+		orderRepository: orderRepo,
+	}
+}
+
+func (u *getOrderTransitionsUseCase) Execute(ctx context.Context, id string) (dtos.OrderTransitionsOutput, error) {
+	if err := requireScope(ctx, auth.ScopeOrdersRead); err != nil {
+		return dtos.OrderTransitionsOutput{}, err
+	}
+
+	order, err := u.orderRepository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return dtos.OrderTransitionsOutput{}, ErrOrderNotFound
+		}
+		return dtos.OrderTransitionsOutput{}, err
+	}
+	if err := authorizeOrder(ctx, order); err != nil {
+		return dtos.OrderTransitionsOutput{}, err
+	}
+
+	return dtos.FromEntityToOrderTransitionsOutput(order), nil
+}
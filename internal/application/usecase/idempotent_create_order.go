@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+const (
+	DefaultIdempotencyKeyTTL = 24 * time.Hour
+	MaxIdempotencyKeyLength  = 255
+
+	// IdempotencyKeyLease is how long a request holds its key before a retry may take it over, in
+	// case the request died without storing its response or releasing the key.
+	IdempotencyKeyLease = time.Minute
+)
+
+var (
+	ErrInvalidIdempotencyKey    = errors.New("idempotency key must be between 1 and 255 characters")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// IdempotentCreateOrderUseCase creates an order at most once per idempotency key of a caller,
+// replaying the stored response when the same request is retried.
+type IdempotentCreateOrderUseCase interface {
+	Execute(ctx context.Context, key string, input dtos.OrderInput) (output dtos.OrderOutput, replayed bool, err error)
+}
+
+type idempotentCreateOrderUseCase struct {
+	createOrderUseCase    CreateOrderUseCase
+	idempotencyRepository repository.IdempotencyRepository
+	ttl                   time.Duration
+}
+
+func NewIdempotentCreateOrderUseCase(createOrderUseCase CreateOrderUseCase, idempotencyRepo repository.IdempotencyRepository, ttl time.Duration) IdempotentCreateOrderUseCase {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyKeyTTL
+	}
+	return &idempotentCreateOrderUseCase{
+		createOrderUseCase:    createOrderUseCase,
+		idempotencyRepository: idempotencyRepo,
+		ttl:                   ttl,
+	}
+}
+
+func (u *idempotentCreateOrderUseCase) Execute(ctx context.Context, key string, input dtos.OrderInput) (dtos.OrderOutput, bool, error) {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return dtos.OrderOutput{}, false, ErrInvalidIdempotencyKey
+	}
+	if err := requireScope(ctx, auth.ScopeOrdersWrite); err != nil {
+		return dtos.OrderOutput{}, false, err
+	}
+	// Scoping the input first puts the customer into the request hash, so one customer cannot
+	// replay the order another customer created with the same key.
+	if err := scopeCustomerID(ctx, &input.CustomerID); err != nil {
+		return dtos.OrderOutput{}, false, err
+	}
+
+	requestHash, err := hashRequest(input)
+	if err != nil {
+		return dtos.OrderOutput{}, false, err
+	}
+
+	// Keys belong to the caller that sent them, so callers cannot block or see each other's keys.
+	var owner string
+	if principal, ok := auth.FromContext(ctx); ok {
+		owner = principal.Subject
+	}
+
+	now := time.Now()
+	existing, reserved, err := u.idempotencyRepository.Reserve(ctx, repository.IdempotencyRecord{
+		Owner:       owner,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(u.ttl),
+		LockedUntil: now.Add(IdempotencyKeyLease),
+	})
+	if err != nil {
+		return dtos.OrderOutput{}, false, err
+	}
+
+	if !reserved {
+		if existing.RequestHash != requestHash {
+			return dtos.OrderOutput{}, false, ErrIdempotencyKeyReused
+		}
+		if !existing.Completed() {
+			return dtos.OrderOutput{}, false, ErrIdempotencyKeyInProgress
+		}
+
+		output, err := dtos.UnmarshalOrderOutput(existing.Response)
+		if err != nil {
+			return dtos.OrderOutput{}, false, err
+		}
+		return output, true, nil
+	}
+
+	// The key is released or completed even when the caller has gone away, so a retry is not
+	// turned away while the key stays in progress.
+	detached := context.WithoutCancel(ctx)
+	output, err := u.createOrderUseCase.Execute(ctx, input)
+	if err != nil {
+		if releaseErr := u.idempotencyRepository.Release(detached, owner, key); releaseErr != nil {
+			log.Printf("Error releasing idempotency key %s: %v", key, releaseErr)
+		}
+		return dtos.OrderOutput{}, false, err
+	}
+
+	response, err := json.Marshal(output)
+	if err == nil {
+		err = u.idempotencyRepository.Complete(detached, owner, key, response)
+	}
+	if err != nil {
+		// The order exists at this point, so the caller still gets it; only replays are affected.
+		log.Printf("Error storing response for idempotency key %s: %v", key, err)
+	}
+
+	return output, false, nil
+}
+
+func hashRequest(input dtos.OrderInput) (string, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
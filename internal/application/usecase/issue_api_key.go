@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+// IssueAPIKeyUseCase issues API keys to services. The key is only part of the output of this use
+// case and of rotations; it cannot be looked up later.
+type IssueAPIKeyUseCase interface {
+	Execute(ctx context.Context, input dtos.APIKeyInput) (dtos.IssuedAPIKeyOutput, error)
+}
+
+type issueAPIKeyUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func NewIssueAPIKeyUseCase(apiKeyRepo repository.APIKeyRepository) IssueAPIKeyUseCase {
+	return &issueAPIKeyUseCase{
+		apiKeyRepository: apiKeyRepo,
+	}
+}
+
+func (u *issueAPIKeyUseCase) Execute(ctx context.Context, input dtos.APIKeyInput) (dtos.IssuedAPIKeyOutput, error) {
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+	now := time.Now()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		var errs validation.Errors
+		errs.Add(validation.Pointer("expires_at"), validation.CodeTooSmall, "must be in the future")
+		return dtos.IssuedAPIKeyOutput{}, errs
+	}
+
+	key, secret, err := auth.NewAPIKey(generateID(), strings.TrimSpace(input.Name), parseScopes(input.Scopes), now)
+	if err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+	if input.ExpiresAt != nil {
+		key.ExpireBy(*input.ExpiresAt)
+	}
+
+	if err := u.apiKeyRepository.Save(ctx, key); err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+
+	return dtos.IssuedAPIKeyOutput{APIKeyOutput: dtos.FromEntityToAPIKeyOutput(key), Key: secret}, nil
+}
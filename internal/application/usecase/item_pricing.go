@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/catalog"
+)
+
+// ItemPricer resolves item names, prices and tax categories from the product catalog. A nil
+// *ItemPricer, used when no catalog is configured, keeps the values clients send.
+type ItemPricer struct {
+	catalog catalog.ProductCatalog
+	mode    catalog.PriceMode
+}
+
+func NewItemPricer(productCatalog catalog.ProductCatalog, mode catalog.PriceMode) *ItemPricer {
+	return &ItemPricer{catalog: productCatalog, mode: mode}
+}
+
+// PriceOrder prices the items of an order in currency in place.
+func (p *ItemPricer) PriceOrder(ctx context.Context, input *dtos.OrderInput, currency string) error {
+	if p == nil {
+		return nil
+	}
+	return p.price(ctx, input.Items, currency, func(index int) []any { return []any{"items", index} })
+}
+
+// PriceItem prices an item sent on its own for an order in currency in place.
+func (p *ItemPricer) PriceItem(ctx context.Context, input *dtos.ItemInput, currency string) error {
+	if p == nil {
+		return nil
+	}
+	items := []dtos.ItemInput{*input}
+	err := p.price(ctx, items, currency, func(int) []any { return nil })
+	*input = items[0]
+	return err
+}
+
+// price looks every product up at once and gives each item the catalog name, price and category. A zero
+// client price always takes the catalog price; in reject mode any other price must match it.
+func (p *ItemPricer) price(ctx context.Context, items []dtos.ItemInput, currency string, base func(index int) []any) error {
+	productIDs := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item.ProductID != "" && !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	products, err := p.catalog.Products(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	var errs validation.Errors
+	for index := range items {
+		item := &items[index]
+		field := func(name string) string {
+			tokens := base(index)
+			return validation.Pointer(append(tokens[:len(tokens):len(tokens)], name)...)
+		}
+
+		if item.ProductID == "" {
+			errs.Add(field("product_id"), validation.CodeRequired, "must be set to price the item from the product catalog")
+			continue
+		}
+		product, ok := products[item.ProductID]
+		switch {
+		case !ok:
+			errs.Add(field("product_id"), validation.CodeUnknownProduct, "is not in the product catalog")
+			continue
+		case !product.Available:
+			errs.Add(field("product_id"), validation.CodeUnavailable, "is not available")
+			continue
+		case product.Price.Currency != currency:
+			errs.Add(field("product_id"), validation.CodeCurrencyMismatch, "is priced in %s, not in the order currency %s", product.Price.Currency, currency)
+			continue
+		}
+
+		if p.mode == catalog.PriceModeReject && !item.Price.IsZero() && item.Price.Amount != product.Price.Amount {
+			errs.Add(field("price"), validation.CodePriceMismatch, "must be %s, the catalog price", product.Price.Decimal())
+			continue
+		}
+		item.Name = product.Name
+		item.Category = product.Category
+		item.Price = product.Price
+	}
+
+	return errs.Err()
+}
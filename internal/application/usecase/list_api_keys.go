@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+type ListAPIKeysUseCase interface {
+	Execute(ctx context.Context) (dtos.ListAPIKeysOutput, error)
+}
+
+type listAPIKeysUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func NewListAPIKeysUseCase(apiKeyRepo repository.APIKeyRepository) ListAPIKeysUseCase {
+	return &listAPIKeysUseCase{
+		apiKeyRepository: apiKeyRepo,
+	}
+}
+
+func (u *listAPIKeysUseCase) Execute(ctx context.Context) (dtos.ListAPIKeysOutput, error) {
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return dtos.ListAPIKeysOutput{}, err
+	}
+
+	keys, err := u.apiKeyRepository.List(ctx)
+	if err != nil {
+		return dtos.ListAPIKeysOutput{}, err
+	}
+
+	output := dtos.ListAPIKeysOutput{APIKeys: []dtos.APIKeyOutput{}}
+	for i := range keys {
+		output.APIKeys = append(output.APIKeys, dtos.FromEntityToAPIKeyOutput(&keys[i]))
+	}
+	return output, nil
+}
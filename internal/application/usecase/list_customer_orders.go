@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+type ListCustomerOrdersUseCase interface {
+	Execute(ctx context.Context, customerID string, input dtos.ListOrderInput) (dtos.ListOrderOutput, error)
+}
+
+type listCustomerOrdersUseCase struct {
+	customerRepository repository.CustomerRepository
+	listOrders         ListOrderUseCase
+}
+
+// NewListCustomerOrdersUseCase builds the use case, which lists orders like listOrders once it has
+// checked that the customer exists.
+func NewListCustomerOrdersUseCase(customerRepo repository.CustomerRepository, listOrders ListOrderUseCase) ListCustomerOrdersUseCase {
+	return &listCustomerOrdersUseCase{
+		customerRepository: customerRepo,
+		listOrders:         listOrders,
+	}
+}
+
+func (u *listCustomerOrdersUseCase) Execute(ctx context.Context, customerID string, input dtos.ListOrderInput) (dtos.ListOrderOutput, error) {
+	if err := requireScope(ctx, auth.ScopeOrdersRead); err != nil {
+		return dtos.ListOrderOutput{}, err
+	}
+	if err := authorizeCustomer(ctx, customerID); err != nil {
+		return dtos.ListOrderOutput{}, err
+	}
+	if _, err := u.customerRepository.FindByID(ctx, customerID); err != nil {
+		if errors.Is(err, repository.ErrCustomerNotFound) {
+			return dtos.ListOrderOutput{}, ErrCustomerNotFound
+		}
+		return dtos.ListOrderOutput{}, err
+	}
+
+	input.CustomerID = customerID
+	return u.listOrders.Execute(ctx, input)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+type ListCustomersUseCase interface {
+	Execute(ctx context.Context, input dtos.ListCustomersInput) (dtos.ListCustomersOutput, error)
+}
+
+type listCustomersUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewListCustomersUseCase(customerRepo repository.CustomerRepository) ListCustomersUseCase {
+	return &listCustomersUseCase{
+		customerRepository: customerRepo,
+	}
+}
+
+func (u *listCustomersUseCase) Execute(ctx context.Context, input dtos.ListCustomersInput) (dtos.ListCustomersOutput, error) {
+	if err := requireRole(ctx, auth.RoleSupport); err != nil {
+		return dtos.ListCustomersOutput{}, err
+	}
+
+	query := repository.CustomerQuery{Page: input.Page, Size: input.Size, Name: input.Name}
+	if query.Page < 0 || query.Size < 0 {
+		return dtos.ListCustomersOutput{}, fmt.Errorf("%w: page and size must not be negative", ErrInvalidListQuery)
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Size == 0 {
+		query.Size = DefaultPageSize
+	}
+	if query.Size > MaxPageSize {
+		return dtos.ListCustomersOutput{}, fmt.Errorf("%w: size must not be greater than %d", ErrInvalidListQuery, MaxPageSize)
+	}
+
+	page, err := u.customerRepository.List(ctx, query)
+	if err != nil {
+		return dtos.ListCustomersOutput{}, err
+	}
+
+	output := dtos.ListCustomersOutput{
+		Page:      query.Page,
+		Size:      query.Size,
+		Total:     page.Total,
+		Customers: []dtos.CustomerOutput{},
+	}
+	for i := range page.Customers {
+		output.Customers = append(output.Customers, dtos.FromEntityToCustomerOutput(&page.Customers[i]))
+	}
+
+	return output, nil
+}
@@ -2,13 +2,29 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/entity"
 	"order-service/internal/domain/repository"
 )
 
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+
+	cursorSeparator = "|"
+)
+
+var ErrInvalidListQuery = errors.New("invalid list query")
+
 type ListOrderUseCase interface {
-	Execute(ctx context.Context) (dtos.ListOrderOutput, error)
+	Execute(ctx context.Context, input dtos.ListOrderInput) (dtos.ListOrderOutput, error)
 }
 
 type listOrderUseCase struct {
@@ -21,16 +37,133 @@ func NewListOrderUseCase(orderRepo repository.OrderRepository) ListOrderUseCase
 	}
 }
 
-func (u *listOrderUseCase) Execute(ctx context.Context) (dtos.ListOrderOutput, error) {
-	orders, err := u.orderRepository.List(ctx)
+func (u *listOrderUseCase) Execute(ctx context.Context, input dtos.ListOrderInput) (dtos.ListOrderOutput, error) {
+	if err := requireScope(ctx, auth.ScopeOrdersRead); err != nil {
+		return dtos.ListOrderOutput{}, err
+	}
+	if err := scopeCustomerID(ctx, &input.CustomerID); err != nil {
+		return dtos.ListOrderOutput{}, err
+	}
+
+	query, err := buildOrderQuery(input)
 	if err != nil {
 		return dtos.ListOrderOutput{}, err
 	}
 
-	var output dtos.ListOrderOutput
-	for _, order := range orders {
-		output.Orders = append(output.Orders, dtos.FromEntityToOrderOutput(&order))
+	page, err := u.orderRepository.List(ctx, query)
+	if err != nil {
+		return dtos.ListOrderOutput{}, err
+	}
+
+	output := dtos.ListOrderOutput{
+		Page:   query.Page,
+		Size:   query.Size,
+		Total:  page.Total,
+		Orders: []dtos.OrderOutput{},
+	}
+	if query.Keyset {
+		output.Page = 0
+		if page.NextCursor != nil {
+			output.NextCursor = encodeCursor(*page.NextCursor)
+		}
+	}
+	for i := range page.Orders {
+		output.Orders = append(output.Orders, dtos.FromEntityToOrderOutput(&page.Orders[i]))
 	}
 
 	return output, nil
 }
+
+func buildOrderQuery(input dtos.ListOrderInput) (repository.OrderQuery, error) {
+	query := repository.OrderQuery{
+		Page:          input.Page,
+		Size:          input.Size,
+		CustomerID:    input.CustomerID,
+		CustomerName:  input.CustomerName,
+		CreatedFrom:   input.CreatedFrom,
+		CreatedTo:     input.CreatedTo,
+		SortBy:        repository.SortByCreatedAt,
+		SortDirection: repository.SortDesc,
+	}
+
+	if query.Page < 0 || query.Size < 0 {
+		return repository.OrderQuery{}, fmt.Errorf("%w: page and size must not be negative", ErrInvalidListQuery)
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Size == 0 {
+		query.Size = DefaultPageSize
+	}
+	if query.Size > MaxPageSize {
+		return repository.OrderQuery{}, fmt.Errorf("%w: size must not be greater than %d", ErrInvalidListQuery, MaxPageSize)
+	}
+
+	if input.Status != "" {
+		status, err := entity.ParseOrderStatus(input.Status)
+		if err != nil {
+			return repository.OrderQuery{}, fmt.Errorf("%w: %v", ErrInvalidListQuery, err)
+		}
+		query.Status = &status
+	}
+
+	if query.CreatedFrom != nil && query.CreatedTo != nil && query.CreatedFrom.After(*query.CreatedTo) {
+		return repository.OrderQuery{}, fmt.Errorf("%w: created_from must not be after created_to", ErrInvalidListQuery)
+	}
+
+	switch repository.SortField(input.SortBy) {
+	case "":
+	case repository.SortByCreatedAt, repository.SortByUpdatedAt, repository.SortByCustomerName, repository.SortByStatus:
+		query.SortBy = repository.SortField(input.SortBy)
+	default:
+		return repository.OrderQuery{}, fmt.Errorf("%w: invalid sort field %q", ErrInvalidListQuery, input.SortBy)
+	}
+
+	switch repository.SortDirection(input.SortDirection) {
+	case "":
+	case repository.SortAsc, repository.SortDesc:
+		query.SortDirection = repository.SortDirection(input.SortDirection)
+	default:
+		return repository.OrderQuery{}, fmt.Errorf("%w: invalid sort direction %q", ErrInvalidListQuery, input.SortDirection)
+	}
+
+	if input.Keyset || input.Cursor != "" {
+		if query.SortBy != repository.SortByCreatedAt {
+			return repository.OrderQuery{}, fmt.Errorf("%w: cursor pagination only supports sorting by created_at", ErrInvalidListQuery)
+		}
+		query.Keyset = true
+		if input.Cursor != "" {
+			cursor, err := decodeCursor(input.Cursor)
+			if err != nil {
+				return repository.OrderQuery{}, fmt.Errorf("%w: %v", ErrInvalidListQuery, err)
+			}
+			query.After = &cursor
+		}
+	}
+
+	return query, nil
+}
+
+func encodeCursor(cursor repository.OrderCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + cursorSeparator + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(value string) (repository.OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return repository.OrderCursor{}, errors.New("malformed cursor")
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), cursorSeparator)
+	if !found || id == "" {
+		return repository.OrderCursor{}, errors.New("malformed cursor")
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return repository.OrderCursor{}, errors.New("malformed cursor")
+	}
+
+	return repository.OrderCursor{CreatedAt: t, ID: id}, nil
+}
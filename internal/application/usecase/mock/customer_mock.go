@@ -0,0 +1,32 @@
+package usecase_mock
+
+import (
+	"context"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerRepository) Save(ctx context.Context, customer *entity.Customer) error {
+	args := m.Called(ctx, customer)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) FindByID(ctx context.Context, id string) (*entity.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) List(ctx context.Context, query repository.CustomerQuery) (repository.CustomerPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(repository.CustomerPage), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package usecase_mock
+
+import (
+	"context"
+
+	"order-service/internal/domain/inventory"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInventoryService struct {
+	mock.Mock
+}
+
+func (m *MockInventoryService) Reserve(ctx context.Context, orderID string, reservations []inventory.Reservation) error {
+	args := m.Called(ctx, orderID, reservations)
+	return args.Error(0)
+}
+
+func (m *MockInventoryService) Adjust(ctx context.Context, orderID string, reservations []inventory.Reservation) error {
+	args := m.Called(ctx, orderID, reservations)
+	return args.Error(0)
+}
+
+func (m *MockInventoryService) Release(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockInventoryService) Consume(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
@@ -0,0 +1,31 @@
+package usecase_mock
+
+import (
+	"context"
+
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) FindByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) Redeem(ctx context.Context, code, orderID string) error {
+	args := m.Called(ctx, code, orderID)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) Release(ctx context.Context, code, orderID string) error {
+	args := m.Called(ctx, code, orderID)
+	return args.Error(0)
+}
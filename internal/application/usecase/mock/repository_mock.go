@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -12,9 +13,19 @@ type MockOrderRepository struct {
 	mock.Mock
 }
 
-func (m *MockOrderRepository) Save(ctx context.Context, order *entity.Order) error {
+// Save returns the error set for the call, such as a version conflict, before running the side
+// effects, as the repository checks the version first; a failing side effect fails the save.
+func (m *MockOrderRepository) Save(ctx context.Context, order *entity.Order, effects ...repository.SideEffect) error {
 	args := m.Called(ctx, order)
-	return args.Error(0)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	for _, effect := range effects {
+		if err := effect(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (m *MockOrderRepository) FindByID(ctx context.Context, id string) (*entity.Order, error) {
@@ -25,7 +36,31 @@ func (m *MockOrderRepository) FindByID(ctx context.Context, id string) (*entity.
 	return args.Get(0).(*entity.Order), args.Error(1)
 }
 
-func (m *MockOrderRepository) List(ctx context.Context) ([]entity.Order, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]entity.Order), args.Error(1)
+func (m *MockOrderRepository) List(ctx context.Context, query repository.OrderQuery) (repository.OrderPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(repository.OrderPage), args.Error(1)
+}
+
+func (m *MockOrderRepository) FindStatusHistory(ctx context.Context, orderID string) ([]entity.StatusChange, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]entity.StatusChange), args.Error(1)
+}
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) Reserve(ctx context.Context, record repository.IdempotencyRecord) (repository.IdempotencyRecord, bool, error) {
+	args := m.Called(ctx, record)
+	return args.Get(0).(repository.IdempotencyRecord), args.Bool(1), args.Error(2)
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, owner, key string, response []byte) error {
+	args := m.Called(ctx, owner, key, response)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Release(ctx context.Context, owner, key string) error {
+	args := m.Called(ctx, owner, key)
+	return args.Error(0)
 }
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/patch"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+	"order-service/internal/domain/tax"
+)
+
+type PatchOrderUseCase interface {
+	Execute(ctx context.Context, id string, expectedVersion int64, input dtos.OrderPatchInput) (dtos.OrderOutput, error)
+}
+
+type patchOrderUseCase struct {
+	orderRepository repository.OrderRepository
+	pricer          *ItemPricer
+	stock           stockReservations
+	coupons         coupons
+	taxes           taxes
+	customers       customers
+}
+
+// NewPatchOrderUseCase builds the use case; pricer, inventoryService, promotionRepo and
+// taxCalculator may be nil when no product catalog, inventory, promotions or taxes are configured,
+// and customerRepo when orders are not linked to customers.
+func NewPatchOrderUseCase(orderRepo repository.OrderRepository, pricer *ItemPricer, inventoryService inventory.InventoryService, promotionRepo repository.PromotionRepository, taxCalculator tax.Calculator, customerRepo repository.CustomerRepository) PatchOrderUseCase {
+	return &patchOrderUseCase{
+		orderRepository: orderRepo,
+		pricer:          pricer,
+		stock:           stockReservations{inventory: inventoryService},
+		coupons:         coupons{promotions: promotionRepo},
+		taxes:           taxes{calculator: taxCalculator},
+		customers:       customers{customers: customerRepo},
+	}
+}
+
+// Execute applies a patch to the dtos.OrderPatchDocument of a pending order and updates the order
+// with the result, which is validated like a full update.
+func (u *patchOrderUseCase) Execute(ctx context.Context, id string, expectedVersion int64, input dtos.OrderPatchInput) (dtos.OrderOutput, error) {
+	order, err := findEditableOrder(ctx, u.orderRepository, id, expectedVersion)
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	document, err := json.Marshal(dtos.FromEntityToOrderPatchDocument(order))
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	patched, err := patch.Apply(input.Format, document, input.Patch)
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	var result dtos.OrderPatchDocument
+	if err := validation.Decode(patched, &result); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	itemOrder := make([]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		itemOrder = append(itemOrder, item.ID)
+	}
+	orderInput, itemIDs := result.ToOrderInput(itemOrder)
+	// The items of the document carry the order currency, so a changed currency is reported as such
+	// rather than as items in the wrong currency.
+	if err := checkCurrencyUnchanged(order, orderInput.Currency); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	orderInput.Items = adoptLegacyItemKeys(orderInput.Items, lineIDsOf(order))
+	if err := u.pricer.PriceOrder(ctx, &orderInput, order.Currency); err != nil {
+		return dtos.OrderOutput{}, pointItemErrorsByID(err, itemIDs)
+	}
+	if err := scopeCustomerID(ctx, &orderInput.CustomerID); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := orderInput.Validate(); err != nil {
+		return dtos.OrderOutput{}, pointItemErrorsByID(err, itemIDs)
+	}
+	if err := u.customers.resolve(ctx, &orderInput); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	previousCode := order.CouponCode
+	if err := applyOrderInput(order, orderInput); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.coupons.apply(ctx, order, orderInput.CouponCode); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.taxes.apply(ctx, order, orderInput.TaxJurisdiction); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	err = saveOrder(ctx, u.orderRepository, order, u.coupons.redeem(order, previousCode), u.stock.adjust(order))
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	return dtos.FromEntityToOrderOutput(order), nil
+}
+
+// adoptLegacyItemKeys handles the keys of items added by a patch. Older clients keyed new items by
+// their product, so the key of an item sent without a product_id is taken as its product, as with the
+// item ids of adoptLegacyItemIDs. Since a patch needs a key for every item, a key sent along with a
+// product_id only names the item within the patch. The items are returned as a copy.
+func adoptLegacyItemKeys(inputs []dtos.ItemInput, lineIDs map[string]bool) []dtos.ItemInput {
+	items := append([]dtos.ItemInput(nil), inputs...)
+	for index := range items {
+		item := &items[index]
+		if lineIDs[item.ID] {
+			continue
+		}
+		if item.ProductID == "" {
+			item.ProductID = item.ID
+		}
+		item.ID = ""
+	}
+	return items
+}
+
+// pointItemErrorsByID rewrites field errors on "/items/{index}" to "/items/{id}", the pointers of the
+// patch document.
+func pointItemErrorsByID(err error, itemIDs []string) error {
+	var fieldErrs validation.Errors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	rewritten := make(validation.Errors, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		if rest, ok := strings.CutPrefix(fieldErr.Pointer, validation.Pointer("items")+"/"); ok {
+			index, field, _ := strings.Cut(rest, "/")
+			if i, convErr := strconv.Atoi(index); convErr == nil && i < len(itemIDs) {
+				fieldErr.Pointer = validation.Pointer("items", itemIDs[i])
+				if field != "" && field != "id" {
+					fieldErr.Pointer += "/" + field
+				}
+			}
+		}
+		rewritten = append(rewritten, fieldErr)
+	}
+	return rewritten
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+	"order-service/internal/domain/tax"
+)
+
+type RemoveOrderItemUseCase interface {
+	Execute(ctx context.Context, orderID, itemID string, expectedVersion int64) (dtos.OrderOutput, error)
+}
+
+type removeOrderItemUseCase struct {
+	orderRepository repository.OrderRepository
+	stock           stockReservations
+	coupons         coupons
+	taxes           taxes
+}
+
+// NewRemoveOrderItemUseCase builds the use case; inventoryService, promotionRepo and
+// taxCalculator may be nil when no inventory, promotions or taxes are configured.
+func NewRemoveOrderItemUseCase(orderRepo repository.OrderRepository, inventoryService inventory.InventoryService, promotionRepo repository.PromotionRepository, taxCalculator tax.Calculator) RemoveOrderItemUseCase {
+	return &removeOrderItemUseCase{
+		orderRepository: orderRepo,
+		stock:           stockReservations{inventory: inventoryService},
+		coupons:         coupons{promotions: promotionRepo},
+		taxes:           taxes{calculator: taxCalculator},
+	}
+}
+
+// Execute removes an item from a pending order; the last item of an order cannot be removed.
+func (u *removeOrderItemUseCase) Execute(ctx context.Context, orderID, itemID string, expectedVersion int64) (dtos.OrderOutput, error) {
+	order, err := findEditableOrder(ctx, u.orderRepository, orderID, expectedVersion)
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	if err := order.RemoveItem(itemID); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.coupons.apply(ctx, order, order.CouponCode); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.taxes.apply(ctx, order, order.TaxJurisdiction); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	err = saveOrder(ctx, u.orderRepository, order, u.stock.adjust(order))
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	return dtos.FromEntityToOrderOutput(order), nil
+}
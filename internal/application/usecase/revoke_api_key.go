@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+type RevokeAPIKeyUseCase interface {
+	Execute(ctx context.Context, id string) (dtos.APIKeyOutput, error)
+}
+
+type revokeAPIKeyUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func NewRevokeAPIKeyUseCase(apiKeyRepo repository.APIKeyRepository) RevokeAPIKeyUseCase {
+	return &revokeAPIKeyUseCase{
+		apiKeyRepository: apiKeyRepo,
+	}
+}
+
+// Execute stops a key from being used at once. Revoking a revoked key changes nothing.
+func (u *revokeAPIKeyUseCase) Execute(ctx context.Context, id string) (dtos.APIKeyOutput, error) {
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return dtos.APIKeyOutput{}, err
+	}
+
+	key, err := findAPIKey(ctx, u.apiKeyRepository, id)
+	if err != nil {
+		return dtos.APIKeyOutput{}, err
+	}
+
+	if key.RevokedAt == nil {
+		key.Revoke(time.Now())
+		if err := u.apiKeyRepository.Save(ctx, key); err != nil {
+			return dtos.APIKeyOutput{}, err
+		}
+	}
+
+	return dtos.FromEntityToAPIKeyOutput(key), nil
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+)
+
+// RotateAPIKeyUseCase replaces an API key with a new one of the same name, scopes and expiry. The old
+// key keeps working for the grace period of the input, giving the service time to switch.
+type RotateAPIKeyUseCase interface {
+	Execute(ctx context.Context, id string, input dtos.RotateAPIKeyInput) (dtos.IssuedAPIKeyOutput, error)
+}
+
+type rotateAPIKeyUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func NewRotateAPIKeyUseCase(apiKeyRepo repository.APIKeyRepository) RotateAPIKeyUseCase {
+	return &rotateAPIKeyUseCase{
+		apiKeyRepository: apiKeyRepo,
+	}
+}
+
+func (u *rotateAPIKeyUseCase) Execute(ctx context.Context, id string, input dtos.RotateAPIKeyInput) (dtos.IssuedAPIKeyOutput, error) {
+	if err := requireRole(ctx, auth.RoleAdmin); err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+
+	key, err := findAPIKey(ctx, u.apiKeyRepository, id)
+	if err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+	now := time.Now()
+	if !key.Active(now) {
+		return dtos.IssuedAPIKeyOutput{}, ErrAPIKeyInactive
+	}
+
+	replacement, secret, err := auth.NewAPIKey(generateID(), key.Name, key.Scopes, now)
+	if err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+	if key.ExpiresAt != nil {
+		replacement.ExpireBy(*key.ExpiresAt)
+	}
+	// The replacement is saved first, so a failure leaves the old key working rather than neither.
+	if err := u.apiKeyRepository.Save(ctx, replacement); err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+	key.ExpireBy(now.Add(input.GraceDuration()))
+	if err := u.apiKeyRepository.Save(ctx, key); err != nil {
+		return dtos.IssuedAPIKeyOutput{}, err
+	}
+
+	return dtos.IssuedAPIKeyOutput{APIKeyOutput: dtos.FromEntityToAPIKeyOutput(replacement), Key: secret}, nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+)
+
+// sideEffect is a change made in another service on behalf of an order, such as reserving stock. The
+// nil sideEffect does nothing.
+type sideEffect func(context.Context) error
+
+// saveOrder saves the order together with its side effects. The repository applies them only once it
+// knows the order is still at the version it was loaded with, and keeps none of them when the save
+// fails, so other services never keep changes for an order that was not stored.
+func saveOrder(ctx context.Context, repo repository.OrderRepository, order *entity.Order, effects ...sideEffect) error {
+	var applied []repository.SideEffect
+	for _, effect := range effects {
+		if effect != nil {
+			applied = append(applied, repository.SideEffect(effect))
+		}
+	}
+	return repo.Save(ctx, order, applied...)
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
+)
+
+// stockReservations keeps the stock held for an order in step with its items. Without an inventory
+// service its side effects do nothing.
+type stockReservations struct {
+	inventory inventory.InventoryService
+}
+
+// reserve holds stock for a new order.
+func (s stockReservations) reserve(order *entity.Order) sideEffect {
+	if s.inventory == nil {
+		return nil
+	}
+	reservations := reservationsFor(order.Items)
+	return func(ctx context.Context) error { return s.inventory.Reserve(ctx, order.ID, reservations) }
+}
+
+// adjust moves the stock held for an order to its current items.
+func (s stockReservations) adjust(order *entity.Order) sideEffect {
+	if s.inventory == nil {
+		return nil
+	}
+	reservations := reservationsFor(order.Items)
+	return func(ctx context.Context) error { return s.inventory.Adjust(ctx, order.ID, reservations) }
+}
+
+// release frees the stock held for a canceled order.
+func (s stockReservations) release(order *entity.Order) sideEffect {
+	if s.inventory == nil {
+		return nil
+	}
+	return func(ctx context.Context) error { return s.inventory.Release(ctx, order.ID) }
+}
+
+// consume takes the stock held for a completed order out of stock.
+func (s stockReservations) consume(order *entity.Order) sideEffect {
+	if s.inventory == nil {
+		return nil
+	}
+	return func(ctx context.Context) error { return s.inventory.Consume(ctx, order.ID) }
+}
+
+// reservationsFor totals the quantities of items per product; items without a product are not reserved.
+func reservationsFor(items []entity.Item) []inventory.Reservation {
+	var reservations []inventory.Reservation
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		if item.ProductID == "" {
+			continue
+		}
+		if i, ok := index[item.ProductID]; ok {
+			reservations[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductID] = len(reservations)
+		reservations = append(reservations, inventory.Reservation{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return reservations
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/tax"
+)
+
+// taxes works out the tax of the items of orders. Without a calculator orders are not taxed and
+// have no jurisdiction.
+type taxes struct {
+	calculator tax.Calculator
+}
+
+// apply taxes the current items of a pending order, after discounts, in jurisdiction; an empty
+// jurisdiction selects the default one of the calculator.
+func (t taxes) apply(ctx context.Context, order *entity.Order, jurisdiction string) error {
+	if t.calculator == nil {
+		untaxed := make([]entity.Money, len(order.Items))
+		for i := range untaxed {
+			untaxed[i] = entity.NewMoney(0, order.Currency)
+		}
+		return order.ApplyTaxes("", untaxed)
+	}
+
+	netTotals := order.NetItemTotals()
+	lines := make([]tax.Line, len(order.Items))
+	for i, item := range order.Items {
+		lines[i] = tax.Line{Category: item.Category, Amount: netTotals[i]}
+	}
+
+	assessment, err := t.calculator.Calculate(ctx, jurisdiction, lines)
+	if errors.Is(err, tax.ErrUnknownJurisdiction) {
+		var errs validation.Errors
+		errs.Add(validation.Pointer("tax_jurisdiction"), validation.CodeUnknownJurisdiction, "is not a known tax jurisdiction")
+		return errs
+	}
+	if err != nil {
+		return err
+	}
+
+	return order.ApplyTaxes(assessment.Jurisdiction, assessment.Taxes)
+}
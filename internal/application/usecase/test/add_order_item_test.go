@@ -0,0 +1,109 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newItemOrder() *entity.Order {
+	return &entity.Order{
+		ID:           "123",
+		CustomerName: "John",
+		Currency:     entity.DefaultCurrency,
+		Status:       entity.Pending,
+		Version:      1,
+		Items: []entity.Item{
+			{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		},
+	}
+}
+
+func TestAddOrderItemUseCase_Execute(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(newItemOrder(), nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewAddOrderItemUseCase(mockRepo, nil, nil, nil, nil).Execute(context.Background(), "123", 1, dtos.ItemInput{
+		Name: "Item 2", Quantity: 2, Price: entity.NewMoney(500, ""),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 2)
+	assert.NotEmpty(t, output.Items[1].ID)
+	assert.Equal(t, entity.NewMoney(500, entity.DefaultCurrency), output.Items[1].Price)
+	assert.Equal(t, entity.NewMoney(2000, entity.DefaultCurrency), output.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddOrderItemUseCase_LegacyID(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(newItemOrder(), nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewAddOrderItemUseCase(mockRepo, nil, nil, nil, nil).Execute(context.Background(), "123", 1, dtos.ItemInput{
+		ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(500, ""),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 2)
+	assert.NotEqual(t, "item1", output.Items[1].ID, "line IDs are generated by the server")
+	assert.Equal(t, "item1", output.Items[1].ProductID, "a legacy id is the product")
+}
+
+func TestAddOrderItemUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		order       *entity.Order
+		input       dtos.ItemInput
+		expectedErr error
+	}{
+		{
+			name:        "invalid item",
+			order:       newItemOrder(),
+			input:       dtos.ItemInput{Name: "Item 2", Quantity: 0, Price: entity.NewMoney(500, "")},
+			expectedErr: validation.Errors{{Pointer: "/quantity", Code: validation.CodeTooSmall, Message: "must be at least 1"}},
+		},
+		{
+			name:        "id that is not the product",
+			order:       newItemOrder(),
+			input:       dtos.ItemInput{ID: "item1", ProductID: "sku-a", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(500, "")},
+			expectedErr: validation.Errors{{Pointer: "/id", Code: validation.CodeProductIDMismatch, Message: "is assigned by the server; send the product in product_id"}},
+		},
+		{
+			name: "order not pending",
+			order: func() *entity.Order {
+				order := newItemOrder()
+				order.Status = entity.Canceled
+				return order
+			}(),
+			input:       dtos.ItemInput{Name: "Item 2", Quantity: 1, Price: entity.NewMoney(500, "")},
+			expectedErr: usecase.ErrOrderNotEditable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			mockRepo.On("FindByID", mock.Anything, "123").Return(tt.order, nil)
+
+			_, err := usecase.NewAddOrderItemUseCase(mockRepo, nil, nil, nil, nil).Execute(context.Background(), "123", usecase.AnyVersion, tt.input)
+
+			if fieldErrs, ok := tt.expectedErr.(validation.Errors); ok {
+				assert.Equal(t, fieldErrs, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+			mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
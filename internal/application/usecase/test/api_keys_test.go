@@ -0,0 +1,232 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func adminContext() context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{Subject: "admin", Roles: []auth.Role{auth.RoleAdmin}})
+}
+
+func serviceContext(scopes ...auth.Scope) context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{Subject: "api-key:k1", Roles: []auth.Role{auth.RoleService}, Scopes: scopes})
+}
+
+func TestIssueAPIKeyUseCase(t *testing.T) {
+	mockRepo := new(usecasemock.MockAPIKeyRepository)
+	var saved *auth.APIKey
+	mockRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*auth.APIKey)
+	}).Return(nil)
+
+	output, err := usecase.NewIssueAPIKeyUseCase(mockRepo).Execute(adminContext(), dtos.APIKeyInput{
+		Name:   " nightly export ",
+		Scopes: []string{"orders:read", "orders:cancel"},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, "nightly export", output.Name)
+	assert.Equal(t, []string{"orders:read", "orders:cancel"}, output.Scopes)
+	assert.Equal(t, auth.HashAPIKey(output.Key), saved.Hash, "only the hash of the key is stored")
+	assert.Equal(t, saved.Prefix, output.Prefix)
+}
+
+func TestIssueAPIKeyUseCase_Invalid(t *testing.T) {
+	mockRepo := new(usecasemock.MockAPIKeyRepository)
+	useCase := usecase.NewIssueAPIKeyUseCase(mockRepo)
+
+	_, err := useCase.Execute(adminContext(), dtos.APIKeyInput{Name: "export", Scopes: []string{"orders:read", "orders:delete", "orders:read"}})
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/scopes/1", Code: validation.CodeUnknownScope, Message: "must be one of orders:read, orders:write, orders:cancel"},
+		{Pointer: "/scopes/2", Code: validation.CodeDuplicate, Message: "duplicates scope 0"},
+	}, err)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = useCase.Execute(adminContext(), dtos.APIKeyInput{Name: "export", Scopes: []string{"orders:read"}, ExpiresAt: &past})
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/expires_at", Code: validation.CodeTooSmall, Message: "must be in the future"},
+	}, err)
+
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyUseCases_RequireAdmin(t *testing.T) {
+	mockRepo := new(usecasemock.MockAPIKeyRepository)
+	ctx := supportContext()
+
+	_, err := usecase.NewIssueAPIKeyUseCase(mockRepo).Execute(ctx, dtos.APIKeyInput{Name: "export", Scopes: []string{"orders:read"}})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	_, err = usecase.NewListAPIKeysUseCase(mockRepo).Execute(ctx)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	_, err = usecase.NewGetAPIKeyUseCase(mockRepo).Execute(ctx, "k1")
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	_, err = usecase.NewRotateAPIKeyUseCase(mockRepo).Execute(ctx, "k1", dtos.RotateAPIKeyInput{})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	_, err = usecase.NewRevokeAPIKeyUseCase(mockRepo).Execute(ctx, "k1")
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRotateAPIKeyUseCase(t *testing.T) {
+	now := time.Now()
+	key, _, err := auth.NewAPIKey("k1", "nightly export", []auth.Scope{auth.ScopeOrdersRead}, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	mockRepo := new(usecasemock.MockAPIKeyRepository)
+	mockRepo.On("FindByID", mock.Anything, "k1").Return(key, nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(saved *auth.APIKey) bool {
+		return saved.ID != "k1" && saved.Name == "nightly export"
+	})).Return(nil).Once()
+	mockRepo.On("Save", mock.Anything, key).Return(nil).Once()
+
+	output, err := usecase.NewRotateAPIKeyUseCase(mockRepo).Execute(adminContext(), "k1", dtos.RotateAPIKeyInput{GracePeriod: "1h"})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, "k1", output.ID)
+	assert.Equal(t, []string{"orders:read"}, output.Scopes)
+	assert.NotEmpty(t, output.Key)
+	require.NotNil(t, key.ExpiresAt)
+	assert.WithinDuration(t, now.Add(time.Hour), *key.ExpiresAt, time.Minute, "the old key works for the grace period")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRotateAPIKeyUseCase_KeepsExpiry(t *testing.T) {
+	now := time.Now()
+	key, _, err := auth.NewAPIKey("k1", "nightly export", []auth.Scope{auth.ScopeOrdersRead}, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	expiresAt := now.Add(30 * 24 * time.Hour)
+	key.ExpireBy(expiresAt)
+
+	mockRepo := new(usecasemock.MockAPIKeyRepository)
+	mockRepo.On("FindByID", mock.Anything, "k1").Return(key, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewRotateAPIKeyUseCase(mockRepo).Execute(adminContext(), "k1", dtos.RotateAPIKeyInput{})
+
+	require.NoError(t, err)
+	require.NotNil(t, output.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*output.ExpiresAt), "the replacement expires when the old key would have")
+}
+
+func TestGetAPIKeyUseCase(t *testing.T) {
+	key, _, err := auth.NewAPIKey("k1", "nightly export", []auth.Scope{auth.ScopeOrdersRead}, time.Now())
+	require.NoError(t, err)
+	mockRepo := new(usecasemock.MockAPIKeyRepository)
+	mockRepo.On("FindByID", mock.Anything, "k1").Return(key, nil)
+	mockRepo.On("FindByID", mock.Anything, "k2").Return(nil, repository.ErrAPIKeyNotFound)
+	useCase := usecase.NewGetAPIKeyUseCase(mockRepo)
+
+	output, err := useCase.Execute(adminContext(), "k1")
+	require.NoError(t, err)
+	assert.Equal(t, "nightly export", output.Name)
+
+	_, err = useCase.Execute(adminContext(), "k2")
+	assert.ErrorIs(t, err, usecase.ErrAPIKeyNotFound)
+}
+
+func TestRotateAPIKeyUseCase_Rejects(t *testing.T) {
+	t.Run("invalid grace period", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockAPIKeyRepository)
+
+		_, err := usecase.NewRotateAPIKeyUseCase(mockRepo).Execute(adminContext(), "k1", dtos.RotateAPIKeyInput{GracePeriod: "720h"})
+
+		assert.Equal(t, validation.Errors{
+			{Pointer: "/grace_period", Code: validation.CodeTooLarge, Message: "must not be longer than 168h0m0s"},
+		}, err)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		key := &auth.APIKey{ID: "k1"}
+		key.Revoke(time.Now())
+		mockRepo := new(usecasemock.MockAPIKeyRepository)
+		mockRepo.On("FindByID", mock.Anything, "k1").Return(key, nil)
+
+		_, err := usecase.NewRotateAPIKeyUseCase(mockRepo).Execute(adminContext(), "k1", dtos.RotateAPIKeyInput{})
+
+		assert.ErrorIs(t, err, usecase.ErrAPIKeyInactive)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockAPIKeyRepository)
+		mockRepo.On("FindByID", mock.Anything, "k9").Return(nil, repository.ErrAPIKeyNotFound)
+
+		_, err := usecase.NewRotateAPIKeyUseCase(mockRepo).Execute(adminContext(), "k9", dtos.RotateAPIKeyInput{})
+
+		assert.ErrorIs(t, err, usecase.ErrAPIKeyNotFound)
+	})
+}
+
+func TestRevokeAPIKeyUseCase(t *testing.T) {
+	key := &auth.APIKey{ID: "k1", Name: "nightly export"}
+	mockRepo := new(usecasemock.MockAPIKeyRepository)
+	mockRepo.On("FindByID", mock.Anything, "k1").Return(key, nil)
+	mockRepo.On("Save", mock.Anything, key).Return(nil).Once()
+	useCase := usecase.NewRevokeAPIKeyUseCase(mockRepo)
+
+	// Without a principal, as from the apikey command, the caller is trusted.
+	output, err := useCase.Execute(context.Background(), "k1")
+	require.NoError(t, err)
+	require.NotNil(t, output.RevokedAt)
+
+	again, err := useCase.Execute(context.Background(), "k1")
+	require.NoError(t, err)
+	assert.Equal(t, output.RevokedAt, again.RevokedAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestServiceScopes(t *testing.T) {
+	t.Run("read scope reaches the orders of every customer", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockRepo.On("FindByID", mock.Anything, "order123").Return(customerOrder(t, "c1"), nil)
+
+		_, err := usecase.NewGetOrderUseCase(mockRepo, nil).Execute(serviceContext(auth.ScopeOrdersRead), "order123", "")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("cancel needs its scope", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+
+		_, err := usecase.NewCancelOrderUseCase(mockRepo, nil, nil).Execute(serviceContext(auth.ScopeOrdersRead, auth.ScopeOrdersWrite), "order123", 1, "")
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("changes need the write scope", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+
+		_, err := usecase.NewRemoveOrderItemUseCase(mockRepo, nil, nil, nil).Execute(serviceContext(auth.ScopeOrdersCancel), "order123", "1", 1)
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("listing is not scoped to a customer", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockRepo.On("List", mock.Anything, mock.MatchedBy(func(query repository.OrderQuery) bool {
+			return query.CustomerID == ""
+		})).Return(repository.OrderPage{}, nil)
+
+		_, err := usecase.NewListOrderUseCase(mockRepo).Execute(serviceContext(auth.ScopeOrdersRead), dtos.ListOrderInput{})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,176 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func customerContext(customerID string) context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{Subject: "user-" + customerID, CustomerID: customerID})
+}
+
+func supportContext() context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{Subject: "agent", Roles: []auth.Role{auth.RoleSupport}})
+}
+
+func customerOrder(t *testing.T, customerID string) *entity.Order {
+	order, err := entity.NewOrder("order123", "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: "1", Name: "Radio", Quantity: 1, Price: entity.NewMoney(4000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	order.CustomerID = customerID
+	return order
+}
+
+func TestGetOrderUseCase_Authorization(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "owner", ctx: customerContext("c1")},
+		{name: "support", ctx: supportContext()},
+		{name: "no principal", ctx: context.Background()},
+		{name: "other customer", ctx: customerContext("c2"), wantErr: auth.ErrForbidden},
+		{name: "principal without customer", ctx: auth.NewContext(context.Background(), auth.Principal{Subject: "someone"}), wantErr: auth.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			mockRepo.On("FindByID", mock.Anything, "order123").Return(customerOrder(t, "c1"), nil)
+
+			_, err := usecase.NewGetOrderUseCase(mockRepo, nil).Execute(tt.ctx, "order123", "")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCancelOrderUseCase_OtherCustomerForbidden(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "order123").Return(customerOrder(t, "c1"), nil)
+
+	_, err := usecase.NewCancelOrderUseCase(mockRepo, nil, nil).Execute(customerContext("c2"), "order123", 0, "")
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestAddOrderItemUseCase_OtherCustomerForbidden(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "order123").Return(customerOrder(t, "c1"), nil)
+
+	_, err := usecase.NewAddOrderItemUseCase(mockRepo, nil, nil, nil, nil).Execute(customerContext("c2"), "order123", 0, dtos.ItemInput{
+		Name: "Lamp", Quantity: 1, Price: entity.NewMoney(1500, ""),
+	})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrderUseCase_PlacesOrderForCallingCustomer(t *testing.T) {
+	customerRepo := new(usecasemock.MockCustomerRepository)
+	customerRepo.On("FindByID", mock.Anything, "c1").Return(&entity.Customer{ID: "c1", Name: "John Doe"}, nil)
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, customerRepo).Execute(customerContext("c1"), dtos.OrderInput{
+		Items: []dtos.ItemInput{{Name: "Radio", Quantity: 1, Price: entity.NewMoney(4000, "")}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "c1", output.CustomerID)
+	assert.Equal(t, "John Doe", output.CustomerName)
+}
+
+func TestCreateOrderUseCase_ForOtherCustomer(t *testing.T) {
+	input := dtos.OrderInput{
+		CustomerID: "c2",
+		Items:      []dtos.ItemInput{{Name: "Radio", Quantity: 1, Price: entity.NewMoney(4000, "")}},
+	}
+
+	t.Run("customer is forbidden", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+
+		_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(customerContext("c1"), input)
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("support is allowed", func(t *testing.T) {
+		customerRepo := new(usecasemock.MockCustomerRepository)
+		customerRepo.On("FindByID", mock.Anything, "c2").Return(&entity.Customer{ID: "c2", Name: "Jane Doe"}, nil)
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		output, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, customerRepo).Execute(supportContext(), input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "c2", output.CustomerID)
+	})
+}
+
+func TestListOrderUseCase_ScopedToCallingCustomer(t *testing.T) {
+	t.Run("own orders", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockRepo.On("List", mock.Anything, mock.MatchedBy(func(query repository.OrderQuery) bool {
+			return query.CustomerID == "c1"
+		})).Return(repository.OrderPage{}, nil)
+
+		_, err := usecase.NewListOrderUseCase(mockRepo).Execute(customerContext("c1"), dtos.ListOrderInput{})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("other customer", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+
+		_, err := usecase.NewListOrderUseCase(mockRepo).Execute(customerContext("c1"), dtos.ListOrderInput{CustomerID: "c2"})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("support sees every order", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockRepo.On("List", mock.Anything, mock.MatchedBy(func(query repository.OrderQuery) bool {
+			return query.CustomerID == ""
+		})).Return(repository.OrderPage{}, nil)
+
+		_, err := usecase.NewListOrderUseCase(mockRepo).Execute(supportContext(), dtos.ListOrderInput{})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCustomerUseCases_Authorization(t *testing.T) {
+	customerRepo := new(usecasemock.MockCustomerRepository)
+
+	_, err := usecase.NewCreateCustomerUseCase(customerRepo).Execute(customerContext("c1"), dtos.CustomerInput{Name: "Jane Doe"})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	_, err = usecase.NewListCustomersUseCase(customerRepo).Execute(customerContext("c1"), dtos.ListCustomersInput{})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	_, err = usecase.NewGetCustomerUseCase(customerRepo).Execute(customerContext("c1"), "c2")
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	customerRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	customerRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	customerRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
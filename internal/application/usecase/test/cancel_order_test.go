@@ -8,6 +8,7 @@ import (
 	"order-service/internal/application/dtos"
 	"order-service/internal/application/usecase"
 	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/auth"
 	"order-service/internal/domain/entity"
 	"order-service/internal/domain/repository"
 
@@ -30,16 +31,26 @@ func TestCancelOrderUseCase_Execute(t *testing.T) {
 				order := &entity.Order{
 					ID:           "123",
 					CustomerName: "John",
+					Currency:     entity.DefaultCurrency,
 					Status:       entity.Pending,
 				}
 				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
-				mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+				mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+					events := order.Events()
+					return len(events) == 1 && events[0].Type == entity.OrderCanceledEvent &&
+						events[0].ChangedBy == usecase.ActorAPI && events[0].Reason == "changed my mind"
+				})).Return(nil)
 			},
 			expected: dtos.OrderOutput{
-				ID:           "123",
-				CustomerName: "John",
-				Total:        0,
-				Status:       "canceled",
+				ID:            "123",
+				CustomerName:  "John",
+				Currency:      entity.DefaultCurrency,
+				Subtotal:      entity.NewMoney(0, entity.DefaultCurrency),
+				DiscountTotal: entity.NewMoney(0, entity.DefaultCurrency),
+				Total:         entity.NewMoney(0, entity.DefaultCurrency),
+				Tax:           entity.NewMoney(0, entity.DefaultCurrency),
+				TotalWithTax:  entity.NewMoney(0, entity.DefaultCurrency),
+				Status:        "canceled",
 			},
 			expectedErr: nil,
 		},
@@ -59,12 +70,13 @@ func TestCancelOrderUseCase_Execute(t *testing.T) {
 				order := &entity.Order{
 					ID:           "123",
 					CustomerName: "John",
+					Currency:     entity.DefaultCurrency,
 					Status:       entity.Completed,
 				}
 				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
 			},
 			expected:    dtos.OrderOutput{},
-			expectedErr: errors.New("only pending orders can be canceled"),
+			expectedErr: &entity.InvalidTransitionError{From: entity.Completed, To: entity.Canceled},
 		},
 	}
 
@@ -72,9 +84,9 @@ func TestCancelOrderUseCase_Execute(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(usecasemock.MockOrderRepository)
 			tt.setupMocks(mockRepo)
-			cancelOrderUseCase := usecase.NewCancelOrderUseCase(mockRepo)
+			cancelOrderUseCase := usecase.NewCancelOrderUseCase(mockRepo, nil, nil)
 
-			result, err := cancelOrderUseCase.Execute(context.Background(), tt.id)
+			result, err := cancelOrderUseCase.Execute(context.Background(), tt.id, usecase.AnyVersion, "changed my mind")
 
 			if tt.expectedErr != nil {
 				assert.EqualError(t, err, tt.expectedErr.Error())
@@ -87,3 +99,32 @@ func TestCancelOrderUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestCancelOrderUseCase_VersionMismatch(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(&entity.Order{ID: "123", Status: entity.Pending, Version: 3}, nil)
+
+	_, err := usecase.NewCancelOrderUseCase(mockRepo, nil, nil).Execute(context.Background(), "123", 2, "")
+
+	assert.ErrorIs(t, err, repository.ErrConcurrentModification)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCancelOrderUseCase_RecordsCaller(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(&entity.Order{ID: "123", Status: entity.Pending}, nil)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+		events := order.Events()
+		return len(events) == 1 && events[0].ChangedBy == "api-key:k1"
+	})).Return(nil)
+	ctx := auth.NewContext(context.Background(), auth.Principal{
+		Subject: "api-key:k1",
+		Roles:   []auth.Role{auth.RoleService},
+		Scopes:  []auth.Scope{auth.ScopeOrdersCancel},
+	})
+
+	_, err := usecase.NewCancelOrderUseCase(mockRepo, nil, nil).Execute(ctx, "123", usecase.AnyVersion, "")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
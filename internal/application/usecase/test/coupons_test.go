@@ -0,0 +1,187 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newCouponInput(code string) dtos.OrderInput {
+	return dtos.OrderInput{
+		CustomerName: "John Doe",
+		CouponCode:   code,
+		Items: []dtos.ItemInput{
+			{ProductID: "sku-a", Name: "Item A", Quantity: 3, Price: entity.NewMoney(1000, "")},
+			{ProductID: "sku-b", Name: "Item B", Quantity: 1, Price: entity.NewMoney(2000, "")},
+		},
+	}
+}
+
+func TestCreateOrderUseCase_AppliesCoupon(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockPromotions := new(usecasemock.MockPromotionRepository)
+	mockPromotions.On("FindByCode", mock.Anything, "TEN").
+		Return(&entity.Promotion{Code: "TEN", Description: "10% off", Rule: entity.PromotionPercentage, PercentOff: 10}, nil)
+	mockPromotions.On("Redeem", mock.Anything, "TEN", mock.Anything).Return(nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, mockPromotions, nil, nil).Execute(context.Background(), newCouponInput("TEN"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "TEN", output.CouponCode)
+	assert.Equal(t, []dtos.DiscountOutput{{Code: "TEN", Description: "10% off", Amount: entity.NewMoney(500, entity.DefaultCurrency)}}, output.Discounts)
+	assert.Equal(t, entity.NewMoney(5000, entity.DefaultCurrency), output.Subtotal)
+	assert.Equal(t, entity.NewMoney(500, entity.DefaultCurrency), output.DiscountTotal)
+	assert.Equal(t, entity.NewMoney(4500, entity.DefaultCurrency), output.Total)
+	mockPromotions.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrderUseCase_CouponErrors(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name         string
+		promotion    *entity.Promotion
+		findErr      error
+		redeemErr    error
+		expectedCode string
+	}{
+		{
+			name:         "unknown coupon",
+			findErr:      fmt.Errorf("%w: NOPE", repository.ErrPromotionNotFound),
+			expectedCode: validation.CodeUnknownCoupon,
+		},
+		{
+			name:         "expired coupon",
+			promotion:    &entity.Promotion{Code: "NOPE", Rule: entity.PromotionPercentage, PercentOff: 10, ValidUntil: &past},
+			expectedCode: validation.CodeCouponInactive,
+		},
+		{
+			name:         "coupon for another product",
+			promotion:    &entity.Promotion{Code: "NOPE", Rule: entity.PromotionPercentage, PercentOff: 10, ProductID: "sku-x"},
+			expectedCode: validation.CodeCouponNotApplicable,
+		},
+		{
+			name:         "exhausted coupon",
+			promotion:    &entity.Promotion{Code: "NOPE", Rule: entity.PromotionPercentage, PercentOff: 10},
+			redeemErr:    fmt.Errorf("%w: NOPE", entity.ErrPromotionExhausted),
+			expectedCode: validation.CodeCouponExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			mockPromotions := new(usecasemock.MockPromotionRepository)
+			mockPromotions.On("FindByCode", mock.Anything, "NOPE").Return(tt.promotion, tt.findErr)
+			mockPromotions.On("Redeem", mock.Anything, "NOPE", mock.Anything).Return(tt.redeemErr)
+			mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, mockPromotions, nil, nil).Execute(context.Background(), newCouponInput("NOPE"))
+
+			var fieldErrs validation.Errors
+			require.ErrorAs(t, err, &fieldErrs)
+			require.Len(t, fieldErrs, 1)
+			assert.Equal(t, "/coupon_code", fieldErrs[0].Pointer)
+			assert.Equal(t, tt.expectedCode, fieldErrs[0].Code)
+			if tt.redeemErr == nil {
+				mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCreateOrderUseCase_DoesNotRedeemCouponWhenSaveFails(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockPromotions := new(usecasemock.MockPromotionRepository)
+	mockPromotions.On("FindByCode", mock.Anything, "TEN").
+		Return(&entity.Promotion{Code: "TEN", Rule: entity.PromotionPercentage, PercentOff: 10}, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(repository.ErrConcurrentModification)
+
+	_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, mockPromotions, nil, nil).Execute(context.Background(), newCouponInput("TEN"))
+
+	assert.ErrorIs(t, err, repository.ErrConcurrentModification)
+	mockPromotions.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderUseCase_ChangesCoupon(t *testing.T) {
+	order := newItemOrder()
+	require.NoError(t, order.ApplyDiscounts("OLD", []entity.Discount{{Code: "OLD", Amount: entity.NewMoney(100, entity.DefaultCurrency)}}))
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockPromotions := new(usecasemock.MockPromotionRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockPromotions.On("FindByCode", mock.Anything, "FIVE").
+		Return(&entity.Promotion{Code: "FIVE", Rule: entity.PromotionFixed, AmountOff: entity.NewMoney(500, entity.DefaultCurrency)}, nil)
+	mockPromotions.On("Redeem", mock.Anything, "FIVE", "123").Return(nil)
+	mockPromotions.On("Release", mock.Anything, "OLD", "123").Return(nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewUpdateOrderUseCase(mockRepo, nil, nil, mockPromotions, nil, nil).Execute(context.Background(), "123", 1, newCouponInput("FIVE"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "FIVE", output.CouponCode)
+	assert.Equal(t, entity.NewMoney(4500, entity.DefaultCurrency), output.Total)
+	mockPromotions.AssertExpectations(t)
+}
+
+func TestRemoveOrderItemUseCase_KeepsExpiredCoupon(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	order := newItemOrder()
+	order.Items = append(order.Items, entity.Item{ID: "item2", Name: "Item 2", Quantity: 1, Price: entity.NewMoney(2000, entity.DefaultCurrency)})
+	require.NoError(t, order.ApplyDiscounts("TEN", []entity.Discount{{Code: "TEN", Amount: entity.NewMoney(300, entity.DefaultCurrency)}}))
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockPromotions := new(usecasemock.MockPromotionRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockPromotions.On("FindByCode", mock.Anything, "TEN").
+		Return(&entity.Promotion{Code: "TEN", Rule: entity.PromotionPercentage, PercentOff: 10, ValidUntil: &past}, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewRemoveOrderItemUseCase(mockRepo, nil, mockPromotions, nil).Execute(context.Background(), "123", "item2", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.NewMoney(100, entity.DefaultCurrency), output.DiscountTotal)
+	assert.Equal(t, entity.NewMoney(900, entity.DefaultCurrency), output.Total)
+	mockPromotions.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelOrderUseCase_ReleasesCoupon(t *testing.T) {
+	order := newItemOrder()
+	require.NoError(t, order.ApplyDiscounts("TEN", []entity.Discount{{Code: "TEN", Amount: entity.NewMoney(100, entity.DefaultCurrency)}}))
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockPromotions := new(usecasemock.MockPromotionRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockPromotions.On("Release", mock.Anything, "TEN", "123").Return(nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := usecase.NewCancelOrderUseCase(mockRepo, nil, mockPromotions).Execute(context.Background(), "123", 1, "")
+
+	require.NoError(t, err)
+	mockPromotions.AssertExpectations(t)
+}
+
+func TestCancelOrderUseCase_KeepsCouponWhenSaveLosesRace(t *testing.T) {
+	order := newItemOrder()
+	require.NoError(t, order.ApplyDiscounts("TEN", []entity.Discount{{Code: "TEN", Amount: entity.NewMoney(100, entity.DefaultCurrency)}}))
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockPromotions := new(usecasemock.MockPromotionRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(repository.ErrConcurrentModification)
+
+	_, err := usecase.NewCancelOrderUseCase(mockRepo, nil, mockPromotions).Execute(context.Background(), "123", 1, "")
+
+	assert.ErrorIs(t, err, repository.ErrConcurrentModification)
+	mockPromotions.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything)
+	mockPromotions.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -8,18 +8,19 @@ import (
 	"order-service/internal/application/dtos"
 	"order-service/internal/application/usecase"
 	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
 	"order-service/internal/domain/entity"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateOrderUseCase(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockRepo := new(usecasemock.MockOrderRepository)
-		mockPub := new(usecasemock.MockOrderPublisher)
-		useCase := usecase.NewCreateOrderUseCase(mockRepo, mockPub)
+		useCase := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil)
 
 		input := dtos.OrderInput{
 			CustomerName: "John Doe",
@@ -28,19 +29,21 @@ func TestCreateOrderUseCase(t *testing.T) {
 					ID:       "1",
 					Name:     "Item 1",
 					Quantity: 2,
-					Price:    10.0,
+					Price:    entity.NewMoney(1000, entity.DefaultCurrency),
 				},
 			},
 		}
 
-		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
-		mockPub.On("PublishCreatedOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
+		mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+			events := order.Events()
+			return len(events) == 1 && events[0].Type == entity.OrderCreatedEvent
+		})).Return(nil)
 
 		mockOrder := &entity.Order{
 			ID:           uuid.New().String(),
 			CustomerName: "John Doe",
 			Status:       entity.Pending,
-			Items:        []entity.Item{{ID: "1", Name: "Item 1", Quantity: 2, Price: 10.0}},
+			Items:        []entity.Item{{ID: "1", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
 		}
 
 		expectedOutput := dtos.FromEntityToOrderOutput(mockOrder)
@@ -56,13 +59,32 @@ func TestCreateOrderUseCase(t *testing.T) {
 		assert.Len(t, output.Items, len(expectedOutput.Items))
 
 		mockRepo.AssertExpectations(t)
-		mockPub.AssertExpectations(t)
+	})
+
+	t.Run("generates item line IDs", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		output, err := useCase.Execute(context.Background(), dtos.OrderInput{
+			CustomerName: "John Doe",
+			Items: []dtos.ItemInput{
+				{ProductID: "123459", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+				{ProductID: "123459", Name: "Item 1 gift wrapped", Quantity: 1, Price: entity.NewMoney(1200, entity.DefaultCurrency)},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, output.Items, 2)
+		assert.NotEmpty(t, output.Items[0].ID)
+		assert.NotEqual(t, output.Items[0].ID, output.Items[1].ID)
+		assert.Equal(t, "123459", output.Items[0].ProductID)
+		assert.Equal(t, "123459", output.Items[1].ProductID)
 	})
 
 	t.Run("empty items", func(t *testing.T) {
 		mockRepo := new(usecasemock.MockOrderRepository)
-		mockPub := new(usecasemock.MockOrderPublisher)
-		useCase := usecase.NewCreateOrderUseCase(mockRepo, mockPub)
+		useCase := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil)
 
 		input := dtos.OrderInput{
 			CustomerName: "John Doe",
@@ -71,15 +93,14 @@ func TestCreateOrderUseCase(t *testing.T) {
 
 		output, err := useCase.Execute(context.Background(), input)
 
-		assert.Error(t, err)
-		assert.Equal(t, "order must contain at least one item", err.Error())
+		assert.ErrorIs(t, err, entity.ErrValidation)
+		assert.Equal(t, validation.Errors{{Pointer: "/items", Code: validation.CodeRequired, Message: "must contain at least one item"}}, err)
 		assert.Empty(t, output)
 	})
 
 	t.Run("invalid item", func(t *testing.T) {
 		mockRepo := new(usecasemock.MockOrderRepository)
-		mockPub := new(usecasemock.MockOrderPublisher)
-		useCase := usecase.NewCreateOrderUseCase(mockRepo, mockPub)
+		useCase := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil)
 
 		input := dtos.OrderInput{
 			CustomerName: "John Doe",
@@ -88,22 +109,21 @@ func TestCreateOrderUseCase(t *testing.T) {
 					ID:       "1",
 					Name:     "Item 1",
 					Quantity: 0,
-					Price:    10.0,
+					Price:    entity.NewMoney(1000, entity.DefaultCurrency),
 				},
 			},
 		}
 
 		output, err := useCase.Execute(context.Background(), input)
 
-		assert.Error(t, err)
-		assert.Equal(t, "item quantity must be greater than zero", err.Error())
+		assert.ErrorIs(t, err, entity.ErrValidation)
+		assert.Equal(t, validation.Errors{{Pointer: "/items/0/quantity", Code: validation.CodeTooSmall, Message: "must be at least 1"}}, err)
 		assert.Empty(t, output)
 	})
 
 	t.Run("repository error", func(t *testing.T) {
 		mockRepo := new(usecasemock.MockOrderRepository)
-		mockPub := new(usecasemock.MockOrderPublisher)
-		useCase := usecase.NewCreateOrderUseCase(mockRepo, mockPub)
+		useCase := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil)
 
 		input := dtos.OrderInput{
 			CustomerName: "John Doe",
@@ -112,7 +132,7 @@ func TestCreateOrderUseCase(t *testing.T) {
 					ID:       "1",
 					Name:     "Item 1",
 					Quantity: 2,
-					Price:    10.0,
+					Price:    entity.NewMoney(1000, entity.DefaultCurrency),
 				},
 			},
 		}
@@ -126,3 +146,37 @@ func TestCreateOrderUseCase(t *testing.T) {
 		assert.Empty(t, output)
 	})
 }
+
+func TestCreateOrderUseCase_LegacyItemIDs(t *testing.T) {
+	t.Run("takes a legacy id as the product", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		input := dtos.OrderInput{
+			CustomerName: "John Doe",
+			Items:        []dtos.ItemInput{{ID: "123459", Name: "Product A", Quantity: 1, Price: entity.NewMoney(5000, "")}},
+		}
+
+		output, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), input)
+
+		require.NoError(t, err)
+		require.Len(t, output.Items, 1)
+		assert.Equal(t, "123459", output.Items[0].ProductID)
+		assert.NotEqual(t, "123459", output.Items[0].ID)
+		assert.Equal(t, "123459", input.Items[0].ID, "the caller's input is left as it was sent")
+	})
+
+	t.Run("rejects an id that is not the product", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		input := dtos.OrderInput{
+			CustomerName: "John Doe",
+			Items:        []dtos.ItemInput{{ID: "line-1", ProductID: "123459", Name: "Product A", Quantity: 1, Price: entity.NewMoney(5000, "")}},
+		}
+
+		_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), input)
+
+		assert.Equal(t, validation.Errors{
+			{Pointer: "/items/0/id", Code: validation.CodeProductIDMismatch, Message: "is assigned by the server; send the product in product_id"},
+		}, err)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,122 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCustomerUseCase(t *testing.T) {
+	mockRepo := new(usecasemock.MockCustomerRepository)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(customer *entity.Customer) bool {
+		return customer.ID != "" && customer.Name == "John Doe" && customer.NormalizedName() == "john doe"
+	})).Return(nil)
+
+	output, err := usecase.NewCreateCustomerUseCase(mockRepo).Execute(context.Background(), dtos.CustomerInput{Name: " John Doe ", Email: "john@example.com"})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, "John Doe", output.Name)
+	assert.Equal(t, "john@example.com", output.Email)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateCustomerUseCase_InvalidEmail(t *testing.T) {
+	mockRepo := new(usecasemock.MockCustomerRepository)
+
+	_, err := usecase.NewCreateCustomerUseCase(mockRepo).Execute(context.Background(), dtos.CustomerInput{Name: "John Doe", Email: "John <john@example.com>"})
+
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/email", Code: validation.CodeInvalidFormat, Message: "must be an email address such as john@example.com"},
+	}, err)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrderUseCase_LinksCustomer(t *testing.T) {
+	customerRepo := new(usecasemock.MockCustomerRepository)
+	customerRepo.On("FindByID", mock.Anything, "c1").Return(&entity.Customer{ID: "c1", Name: "John Doe"}, nil)
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+		events := order.Events()
+		return len(events) == 1 && events[0].Current.CustomerID == "c1"
+	})).Return(nil)
+
+	output, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, customerRepo).Execute(context.Background(), dtos.OrderInput{
+		CustomerID: "c1",
+		Items:      []dtos.ItemInput{{Name: "Radio", Quantity: 1, Price: entity.NewMoney(4000, "")}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "c1", output.CustomerID)
+	assert.Equal(t, "John Doe", output.CustomerName, "the order is named after its customer")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrderUseCase_UnknownCustomer(t *testing.T) {
+	customerRepo := new(usecasemock.MockCustomerRepository)
+	customerRepo.On("FindByID", mock.Anything, "c9").Return(nil, repository.ErrCustomerNotFound)
+	mockRepo := new(usecasemock.MockOrderRepository)
+
+	_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, customerRepo).Execute(context.Background(), dtos.OrderInput{
+		CustomerID:   "c9",
+		CustomerName: "John Doe",
+		Items:        []dtos.ItemInput{{Name: "Radio", Quantity: 1, Price: entity.NewMoney(4000, "")}},
+	})
+
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/customer_id", Code: validation.CodeUnknownCustomer, Message: "is not a known customer"},
+	}, err)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderUseCase_KeepsCustomer(t *testing.T) {
+	order := &entity.Order{
+		ID:           "123",
+		CustomerID:   "c1",
+		CustomerName: "John",
+		Currency:     entity.DefaultCurrency,
+		Status:       entity.Pending,
+		Items:        []entity.Item{{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+	}
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewUpdateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", 0, dtos.OrderInput{
+		CustomerName: "Johnny",
+		Items:        []dtos.ItemInput{{ID: "item1", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "c1", output.CustomerID)
+	assert.Equal(t, "Johnny", output.CustomerName)
+}
+
+func TestListCustomerOrdersUseCase(t *testing.T) {
+	customerRepo := new(usecasemock.MockCustomerRepository)
+	customerRepo.On("FindByID", mock.Anything, "c1").Return(&entity.Customer{ID: "c1", Name: "John Doe"}, nil)
+	customerRepo.On("FindByID", mock.Anything, "c9").Return(nil, repository.ErrCustomerNotFound)
+	orderRepo := new(usecasemock.MockOrderRepository)
+	orderRepo.On("List", mock.Anything, mock.MatchedBy(func(query repository.OrderQuery) bool {
+		return query.CustomerID == "c1"
+	})).Return(repository.OrderPage{Total: 0}, nil)
+	useCase := usecase.NewListCustomerOrdersUseCase(customerRepo, usecase.NewListOrderUseCase(orderRepo))
+
+	output, err := useCase.Execute(context.Background(), "c1", dtos.ListOrderInput{})
+	require.NoError(t, err)
+	assert.Empty(t, output.Orders)
+	orderRepo.AssertExpectations(t)
+
+	_, err = useCase.Execute(context.Background(), "c9", dtos.ListOrderInput{})
+	assert.ErrorIs(t, err, usecase.ErrCustomerNotFound)
+}
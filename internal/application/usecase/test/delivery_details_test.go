@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var paulista = dtos.Address{Line1: "Av. Paulista, 1000", City: "São Paulo", State: "SP", PostalCode: "01310-100", Country: "BR"}
+
+func TestCreateOrderUseCase_DeliveryDetails(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+		events := order.Events()
+		return len(events) == 1 && events[0].Current.ShippingAddress != nil && events[0].Current.ShippingAddress.PostalCode == "01310-100"
+	})).Return(nil)
+
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Hour)
+	output, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), dtos.OrderInput{
+		CustomerName:    "John Doe",
+		Items:           []dtos.ItemInput{{Name: "Radio", Quantity: 1, Price: entity.NewMoney(4000, "")}},
+		ShippingAddress: &paulista,
+		DeliveryMethod:  "express",
+		DeliveryWindow:  &dtos.DeliveryWindow{Start: start, End: start.Add(4 * time.Hour)},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &paulista, output.ShippingAddress)
+	assert.Nil(t, output.BillingAddress)
+	assert.Equal(t, "express", output.DeliveryMethod)
+	assert.Equal(t, &dtos.DeliveryWindow{Start: start, End: start.Add(4 * time.Hour)}, output.DeliveryWindow)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrderUseCase_PastDeliveryWindow(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+
+	start := time.Now().Add(-time.Hour)
+	_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), dtos.OrderInput{
+		CustomerName:    "John Doe",
+		Items:           []dtos.ItemInput{{Name: "Radio", Quantity: 1, Price: entity.NewMoney(4000, "")}},
+		ShippingAddress: &paulista,
+		DeliveryWindow:  &dtos.DeliveryWindow{Start: start, End: start.Add(4 * time.Hour)},
+	})
+
+	var fieldErrs validation.Errors
+	require.ErrorAs(t, err, &fieldErrs)
+	assert.Equal(t, "/delivery_window/start", fieldErrs[0].Pointer)
+	assert.Equal(t, validation.CodeTooSmall, fieldErrs[0].Code)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderUseCase_KeepsPastDeliveryWindow(t *testing.T) {
+	start := time.Now().Add(-time.Hour).UTC()
+	window := &entity.DeliveryWindow{Start: start, End: start.Add(4 * time.Hour)}
+	order := &entity.Order{
+		ID:           "123",
+		CustomerName: "John",
+		Currency:     entity.DefaultCurrency,
+		Status:       entity.Pending,
+		Items:        []entity.Item{{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+		DeliveryDetails: entity.DeliveryDetails{
+			DeliveryMethod: entity.DeliveryPickup,
+			DeliveryWindow: window,
+		},
+	}
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewUpdateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", 0, dtos.OrderInput{
+		CustomerName:   "Jane",
+		Currency:       entity.DefaultCurrency,
+		Items:          []dtos.ItemInput{{ID: "item1", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+		DeliveryMethod: "pickup",
+		DeliveryWindow: &dtos.DeliveryWindow{Start: start, End: start.Add(4 * time.Hour)},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Jane", output.CustomerName)
+	assert.Equal(t, "pickup", output.DeliveryMethod)
+	assert.True(t, window.Equal(order.DeliveryWindow))
+}
@@ -0,0 +1,69 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetOrderHistoryUseCase(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderHistoryUseCase(mockRepo)
+
+		pending := entity.Pending
+		history := []entity.StatusChange{
+			{To: entity.Pending, ChangedAt: time.Now().Add(-time.Hour)},
+			{From: &pending, To: entity.Canceled, ChangedBy: usecase.ActorAPI, Reason: "duplicate", ChangedAt: time.Now()},
+		}
+		mockRepo.On("FindByID", mock.Anything, "order123").Return(&entity.Order{ID: "order123"}, nil)
+		mockRepo.On("FindStatusHistory", mock.Anything, "order123").Return(history, nil)
+
+		output, err := useCase.Execute(context.Background(), "order123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "order123", output.ID)
+		assert.Len(t, output.History, 2)
+		assert.Nil(t, output.History[0].From)
+		assert.Equal(t, "pending", output.History[0].To)
+		assert.Equal(t, "pending", *output.History[1].From)
+		assert.Equal(t, "canceled", output.History[1].To)
+		assert.Equal(t, "api", output.History[1].ChangedBy)
+		assert.Equal(t, "duplicate", output.History[1].Reason)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderHistoryUseCase(mockRepo)
+
+		mockRepo.On("FindByID", mock.Anything, "non-existent").Return(nil, repository.ErrNotFound)
+
+		output, err := useCase.Execute(context.Background(), "non-existent")
+
+		assert.Error(t, err)
+		assert.Equal(t, "order not found", err.Error())
+		assert.Empty(t, output)
+		mockRepo.AssertNotCalled(t, "FindStatusHistory", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderHistoryUseCase(mockRepo)
+
+		mockRepo.On("FindByID", mock.Anything, "order123").Return(&entity.Order{ID: "order123"}, nil)
+		mockRepo.On("FindStatusHistory", mock.Anything, "order123").Return([]entity.StatusChange{}, errors.New("database error"))
+
+		_, err := useCase.Execute(context.Background(), "order123")
+
+		assert.EqualError(t, err, "database error")
+	})
+}
@@ -2,38 +2,52 @@ package usecase_test
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"order-service/internal/application/dtos"
 	"order-service/internal/application/usecase"
 	usecasemock "order-service/internal/application/usecase/mock"
 	"order-service/internal/domain/entity"
+	"order-service/internal/domain/exchange"
 	"order-service/internal/domain/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+type stubRateProvider struct {
+	rates map[string]*big.Rat
+}
+
+func (s stubRateProvider) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	rate, ok := s.rates[from+to]
+	if !ok {
+		return nil, exchange.ErrRateNotFound
+	}
+	return rate, nil
+}
+
 func TestGetOrderUseCase(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockRepo := new(usecasemock.MockOrderRepository)
-		useCase := usecase.NewGetOrderUseCase(mockRepo)
+		useCase := usecase.NewGetOrderUseCase(mockRepo, nil)
 
 		items := []entity.Item{
 			{
 				ID:       "1",
 				Name:     "Item 1",
 				Quantity: 2,
-				Price:    10.0,
+				Price:    entity.NewMoney(1000, entity.DefaultCurrency),
 			},
 		}
 
-		order, _ := entity.NewOrder("order123", "John Doe", items)
+		order, _ := entity.NewOrder("order123", "John Doe", entity.DefaultCurrency, items)
 		mockRepo.On("FindByID", mock.Anything, "order123").Return(order, nil)
 
 		expectedOutput := dtos.FromEntityToOrderOutput(order)
 
-		output, err := useCase.Execute(context.Background(), "order123")
+		output, err := useCase.Execute(context.Background(), "order123", "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedOutput.ID, output.ID)
@@ -44,14 +58,64 @@ func TestGetOrderUseCase(t *testing.T) {
 
 	t.Run("not found", func(t *testing.T) {
 		mockRepo := new(usecasemock.MockOrderRepository)
-		useCase := usecase.NewGetOrderUseCase(mockRepo)
+		useCase := usecase.NewGetOrderUseCase(mockRepo, nil)
 
 		mockRepo.On("FindByID", mock.Anything, "non-existent").Return(nil, repository.ErrNotFound)
 
-		output, err := useCase.Execute(context.Background(), "non-existent")
+		output, err := useCase.Execute(context.Background(), "non-existent", "")
 
 		assert.Error(t, err)
 		assert.Equal(t, "order not found", err.Error())
 		assert.Empty(t, output)
 	})
+	t.Run("converts total to the requested currency", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		rates := stubRateProvider{rates: map[string]*big.Rat{"BRLUSD": big.NewRat(1, 5)}}
+		useCase := usecase.NewGetOrderUseCase(mockRepo, rates)
+
+		order, _ := entity.NewOrder("order123", "John Doe", "BRL", []entity.Item{
+			{ID: "1", Name: "Item 1", Quantity: 3, Price: entity.NewMoney(1999, "BRL")},
+		})
+		mockRepo.On("FindByID", mock.Anything, "order123").Return(order, nil)
+
+		output, err := useCase.Execute(context.Background(), "order123", "USD")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BRL", output.Currency)
+		assert.Equal(t, entity.NewMoney(5997, "BRL"), output.Total)
+		assert.Equal(t, &dtos.ConvertedAmount{Currency: "USD", Rate: "0.2", Total: entity.NewMoney(1199, "USD")}, output.ConvertedTotal)
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderUseCase(mockRepo, stubRateProvider{})
+
+		_, err := useCase.Execute(context.Background(), "order123", "JPY")
+
+		assert.ErrorIs(t, err, entity.ErrUnsupportedCurrency)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("conversion not configured", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderUseCase(mockRepo, nil)
+
+		_, err := useCase.Execute(context.Background(), "order123", "USD")
+
+		assert.ErrorIs(t, err, usecase.ErrConversionUnavailable)
+	})
+
+	t.Run("missing rate", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderUseCase(mockRepo, stubRateProvider{})
+
+		order, _ := entity.NewOrder("order123", "John Doe", "BRL", []entity.Item{
+			{ID: "1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, "BRL")},
+		})
+		mockRepo.On("FindByID", mock.Anything, "order123").Return(order, nil)
+
+		_, err := useCase.Execute(context.Background(), "order123", "EUR")
+
+		assert.ErrorIs(t, err, usecase.ErrConversionUnavailable)
+	})
 }
@@ -0,0 +1,59 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetOrderTransitionsUseCase(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderTransitionsUseCase(mockRepo)
+
+		order, _ := entity.NewOrder("order123", "John Doe", entity.DefaultCurrency, []entity.Item{
+			{ID: "1", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		})
+		mockRepo.On("FindByID", mock.Anything, "order123").Return(order, nil)
+
+		output, err := useCase.Execute(context.Background(), "order123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "order123", output.ID)
+		assert.Equal(t, "pending", output.Status)
+		assert.Equal(t, []string{"processing", "canceled"}, output.Transitions)
+	})
+
+	t.Run("terminal status", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderTransitionsUseCase(mockRepo)
+
+		order := &entity.Order{ID: "order123", Status: entity.Completed}
+		mockRepo.On("FindByID", mock.Anything, "order123").Return(order, nil)
+
+		output, err := useCase.Execute(context.Background(), "order123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{}, output.Transitions)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewGetOrderTransitionsUseCase(mockRepo)
+
+		mockRepo.On("FindByID", mock.Anything, "non-existent").Return(nil, repository.ErrNotFound)
+
+		output, err := useCase.Execute(context.Background(), "non-existent")
+
+		assert.Error(t, err)
+		assert.Equal(t, "order not found", err.Error())
+		assert.Empty(t, output)
+	})
+}
@@ -0,0 +1,180 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type inMemoryIdempotencyRepository struct {
+	records map[[2]string]repository.IdempotencyRecord
+}
+
+func newInMemoryIdempotencyRepository() *inMemoryIdempotencyRepository {
+	return &inMemoryIdempotencyRepository{records: map[[2]string]repository.IdempotencyRecord{}}
+}
+
+func (r *inMemoryIdempotencyRepository) Reserve(ctx context.Context, record repository.IdempotencyRecord) (repository.IdempotencyRecord, bool, error) {
+	id := [2]string{record.Owner, record.Key}
+	if existing, ok := r.records[id]; ok && existing.ExpiresAt.After(record.CreatedAt) &&
+		(existing.Completed() || existing.LockedUntil.After(record.CreatedAt)) {
+		return existing, false, nil
+	}
+	r.records[id] = record
+	return record, true, nil
+}
+
+func (r *inMemoryIdempotencyRepository) Complete(ctx context.Context, owner, key string, response []byte) error {
+	record := r.records[[2]string{owner, key}]
+	record.Response = response
+	r.records[[2]string{owner, key}] = record
+	return nil
+}
+
+func (r *inMemoryIdempotencyRepository) Release(ctx context.Context, owner, key string) error {
+	if !r.records[[2]string{owner, key}].Completed() {
+		delete(r.records, [2]string{owner, key})
+	}
+	return nil
+}
+
+func TestIdempotentCreateOrderUseCase(t *testing.T) {
+	input := dtos.OrderInput{
+		CustomerName: "John Doe",
+		Items:        []dtos.ItemInput{{ID: "1", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+	}
+
+	t.Run("retry replays the stored response", func(t *testing.T) {
+		orderRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewIdempotentCreateOrderUseCase(usecase.NewCreateOrderUseCase(orderRepo, nil, nil, nil, nil, nil), newInMemoryIdempotencyRepository(), 0)
+
+		orderRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+
+		first, replayed, err := useCase.Execute(context.Background(), "key-1", input)
+		require.NoError(t, err)
+		assert.False(t, replayed)
+
+		second, replayed, err := useCase.Execute(context.Background(), "key-1", input)
+		require.NoError(t, err)
+		assert.True(t, replayed)
+		assert.Equal(t, first, second)
+
+		orderRepo.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("key reused with a different request", func(t *testing.T) {
+		orderRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewIdempotentCreateOrderUseCase(usecase.NewCreateOrderUseCase(orderRepo, nil, nil, nil, nil, nil), newInMemoryIdempotencyRepository(), 0)
+
+		orderRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		_, _, err := useCase.Execute(context.Background(), "key-1", input)
+		require.NoError(t, err)
+
+		other := input
+		other.CustomerName = "Jane Doe"
+		_, _, err = useCase.Execute(context.Background(), "key-1", other)
+
+		assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyReused)
+		orderRepo.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("request still in progress", func(t *testing.T) {
+		orderRepo := new(usecasemock.MockOrderRepository)
+		idempotencyRepo := new(usecasemock.MockIdempotencyRepository)
+		useCase := usecase.NewIdempotentCreateOrderUseCase(usecase.NewCreateOrderUseCase(orderRepo, nil, nil, nil, nil, nil), idempotencyRepo, 0)
+
+		var existing repository.IdempotencyRecord
+		idempotencyRepo.On("Reserve", mock.Anything, mock.Anything).Return(repository.IdempotencyRecord{}, true, nil).Once().Run(func(args mock.Arguments) {
+			existing = args.Get(1).(repository.IdempotencyRecord)
+		})
+		orderRepo.On("Save", mock.Anything, mock.Anything).Return(errors.New("database error"))
+		idempotencyRepo.On("Release", mock.Anything, "", "key-1").Return(nil)
+
+		_, _, err := useCase.Execute(context.Background(), "key-1", input)
+		require.Error(t, err)
+		idempotencyRepo.AssertCalled(t, "Release", mock.Anything, "", "key-1")
+
+		idempotencyRepo.On("Reserve", mock.Anything, mock.Anything).Return(existing, false, nil).Once()
+
+		_, _, err = useCase.Execute(context.Background(), "key-1", input)
+
+		assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyInProgress)
+	})
+
+	t.Run("keys belong to their caller", func(t *testing.T) {
+		orderRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewIdempotentCreateOrderUseCase(usecase.NewCreateOrderUseCase(orderRepo, nil, nil, nil, nil, nil), newInMemoryIdempotencyRepository(), 0)
+
+		orderRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		_, _, err := useCase.Execute(supportContext(), "key-1", input)
+		require.NoError(t, err)
+
+		other := input
+		other.CustomerName = "Jane Doe"
+		otherCaller := auth.NewContext(context.Background(), auth.Principal{Subject: "other-agent", Roles: []auth.Role{auth.RoleSupport}})
+		output, replayed, err := useCase.Execute(otherCaller, "key-1", other)
+
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		assert.Equal(t, "Jane Doe", output.CustomerName)
+		orderRepo.AssertNumberOfCalls(t, "Save", 2)
+	})
+
+	t.Run("releases the key after the caller went away", func(t *testing.T) {
+		orderRepo := new(usecasemock.MockOrderRepository)
+		idempotencyRepo := new(usecasemock.MockIdempotencyRepository)
+		useCase := usecase.NewIdempotentCreateOrderUseCase(usecase.NewCreateOrderUseCase(orderRepo, nil, nil, nil, nil, nil), idempotencyRepo, 0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		idempotencyRepo.On("Reserve", mock.Anything, mock.Anything).Return(repository.IdempotencyRecord{}, true, nil)
+		orderRepo.On("Save", mock.Anything, mock.Anything).Run(func(mock.Arguments) { cancel() }).Return(context.Canceled)
+		live := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil })
+		idempotencyRepo.On("Release", live, "", "key-1").Return(nil)
+
+		_, _, err := useCase.Execute(ctx, "key-1", input)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		idempotencyRepo.AssertExpectations(t)
+	})
+
+	t.Run("takes over a key whose request died", func(t *testing.T) {
+		orderRepo := new(usecasemock.MockOrderRepository)
+		idempotencyRepo := newInMemoryIdempotencyRepository()
+		useCase := usecase.NewIdempotentCreateOrderUseCase(usecase.NewCreateOrderUseCase(orderRepo, nil, nil, nil, nil, nil), idempotencyRepo, 0)
+
+		now := time.Now()
+		_, _, err := idempotencyRepo.Reserve(context.Background(), repository.IdempotencyRecord{
+			Key: "key-1", CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour), LockedUntil: now.Add(-time.Minute),
+		})
+		require.NoError(t, err)
+		orderRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		_, replayed, err := useCase.Execute(context.Background(), "key-1", input)
+
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		orderRepo.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		useCase := usecase.NewIdempotentCreateOrderUseCase(nil, newInMemoryIdempotencyRepository(), 0)
+
+		_, _, err := useCase.Execute(context.Background(), string(make([]byte, usecase.MaxIdempotencyKeyLength+1)), input)
+
+		assert.ErrorIs(t, err, usecase.ErrInvalidIdempotencyKey)
+	})
+}
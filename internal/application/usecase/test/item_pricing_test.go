@@ -0,0 +1,188 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/catalog"
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProductCatalog struct {
+	products map[string]catalog.Product
+	err      error
+}
+
+func (c fakeProductCatalog) Products(ctx context.Context, productIDs []string) (map[string]catalog.Product, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	found := make(map[string]catalog.Product)
+	for _, id := range productIDs {
+		if product, ok := c.products[id]; ok {
+			found[id] = product
+		}
+	}
+	return found, nil
+}
+
+func newTestPricer(mode catalog.PriceMode) *usecase.ItemPricer {
+	return usecase.NewItemPricer(fakeProductCatalog{products: map[string]catalog.Product{
+		"sku-a":    {ID: "sku-a", Name: "Product A", Price: entity.NewMoney(1999, "BRL"), Available: true},
+		"sku-b":    {ID: "sku-b", Name: "Product B", Price: entity.NewMoney(4999, "BRL"), Available: true},
+		"sku-gone": {ID: "sku-gone", Name: "Gone", Price: entity.NewMoney(100, "BRL")},
+		"sku-usd":  {ID: "sku-usd", Name: "Imported", Price: entity.NewMoney(500, "USD"), Available: true},
+	}}, mode)
+}
+
+func TestCreateOrderUseCase_PricesItemsFromCatalog(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewCreateOrderUseCase(mockRepo, newTestPricer(catalog.PriceModeOverride), nil, nil, nil, nil).Execute(context.Background(), dtos.OrderInput{
+		CustomerName: "John Doe",
+		Items: []dtos.ItemInput{
+			{ProductID: "sku-a", Quantity: 2, Price: entity.NewMoney(1, "")},
+			{ProductID: "sku-b", Name: "Renamed", Quantity: 1},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 2)
+	assert.Equal(t, "Product A", output.Items[0].Name)
+	assert.Equal(t, entity.NewMoney(1999, "BRL"), output.Items[0].Price)
+	assert.Equal(t, "Product B", output.Items[1].Name)
+	assert.Equal(t, entity.NewMoney(8997, "BRL"), output.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrderUseCase_CatalogErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          catalog.PriceMode
+		item          dtos.ItemInput
+		expectedField validation.FieldError
+	}{
+		{
+			name:          "missing product",
+			item:          dtos.ItemInput{Name: "Item", Quantity: 1, Price: entity.NewMoney(100, "")},
+			expectedField: validation.FieldError{Pointer: "/items/0/product_id", Code: validation.CodeRequired},
+		},
+		{
+			name:          "unknown product",
+			item:          dtos.ItemInput{ProductID: "sku-x", Quantity: 1},
+			expectedField: validation.FieldError{Pointer: "/items/0/product_id", Code: validation.CodeUnknownProduct},
+		},
+		{
+			name:          "unavailable product",
+			item:          dtos.ItemInput{ProductID: "sku-gone", Quantity: 1},
+			expectedField: validation.FieldError{Pointer: "/items/0/product_id", Code: validation.CodeUnavailable},
+		},
+		{
+			name:          "product in another currency",
+			item:          dtos.ItemInput{ProductID: "sku-usd", Quantity: 1},
+			expectedField: validation.FieldError{Pointer: "/items/0/product_id", Code: validation.CodeCurrencyMismatch},
+		},
+		{
+			name:          "rejected price",
+			mode:          catalog.PriceModeReject,
+			item:          dtos.ItemInput{ProductID: "sku-a", Quantity: 1, Price: entity.NewMoney(1000, "")},
+			expectedField: validation.FieldError{Pointer: "/items/0/price", Code: validation.CodePriceMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			mode := tt.mode
+			if mode == "" {
+				mode = catalog.PriceModeOverride
+			}
+
+			_, err := usecase.NewCreateOrderUseCase(mockRepo, newTestPricer(mode), nil, nil, nil, nil).Execute(context.Background(), dtos.OrderInput{
+				CustomerName: "John Doe",
+				Items:        []dtos.ItemInput{tt.item},
+			})
+
+			var fieldErrs validation.Errors
+			require.ErrorAs(t, err, &fieldErrs)
+			require.Len(t, fieldErrs, 1)
+			assert.Equal(t, tt.expectedField.Pointer, fieldErrs[0].Pointer)
+			assert.Equal(t, tt.expectedField.Code, fieldErrs[0].Code)
+			mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateOrderUseCase_RejectModeAcceptsMatchingPrice(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewCreateOrderUseCase(mockRepo, newTestPricer(catalog.PriceModeReject), nil, nil, nil, nil).Execute(context.Background(), dtos.OrderInput{
+		CustomerName: "John Doe",
+		Items: []dtos.ItemInput{
+			{ProductID: "sku-a", Quantity: 1, Price: entity.NewMoney(1999, "")},
+			{ProductID: "sku-b", Quantity: 1},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.NewMoney(6998, "BRL"), output.Total)
+}
+
+func TestCreateOrderUseCase_CatalogUnavailable(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	pricer := usecase.NewItemPricer(fakeProductCatalog{err: catalog.ErrCatalogUnavailable}, catalog.PriceModeOverride)
+
+	_, err := usecase.NewCreateOrderUseCase(mockRepo, pricer, nil, nil, nil, nil).Execute(context.Background(), dtos.OrderInput{
+		CustomerName: "John Doe",
+		Items:        []dtos.ItemInput{{ProductID: "sku-a", Quantity: 1}},
+	})
+
+	assert.True(t, errors.Is(err, catalog.ErrCatalogUnavailable))
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderItemUseCase_KeepsCatalogPrice(t *testing.T) {
+	order := newItemOrder()
+	order.Items[0].ProductID = "sku-a"
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	quantity := 2
+	output, err := usecase.NewUpdateOrderItemUseCase(mockRepo, newTestPricer(catalog.PriceModeOverride), nil, nil, nil).Execute(context.Background(), "123", "item1", 1, dtos.ItemUpdateInput{
+		Quantity: &quantity,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 1)
+	assert.Equal(t, "Product A", output.Items[0].Name)
+	assert.Equal(t, entity.NewMoney(3998, "BRL"), output.Total)
+}
+
+func TestAddOrderItemUseCase_RejectsCatalogPriceMismatch(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(newItemOrder(), nil)
+
+	_, err := usecase.NewAddOrderItemUseCase(mockRepo, newTestPricer(catalog.PriceModeReject), nil, nil, nil).Execute(context.Background(), "123", 1, dtos.ItemInput{
+		ProductID: "sku-b",
+		Quantity:  1,
+		Price:     entity.NewMoney(100, ""),
+	})
+
+	var fieldErrs validation.Errors
+	require.ErrorAs(t, err, &fieldErrs)
+	assert.Equal(t, "/price", fieldErrs[0].Pointer)
+	assert.Equal(t, validation.CodePriceMismatch, fieldErrs[0].Code)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"order-service/internal/application/dtos"
 	"order-service/internal/application/usecase"
 	usecasemock "order-service/internal/application/usecase/mock"
 	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -23,43 +26,158 @@ func TestListOrderUseCase(t *testing.T) {
 				ID:       "1",
 				Name:     "Item 1",
 				Quantity: 2,
-				Price:    10.0,
+				Price:    entity.NewMoney(1000, entity.DefaultCurrency),
 			},
 		}
 
-		order1, _ := entity.NewOrder("order123", "John Doe", items)
-		order2, _ := entity.NewOrder("order456", "Jane Doe", items)
+		order1, _ := entity.NewOrder("order123", "John Doe", entity.DefaultCurrency, items)
+		order2, _ := entity.NewOrder("order456", "Jane Doe", entity.DefaultCurrency, items)
 		orders := []entity.Order{*order1, *order2}
 
-		mockRepo.On("List", mock.Anything).Return(orders, nil)
+		mockRepo.On("List", mock.Anything, mock.Anything).Return(repository.OrderPage{Orders: orders, Total: 2}, nil)
 
-		output, err := useCase.Execute(context.Background())
+		output, err := useCase.Execute(context.Background(), dtos.ListOrderInput{})
 
 		assert.NoError(t, err)
 		assert.Len(t, output.Orders, 2)
 		assert.Equal(t, order1.ID, output.Orders[0].ID)
 		assert.Equal(t, order2.ID, output.Orders[1].ID)
+		assert.Equal(t, 1, output.Page)
+		assert.Equal(t, usecase.DefaultPageSize, output.Size)
+		assert.Equal(t, 2, output.Total)
 	})
 
 	t.Run("empty list", func(t *testing.T) {
 		mockRepo := new(usecasemock.MockOrderRepository)
 		useCase := usecase.NewListOrderUseCase(mockRepo)
 
-		mockRepo.On("List", mock.Anything).Return([]entity.Order{}, nil)
+		mockRepo.On("List", mock.Anything, mock.Anything).Return(repository.OrderPage{}, nil)
 
-		output, err := useCase.Execute(context.Background())
+		output, err := useCase.Execute(context.Background(), dtos.ListOrderInput{})
 
 		assert.NoError(t, err)
 		assert.Empty(t, output.Orders)
+		assert.Equal(t, 0, output.Total)
+	})
+
+	t.Run("builds query from input", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewListOrderUseCase(mockRepo)
+
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		status := entity.Canceled
+
+		expectedQuery := repository.OrderQuery{
+			Page:          3,
+			Size:          25,
+			Status:        &status,
+			CustomerName:  "john",
+			CreatedFrom:   &from,
+			CreatedTo:     &to,
+			SortBy:        repository.SortByCustomerName,
+			SortDirection: repository.SortAsc,
+		}
+		mockRepo.On("List", mock.Anything, expectedQuery).Return(repository.OrderPage{Total: 60}, nil)
+
+		output, err := useCase.Execute(context.Background(), dtos.ListOrderInput{
+			Page:          3,
+			Size:          25,
+			Status:        "canceled",
+			CustomerName:  "john",
+			CreatedFrom:   &from,
+			CreatedTo:     &to,
+			SortBy:        "customer_name",
+			SortDirection: "asc",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, output.Page)
+		assert.Equal(t, 25, output.Size)
+		assert.Equal(t, 60, output.Total)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		inputs := map[string]dtos.ListOrderInput{
+			"negative page":  {Page: -1},
+			"size too large": {Size: usecase.MaxPageSize + 1},
+			"unknown status": {Status: "shipped"},
+			"inverted range": {CreatedFrom: &from, CreatedTo: &to},
+			"unknown sort":   {SortBy: "total"},
+			"unknown order":  {SortDirection: "sideways"},
+		}
+
+		for name, input := range inputs {
+			t.Run(name, func(t *testing.T) {
+				mockRepo := new(usecasemock.MockOrderRepository)
+				useCase := usecase.NewListOrderUseCase(mockRepo)
+
+				_, err := useCase.Execute(context.Background(), input)
+
+				assert.ErrorIs(t, err, usecase.ErrInvalidListQuery)
+				mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("cursor pagination", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		useCase := usecase.NewListOrderUseCase(mockRepo)
+
+		createdAt := time.Date(2024, 1, 1, 10, 30, 0, 123456000, time.UTC)
+		next := &repository.OrderCursor{CreatedAt: createdAt, ID: "order456"}
+
+		mockRepo.On("List", mock.Anything, mock.MatchedBy(func(q repository.OrderQuery) bool {
+			return q.Keyset && q.After == nil
+		})).Return(repository.OrderPage{NextCursor: next}, nil).Once()
+
+		output, err := useCase.Execute(context.Background(), dtos.ListOrderInput{Keyset: true, Size: 2})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, output.NextCursor)
+		assert.Equal(t, 0, output.Page)
+
+		mockRepo.On("List", mock.Anything, mock.MatchedBy(func(q repository.OrderQuery) bool {
+			return q.Keyset && q.After != nil && q.After.ID == "order456" && q.After.CreatedAt.Equal(createdAt)
+		})).Return(repository.OrderPage{}, nil).Once()
+
+		output, err = useCase.Execute(context.Background(), dtos.ListOrderInput{Cursor: output.NextCursor, Size: 2})
+
+		assert.NoError(t, err)
+		assert.Empty(t, output.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		inputs := map[string]dtos.ListOrderInput{
+			"malformed cursor":    {Cursor: "not-a-cursor"},
+			"unsupported sorting": {Keyset: true, SortBy: "customer_name"},
+		}
+
+		for name, input := range inputs {
+			t.Run(name, func(t *testing.T) {
+				mockRepo := new(usecasemock.MockOrderRepository)
+				useCase := usecase.NewListOrderUseCase(mockRepo)
+
+				_, err := useCase.Execute(context.Background(), input)
+
+				assert.ErrorIs(t, err, usecase.ErrInvalidListQuery)
+				mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+			})
+		}
 	})
 
 	t.Run("repository error", func(t *testing.T) {
 		mockRepo := new(usecasemock.MockOrderRepository)
 		useCase := usecase.NewListOrderUseCase(mockRepo)
 
-		mockRepo.On("List", mock.Anything).Return([]entity.Order{}, errors.New("database error"))
+		mockRepo.On("List", mock.Anything, mock.Anything).Return(repository.OrderPage{}, errors.New("database error"))
 
-		output, err := useCase.Execute(context.Background())
+		output, err := useCase.Execute(context.Background(), dtos.ListOrderInput{})
 
 		assert.Error(t, err)
 		assert.Equal(t, "database error", err.Error())
@@ -0,0 +1,209 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/patch"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPatchableOrder() *entity.Order {
+	return &entity.Order{
+		ID:           "123",
+		CustomerName: "John",
+		Currency:     entity.DefaultCurrency,
+		Status:       entity.Pending,
+		Version:      2,
+		Items: []entity.Item{
+			{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+			{ID: "item2", Name: "Item 2", Quantity: 2, Price: entity.NewMoney(500, entity.DefaultCurrency)},
+		},
+	}
+}
+
+func TestPatchOrderUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         dtos.OrderPatchInput
+		expectedName  string
+		expectedItems []dtos.ItemOutput
+	}{
+		{
+			name:         "merge patch changes the customer name alone",
+			input:        dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"customer_name": "Jane"}`)},
+			expectedName: "Jane",
+			expectedItems: []dtos.ItemOutput{
+				{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency), Total: entity.NewMoney(1000, entity.DefaultCurrency), Tax: entity.NewMoney(0, entity.DefaultCurrency), TotalWithTax: entity.NewMoney(1000, entity.DefaultCurrency)},
+				{ID: "item2", Name: "Item 2", Quantity: 2, Price: entity.NewMoney(500, entity.DefaultCurrency), Total: entity.NewMoney(1000, entity.DefaultCurrency), Tax: entity.NewMoney(0, entity.DefaultCurrency), TotalWithTax: entity.NewMoney(1000, entity.DefaultCurrency)},
+			},
+		},
+		{
+			name: "merge patch adds, modifies and removes items by ID",
+			input: dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"items": {
+				"item1": {"quantity": 3},
+				"item2": null,
+				"item3": {"product_id": "sku-3", "name": "Item 3", "quantity": 1, "price": 2.5}
+			}}`)},
+			expectedName: "John",
+			expectedItems: []dtos.ItemOutput{
+				{ID: "item1", Name: "Item 1", Quantity: 3, Price: entity.NewMoney(1000, entity.DefaultCurrency), Total: entity.NewMoney(3000, entity.DefaultCurrency), Tax: entity.NewMoney(0, entity.DefaultCurrency), TotalWithTax: entity.NewMoney(3000, entity.DefaultCurrency)},
+				// New items get a line ID from the server rather than their key in the patch.
+				{ProductID: "sku-3", Name: "Item 3", Quantity: 1, Price: entity.NewMoney(250, entity.DefaultCurrency), Total: entity.NewMoney(250, entity.DefaultCurrency), Tax: entity.NewMoney(0, entity.DefaultCurrency), TotalWithTax: entity.NewMoney(250, entity.DefaultCurrency)},
+			},
+		},
+		{
+			name: "items of the document carry the order currency",
+			input: dtos.OrderPatchInput{Format: patch.JSONPatch, Patch: []byte(`[
+				{"op": "test", "path": "/items/item1/currency", "value": "BRL"},
+				{"op": "replace", "path": "/items/item1/price", "value": 12.5}
+			]`)},
+			expectedName: "John",
+			expectedItems: []dtos.ItemOutput{
+				{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1250, entity.DefaultCurrency), Total: entity.NewMoney(1250, entity.DefaultCurrency), Tax: entity.NewMoney(0, entity.DefaultCurrency), TotalWithTax: entity.NewMoney(1250, entity.DefaultCurrency)},
+				{ID: "item2", Name: "Item 2", Quantity: 2, Price: entity.NewMoney(500, entity.DefaultCurrency), Total: entity.NewMoney(1000, entity.DefaultCurrency), Tax: entity.NewMoney(0, entity.DefaultCurrency), TotalWithTax: entity.NewMoney(1000, entity.DefaultCurrency)},
+			},
+		},
+		{
+			name: "JSON patch addresses items by ID",
+			input: dtos.OrderPatchInput{Format: patch.JSONPatch, Patch: []byte(`[
+				{"op": "test", "path": "/items/item2/quantity", "value": 2},
+				{"op": "replace", "path": "/items/item2/price", "value": 7.5},
+				{"op": "remove", "path": "/items/item1"}
+			]`)},
+			expectedName: "John",
+			expectedItems: []dtos.ItemOutput{
+				{ID: "item2", Name: "Item 2", Quantity: 2, Price: entity.NewMoney(750, entity.DefaultCurrency), Total: entity.NewMoney(1500, entity.DefaultCurrency), Tax: entity.NewMoney(0, entity.DefaultCurrency), TotalWithTax: entity.NewMoney(1500, entity.DefaultCurrency)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			mockRepo.On("FindByID", mock.Anything, "123").Return(newPatchableOrder(), nil)
+			mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+			output, err := usecase.NewPatchOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", 2, tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, output.CustomerName)
+			for i, item := range tt.expectedItems {
+				if item.ID == "" && i < len(output.Items) {
+					assert.NotEmpty(t, output.Items[i].ID)
+					assert.NotEqual(t, "item3", output.Items[i].ID)
+					output.Items[i].ID = ""
+				}
+			}
+			assert.Equal(t, tt.expectedItems, output.Items)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPatchOrderUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		order       *entity.Order
+		version     int64
+		input       dtos.OrderPatchInput
+		expectedErr error
+	}{
+		{
+			name:        "stale version",
+			order:       newPatchableOrder(),
+			version:     1,
+			input:       dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"customer_name": "Jane"}`)},
+			expectedErr: repository.ErrConcurrentModification,
+		},
+		{
+			name: "order not pending",
+			order: func() *entity.Order {
+				order := newPatchableOrder()
+				order.Status = entity.Completed
+				return order
+			}(),
+			input:       dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"customer_name": "Jane"}`)},
+			expectedErr: usecase.ErrOrderNotEditable,
+		},
+		{
+			name:        "failed JSON patch test",
+			order:       newPatchableOrder(),
+			input:       dtos.OrderPatchInput{Format: patch.JSONPatch, Patch: []byte(`[{"op": "test", "path": "/customer_name", "value": "Jane"}]`)},
+			expectedErr: patch.ErrNotApplicable,
+		},
+		{
+			name:        "currency change",
+			order:       newPatchableOrder(),
+			input:       dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"currency": "USD"}`)},
+			expectedErr: entity.ErrCurrencyMismatch,
+		},
+		{
+			name:        "removing every item",
+			order:       newPatchableOrder(),
+			input:       dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"items": null}`)},
+			expectedErr: entity.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			mockRepo.On("FindByID", mock.Anything, "123").Return(tt.order, nil)
+
+			_, err := usecase.NewPatchOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", tt.version, tt.input)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPatchOrderUseCase_FieldErrorsPointAtItemIDs(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(newPatchableOrder(), nil)
+
+	input := dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"items": {"item2": {"quantity": 0, "colour": "red"}}}`)}
+	_, err := usecase.NewPatchOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", usecase.AnyVersion, input)
+
+	var fieldErrs validation.Errors
+	require.ErrorAs(t, err, &fieldErrs)
+	assert.Equal(t, "/items/item2/colour", fieldErrs[0].Pointer)
+
+	input = dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"items": {"item2": {"quantity": 0}}}`)}
+	_, err = usecase.NewPatchOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", usecase.AnyVersion, input)
+
+	require.ErrorAs(t, err, &fieldErrs)
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/items/item2/quantity", Code: validation.CodeTooSmall, Message: "must be at least 1"},
+	}, fieldErrs)
+}
+
+func TestPatchOrderUseCase_LegacyItemKeys(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockInventory := new(usecasemock.MockInventoryService)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(newPatchableOrder(), nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	mockInventory.On("Adjust", mock.Anything, "123", []inventory.Reservation{{ProductID: "sku-a", Quantity: 2}}).Return(nil)
+
+	input := dtos.OrderPatchInput{Format: patch.MergePatch, Patch: []byte(`{"items": {
+		"sku-a": {"name": "Item A", "quantity": 2, "price": 1}
+	}}`)}
+	output, err := usecase.NewPatchOrderUseCase(mockRepo, nil, mockInventory, nil, nil, nil).Execute(context.Background(), "123", 2, input)
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 3)
+	assert.Equal(t, "sku-a", output.Items[2].ProductID, "the key of an item without a product is its product")
+	assert.NotEqual(t, "sku-a", output.Items[2].ID)
+	mockInventory.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveOrderItemUseCase_Execute(t *testing.T) {
+	order := newItemOrder()
+	order.Items = append(order.Items, entity.Item{ID: "item2", Name: "Item 2", Quantity: 1, Price: entity.NewMoney(500, entity.DefaultCurrency)})
+
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewRemoveOrderItemUseCase(mockRepo, nil, nil, nil).Execute(context.Background(), "123", "item1", 1)
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 1)
+	assert.Equal(t, "item2", output.Items[0].ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRemoveOrderItemUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		orderID     string
+		itemID      string
+		setupMocks  func(mockRepo *usecasemock.MockOrderRepository)
+		expectedErr error
+	}{
+		{
+			name:    "last item",
+			orderID: "123",
+			itemID:  "item1",
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				mockRepo.On("FindByID", mock.Anything, "123").Return(newItemOrder(), nil)
+			},
+			expectedErr: entity.ErrLastItem,
+		},
+		{
+			name:    "order not found",
+			orderID: "404",
+			itemID:  "item1",
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				mockRepo.On("FindByID", mock.Anything, "404").Return(nil, repository.ErrNotFound)
+			},
+			expectedErr: usecase.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			tt.setupMocks(mockRepo)
+
+			_, err := usecase.NewRemoveOrderItemUseCase(mockRepo, nil, nil, nil).Execute(context.Background(), tt.orderID, tt.itemID, usecase.AnyVersion)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newStockOrder() *entity.Order {
+	order := newItemOrder()
+	order.Items = []entity.Item{
+		{ID: "item1", ProductID: "sku-a", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		{ID: "item2", ProductID: "sku-a", Name: "Item 1 gift wrapped", Quantity: 2, Price: entity.NewMoney(1200, entity.DefaultCurrency)},
+		{ID: "item3", Name: "Service", Quantity: 1, Price: entity.NewMoney(500, entity.DefaultCurrency)},
+	}
+	return order
+}
+
+func TestCreateOrderUseCase_ReservesStock(t *testing.T) {
+	input := dtos.OrderInput{
+		CustomerName: "John Doe",
+		Items: []dtos.ItemInput{
+			{ProductID: "sku-a", Name: "Item A", Quantity: 2, Price: entity.NewMoney(1000, "")},
+			{ProductID: "sku-b", Name: "Item B", Quantity: 1, Price: entity.NewMoney(500, "")},
+			{ProductID: "sku-a", Name: "Item A again", Quantity: 1, Price: entity.NewMoney(1000, "")},
+		},
+	}
+	expected := []inventory.Reservation{{ProductID: "sku-a", Quantity: 3}, {ProductID: "sku-b", Quantity: 1}}
+
+	t.Run("reserves before saving", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		mockInventory.On("Reserve", mock.Anything, mock.Anything, expected).Return(nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, mockInventory, nil, nil, nil).Execute(context.Background(), input)
+
+		require.NoError(t, err)
+		mockInventory.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails without stock", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		mockInventory.On("Reserve", mock.Anything, mock.Anything, expected).
+			Return(&inventory.InsufficientStockError{ProductID: "sku-a", Requested: 3, Available: 2})
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, mockInventory, nil, nil, nil).Execute(context.Background(), input)
+
+		assert.ErrorIs(t, err, inventory.ErrInsufficientStock)
+	})
+
+	t.Run("reserves nothing when the save fails", func(t *testing.T) {
+		saveErr := errors.New("connection reset")
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(saveErr)
+
+		_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, mockInventory, nil, nil, nil).Execute(context.Background(), input)
+
+		assert.ErrorIs(t, err, saveErr)
+		mockInventory.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateOrderItemUseCase_AdjustsStock(t *testing.T) {
+	t.Run("adjusts the reservation", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		mockRepo.On("FindByID", mock.Anything, "123").Return(newStockOrder(), nil)
+		mockInventory.On("Adjust", mock.Anything, "123", []inventory.Reservation{{ProductID: "sku-a", Quantity: 6}}).Return(nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		quantity := 5
+		_, err := usecase.NewUpdateOrderItemUseCase(mockRepo, nil, mockInventory, nil, nil).Execute(context.Background(), "123", "item2", 1, dtos.ItemUpdateInput{Quantity: &quantity})
+
+		require.NoError(t, err)
+		mockInventory.AssertExpectations(t)
+	})
+
+	t.Run("keeps the reservation when the save loses a race", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		mockRepo.On("FindByID", mock.Anything, "123").Return(newStockOrder(), nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(repository.ErrConcurrentModification)
+
+		_, err := usecase.NewRemoveOrderItemUseCase(mockRepo, mockInventory, nil, nil).Execute(context.Background(), "123", "item1", 1)
+
+		assert.ErrorIs(t, err, repository.ErrConcurrentModification)
+		mockInventory.AssertNotCalled(t, "Adjust", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCancelOrderUseCase_ReleasesStock(t *testing.T) {
+	t.Run("releases the reservation", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		mockRepo.On("FindByID", mock.Anything, "123").Return(newStockOrder(), nil)
+		mockInventory.On("Release", mock.Anything, "123").Return(nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		output, err := usecase.NewCancelOrderUseCase(mockRepo, mockInventory, nil).Execute(context.Background(), "123", 1, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "canceled", output.Status)
+		mockInventory.AssertExpectations(t)
+	})
+
+	t.Run("keeps the reservation when the save loses a race", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		mockRepo.On("FindByID", mock.Anything, "123").Return(newStockOrder(), nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(repository.ErrConcurrentModification)
+
+		_, err := usecase.NewCancelOrderUseCase(mockRepo, mockInventory, nil).Execute(context.Background(), "123", 1, "")
+
+		assert.ErrorIs(t, err, repository.ErrConcurrentModification)
+		mockInventory.AssertNotCalled(t, "Release", mock.Anything, mock.Anything)
+		mockInventory.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not release twice", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		order := newStockOrder()
+		order.Status = entity.Canceled
+		mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+
+		_, err := usecase.NewCancelOrderUseCase(mockRepo, mockInventory, nil).Execute(context.Background(), "123", 1, "")
+
+		assert.ErrorIs(t, err, usecase.ErrOrderAlreadyCanceled)
+		mockInventory.AssertNotCalled(t, "Release", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateOrderStatusUseCase_ConsumesStock(t *testing.T) {
+	completion := dtos.OrderStatusInput{OrderID: "123", Status: "completed"}
+
+	t.Run("consumes the reservation when the order completes", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		order := newStockOrder()
+		order.Status = entity.Processing
+		mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+		mockInventory.On("Consume", mock.Anything, "123").Return(nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		output, err := usecase.NewUpdateOrderStatusUseCase(mockRepo, mockInventory).Execute(context.Background(), completion)
+
+		require.NoError(t, err)
+		assert.Equal(t, "completed", output.Status)
+		mockInventory.AssertExpectations(t)
+	})
+
+	t.Run("consumes nothing when the save fails", func(t *testing.T) {
+		saveErr := errors.New("connection reset")
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		order := newStockOrder()
+		order.Status = entity.Processing
+		mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(saveErr)
+
+		_, err := usecase.NewUpdateOrderStatusUseCase(mockRepo, mockInventory).Execute(context.Background(), completion)
+
+		assert.ErrorIs(t, err, saveErr)
+		mockInventory.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps the reservation while processing", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockInventory := new(usecasemock.MockInventoryService)
+		mockRepo.On("FindByID", mock.Anything, "123").Return(newStockOrder(), nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := usecase.NewUpdateOrderStatusUseCase(mockRepo, mockInventory).Execute(context.Background(), dtos.OrderStatusInput{OrderID: "123", Status: "processing"})
+
+		require.NoError(t, err)
+		mockInventory.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything)
+	})
+}
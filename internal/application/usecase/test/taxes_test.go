@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/tax"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTaxCalculator taxes every line at 10%, or nothing for books, in BR-SP, its default jurisdiction.
+type fakeTaxCalculator struct{}
+
+func (fakeTaxCalculator) Calculate(ctx context.Context, jurisdiction string, lines []tax.Line) (tax.Assessment, error) {
+	if jurisdiction == "" {
+		jurisdiction = "BR-SP"
+	}
+	if jurisdiction != "BR-SP" {
+		return tax.Assessment{}, fmt.Errorf("%w: %s", tax.ErrUnknownJurisdiction, jurisdiction)
+	}
+
+	assessment := tax.Assessment{Jurisdiction: jurisdiction}
+	for _, line := range lines {
+		amount := line.Amount.Amount / 10
+		if line.Category == "books" {
+			amount = 0
+		}
+		assessment.Taxes = append(assessment.Taxes, entity.NewMoney(amount, line.Amount.Currency))
+	}
+	return assessment, nil
+}
+
+func TestCreateOrderUseCase_TaxesItems(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+		events := order.Events()
+		return len(events) == 1 && events[0].Current.Tax() == entity.NewMoney(450, entity.DefaultCurrency)
+	})).Return(nil)
+
+	output, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, fakeTaxCalculator{}, nil).Execute(context.Background(), dtos.OrderInput{
+		CustomerName: "John Doe",
+		Items: []dtos.ItemInput{
+			{Name: "Novel", Category: "books", Quantity: 1, Price: entity.NewMoney(2000, "")},
+			{Name: "Radio", Category: "electronics", Quantity: 1, Price: entity.NewMoney(4500, "")},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "BR-SP", output.TaxJurisdiction)
+	require.Len(t, output.Items, 2)
+	assert.Equal(t, entity.NewMoney(0, entity.DefaultCurrency), output.Items[0].Tax)
+	assert.Equal(t, entity.NewMoney(2000, entity.DefaultCurrency), output.Items[0].TotalWithTax)
+	assert.Equal(t, entity.NewMoney(450, entity.DefaultCurrency), output.Items[1].Tax)
+	assert.Equal(t, entity.NewMoney(4950, entity.DefaultCurrency), output.Items[1].TotalWithTax)
+	assert.Equal(t, entity.NewMoney(6500, entity.DefaultCurrency), output.Total)
+	assert.Equal(t, entity.NewMoney(450, entity.DefaultCurrency), output.Tax)
+	assert.Equal(t, entity.NewMoney(6950, entity.DefaultCurrency), output.TotalWithTax)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOrderUseCase_TaxesAfterDiscounts(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockPromotions := new(usecasemock.MockPromotionRepository)
+	mockPromotions.On("FindByCode", mock.Anything, "HALF").
+		Return(&entity.Promotion{Code: "HALF", Rule: entity.PromotionPercentage, PercentOff: 50}, nil)
+	mockPromotions.On("Redeem", mock.Anything, "HALF", mock.Anything).Return(nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, mockPromotions, fakeTaxCalculator{}, nil).Execute(context.Background(), dtos.OrderInput{
+		CustomerName: "John Doe",
+		CouponCode:   "HALF",
+		Items:        []dtos.ItemInput{{Name: "Radio", Quantity: 2, Price: entity.NewMoney(4000, "")}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.NewMoney(4000, entity.DefaultCurrency), output.Total)
+	assert.Equal(t, entity.NewMoney(400, entity.DefaultCurrency), output.Tax)
+	assert.Equal(t, entity.NewMoney(4400, entity.DefaultCurrency), output.TotalWithTax)
+}
+
+func TestCreateOrderUseCase_UnknownTaxJurisdiction(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+
+	_, err := usecase.NewCreateOrderUseCase(mockRepo, nil, nil, nil, fakeTaxCalculator{}, nil).Execute(context.Background(), dtos.OrderInput{
+		CustomerName:    "John Doe",
+		TaxJurisdiction: "XX",
+		Items:           []dtos.ItemInput{{Name: "Radio", Quantity: 1, Price: entity.NewMoney(4000, "")}},
+	})
+
+	var fieldErrs validation.Errors
+	require.ErrorAs(t, err, &fieldErrs)
+	assert.Equal(t, "/tax_jurisdiction", fieldErrs[0].Pointer)
+	assert.Equal(t, validation.CodeUnknownJurisdiction, fieldErrs[0].Code)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderItemUseCase_RetaxesItems(t *testing.T) {
+	order := newItemOrder()
+	require.NoError(t, order.ApplyTaxes("BR-SP", []entity.Money{entity.NewMoney(100, entity.DefaultCurrency)}))
+	order.ClearEvents()
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	quantity := 3
+	output, err := usecase.NewUpdateOrderItemUseCase(mockRepo, nil, nil, nil, fakeTaxCalculator{}).Execute(context.Background(), "123", "item1", 1, dtos.ItemUpdateInput{
+		Quantity: &quantity,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "BR-SP", output.TaxJurisdiction)
+	assert.Equal(t, entity.NewMoney(300, entity.DefaultCurrency), output.Tax)
+	assert.Equal(t, entity.NewMoney(3300, entity.DefaultCurrency), output.TotalWithTax)
+	assert.Len(t, order.Events(), 1, "the new tax is part of the update event")
+}
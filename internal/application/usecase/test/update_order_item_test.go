@@ -0,0 +1,63 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateOrderItemUseCase_Execute(t *testing.T) {
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(newItemOrder(), nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	quantity := 3
+	price := entity.NewMoney(1500, "")
+	output, err := usecase.NewUpdateOrderItemUseCase(mockRepo, nil, nil, nil, nil).Execute(context.Background(), "123", "item1", 1, dtos.ItemUpdateInput{
+		Quantity: &quantity,
+		Price:    &price,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 1)
+	assert.Equal(t, "Item 1", output.Items[0].Name)
+	assert.Equal(t, 3, output.Items[0].Quantity)
+	assert.Equal(t, entity.NewMoney(4500, entity.DefaultCurrency), output.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateOrderItemUseCase_Errors(t *testing.T) {
+	quantity := 3
+	tests := []struct {
+		name        string
+		itemID      string
+		version     int64
+		input       dtos.ItemUpdateInput
+		expectedErr error
+	}{
+		{name: "no changes", itemID: "item1", input: dtos.ItemUpdateInput{}, expectedErr: entity.ErrValidation},
+		{name: "unknown item", itemID: "missing", input: dtos.ItemUpdateInput{Quantity: &quantity}, expectedErr: entity.ErrItemNotFound},
+		{name: "stale version", itemID: "item1", version: 5, input: dtos.ItemUpdateInput{Quantity: &quantity}, expectedErr: repository.ErrConcurrentModification},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			mockRepo.On("FindByID", mock.Anything, "123").Return(newItemOrder(), nil)
+
+			_, err := usecase.NewUpdateOrderItemUseCase(mockRepo, nil, nil, nil, nil).Execute(context.Background(), "123", tt.itemID, tt.version, tt.input)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	usecasemock "order-service/internal/application/usecase/mock"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateOrderStatusUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          dtos.OrderStatusInput
+		setupMocks     func(mockRepo *usecasemock.MockOrderRepository)
+		expectedStatus string
+		expectedErr    error
+	}{
+		{
+			name:  "should move pending order to processing",
+			input: dtos.OrderStatusInput{OrderID: "123", Status: "processing"},
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				order := &entity.Order{ID: "123", CustomerName: "John", Status: entity.Pending, Items: []entity.Item{{ID: "1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}}}
+				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+				mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatus: "processing",
+		},
+		{
+			name:  "should complete processing order",
+			input: dtos.OrderStatusInput{OrderID: "123", Status: "completed"},
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				order := &entity.Order{ID: "123", CustomerName: "John", Status: entity.Processing}
+				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+				mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatus: "completed",
+		},
+		{
+			name:  "should ignore redelivered status",
+			input: dtos.OrderStatusInput{OrderID: "123", Status: "processing"},
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				order := &entity.Order{ID: "123", CustomerName: "John", Status: entity.Processing}
+				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+			},
+			expectedStatus: "processing",
+		},
+		{
+			name:        "should reject unknown status",
+			input:       dtos.OrderStatusInput{OrderID: "123", Status: "shipped"},
+			setupMocks:  func(mockRepo *usecasemock.MockOrderRepository) {},
+			expectedErr: usecase.ErrInvalidStatusUpdate,
+		},
+		{
+			name:        "should reject statuses not driven by processing",
+			input:       dtos.OrderStatusInput{OrderID: "123", Status: "canceled"},
+			setupMocks:  func(mockRepo *usecasemock.MockOrderRepository) {},
+			expectedErr: usecase.ErrInvalidStatusUpdate,
+		},
+		{
+			name:  "should reject transition from canceled order",
+			input: dtos.OrderStatusInput{OrderID: "123", Status: "processing"},
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				order := &entity.Order{ID: "123", CustomerName: "John", Status: entity.Canceled}
+				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+			},
+			expectedErr: usecase.ErrInvalidStatusUpdate,
+		},
+		{
+			name:  "should ask to retry a completion delivered before processing",
+			input: dtos.OrderStatusInput{OrderID: "123", Status: "completed"},
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				order := &entity.Order{ID: "123", CustomerName: "John", Status: entity.Pending}
+				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+			},
+			expectedErr: usecase.ErrStatusUpdateOutOfOrder,
+		},
+		{
+			name:  "should ignore processing delivered after completion",
+			input: dtos.OrderStatusInput{OrderID: "123", Status: "processing"},
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				order := &entity.Order{ID: "123", CustomerName: "John", Status: entity.Completed}
+				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+			},
+			expectedStatus: "completed",
+		},
+		{
+			name:  "should return not found",
+			input: dtos.OrderStatusInput{OrderID: "999", Status: "processing"},
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				mockRepo.On("FindByID", mock.Anything, "999").Return(nil, repository.ErrNotFound)
+			},
+			expectedErr: repository.ErrNotFound,
+		},
+		{
+			name:  "should return repository error",
+			input: dtos.OrderStatusInput{OrderID: "123", Status: "processing"},
+			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
+				order := &entity.Order{ID: "123", CustomerName: "John", Status: entity.Pending, Items: []entity.Item{{ID: "1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}}}
+				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+				mockRepo.On("Save", mock.Anything, mock.Anything).Return(errors.New("database error"))
+			},
+			expectedErr: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(usecasemock.MockOrderRepository)
+			tt.setupMocks(mockRepo)
+			useCase := usecase.NewUpdateOrderStatusUseCase(mockRepo, nil)
+
+			result, err := useCase.Execute(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				if !errors.Is(err, tt.expectedErr) {
+					assert.EqualError(t, err, tt.expectedErr.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, result.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -29,27 +29,34 @@ func TestUpdateOrderUseCase_Execute(t *testing.T) {
 			id:   "123",
 			input: dtos.OrderInput{
 				CustomerName: "Jane",
+				Currency:     entity.DefaultCurrency,
 				Items: []dtos.ItemInput{
-					{ID: "item1", Name: "Item 1", Quantity: 2, Price: 10},
+					{ID: "item1", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
 				},
 			},
 			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
 				order := &entity.Order{
 					ID:           "123",
 					CustomerName: "John",
+					Currency:     entity.DefaultCurrency,
 					Status:       entity.Pending,
-					Items:        []entity.Item{{ID: "item1", Name: "Item 1", Quantity: 1, Price: 10}},
+					Items:        []entity.Item{{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
 				}
 				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
 				mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
 			},
 			expected: dtos.OrderOutput{
-				ID:           "123",
-				CustomerName: "Jane",
-				Total:        20,
-				Status:       "pending",
+				ID:            "123",
+				CustomerName:  "Jane",
+				Currency:      entity.DefaultCurrency,
+				Subtotal:      entity.NewMoney(2000, entity.DefaultCurrency),
+				DiscountTotal: entity.NewMoney(0, entity.DefaultCurrency),
+				Total:         entity.NewMoney(2000, entity.DefaultCurrency),
+				Tax:           entity.NewMoney(0, entity.DefaultCurrency),
+				TotalWithTax:  entity.NewMoney(2000, entity.DefaultCurrency),
+				Status:        "pending",
 				Items: []dtos.ItemOutput{
-					{ID: "item1", Name: "Item 1", Quantity: 2, Price: 10, Total: 20},
+					{ID: "item1", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency), Total: entity.NewMoney(2000, entity.DefaultCurrency), Tax: entity.NewMoney(0, entity.DefaultCurrency), TotalWithTax: entity.NewMoney(2000, entity.DefaultCurrency)},
 				},
 			},
 			expectedErr: nil,
@@ -59,8 +66,9 @@ func TestUpdateOrderUseCase_Execute(t *testing.T) {
 			id:   "999",
 			input: dtos.OrderInput{
 				CustomerName: "Jane",
+				Currency:     entity.DefaultCurrency,
 				Items: []dtos.ItemInput{
-					{ID: "item1", Name: "Item 1", Quantity: 1, Price: 10},
+					{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
 				},
 			},
 			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
@@ -74,16 +82,18 @@ func TestUpdateOrderUseCase_Execute(t *testing.T) {
 			id:   "123",
 			input: dtos.OrderInput{
 				CustomerName: "Jane",
+				Currency:     entity.DefaultCurrency,
 				Items: []dtos.ItemInput{
-					{ID: "item1", Name: "Item 1", Quantity: 1, Price: 10},
+					{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
 				},
 			},
 			setupMocks: func(mockRepo *usecasemock.MockOrderRepository) {
 				order := &entity.Order{
 					ID:           "123",
 					CustomerName: "John",
+					Currency:     entity.DefaultCurrency,
 					Status:       entity.Completed,
-					Items:        []entity.Item{{ID: "item1", Name: "Item 1", Quantity: 1, Price: 10}},
+					Items:        []entity.Item{{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
 				}
 				mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
 			},
@@ -96,9 +106,9 @@ func TestUpdateOrderUseCase_Execute(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(usecasemock.MockOrderRepository)
 			tt.setupMocks(mockRepo)
-			updateOrderUseCase := usecase.NewUpdateOrderUseCase(mockRepo)
+			updateOrderUseCase := usecase.NewUpdateOrderUseCase(mockRepo, nil, nil, nil, nil, nil)
 
-			result, err := updateOrderUseCase.Execute(context.Background(), tt.id, tt.input)
+			result, err := updateOrderUseCase.Execute(context.Background(), tt.id, usecase.AnyVersion, tt.input)
 
 			if tt.expectedErr != nil {
 				assert.EqualError(t, err, tt.expectedErr.Error())
@@ -111,3 +121,68 @@ func TestUpdateOrderUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateOrderUseCase_Version(t *testing.T) {
+	input := dtos.OrderInput{
+		CustomerName: "Jane",
+		Items:        []dtos.ItemInput{{ID: "item1", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+	}
+	newOrder := func() *entity.Order {
+		return &entity.Order{
+			ID:           "123",
+			CustomerName: "John",
+			Currency:     entity.DefaultCurrency,
+			Status:       entity.Pending,
+			Version:      3,
+			Items:        []entity.Item{{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+		}
+	}
+
+	t.Run("stale version", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockRepo.On("FindByID", mock.Anything, "123").Return(newOrder(), nil)
+
+		_, err := usecase.NewUpdateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", 2, input)
+
+		assert.ErrorIs(t, err, repository.ErrConcurrentModification)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("concurrent save", func(t *testing.T) {
+		mockRepo := new(usecasemock.MockOrderRepository)
+		mockRepo.On("FindByID", mock.Anything, "123").Return(newOrder(), nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(repository.ErrConcurrentModification)
+
+		_, err := usecase.NewUpdateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", 3, input)
+
+		assert.ErrorIs(t, err, repository.ErrConcurrentModification)
+	})
+}
+
+func TestUpdateOrderUseCase_KeepsLineIDs(t *testing.T) {
+	order := &entity.Order{
+		ID:           "123",
+		CustomerName: "John",
+		Currency:     entity.DefaultCurrency,
+		Status:       entity.Pending,
+		Items:        []entity.Item{{ID: "item1", ProductID: "sku-a", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+	}
+	mockRepo := new(usecasemock.MockOrderRepository)
+	mockRepo.On("FindByID", mock.Anything, "123").Return(order, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := usecase.NewUpdateOrderUseCase(mockRepo, nil, nil, nil, nil, nil).Execute(context.Background(), "123", usecase.AnyVersion, dtos.OrderInput{
+		CustomerName: "John",
+		Items: []dtos.ItemInput{
+			{ID: "item1", ProductID: "sku-a", Name: "Item 1", Quantity: 2, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+			{ID: "sku-b", Name: "Item 2", Quantity: 1, Price: entity.NewMoney(500, entity.DefaultCurrency)},
+		},
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, output.Items, 2) {
+		assert.Equal(t, "item1", output.Items[0].ID, "an existing line keeps its ID")
+		assert.Equal(t, "sku-b", output.Items[1].ProductID, "any other id is the product")
+		assert.NotEqual(t, "sku-b", output.Items[1].ID)
+	}
+}
@@ -2,65 +2,110 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"order-service/internal/application/dtos"
 	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
 	"order-service/internal/domain/repository"
+	"order-service/internal/domain/tax"
 )
 
 type UpdateOrderUseCase interface {
-	Execute(ctx context.Context, id string, input dtos.OrderInput) (dtos.OrderOutput, error)
+	Execute(ctx context.Context, id string, expectedVersion int64, input dtos.OrderInput) (dtos.OrderOutput, error)
 }
 
 type updateOrderUseCase struct {
 	orderRepository repository.OrderRepository
+	pricer          *ItemPricer
+	stock           stockReservations
+	coupons         coupons
+	taxes           taxes
+	customers       customers
 }
 
-func NewUpdateOrderUseCase(orderRepo repository.OrderRepository) UpdateOrderUseCase {
+// NewUpdateOrderUseCase builds the use case; pricer, inventoryService, promotionRepo and
+// taxCalculator may be nil when no product catalog, inventory, promotions or taxes are configured,
+// and customerRepo when orders are not linked to customers.
+func NewUpdateOrderUseCase(orderRepo repository.OrderRepository, pricer *ItemPricer, inventoryService inventory.InventoryService, promotionRepo repository.PromotionRepository, taxCalculator tax.Calculator, customerRepo repository.CustomerRepository) UpdateOrderUseCase {
 	return &updateOrderUseCase{
 		orderRepository: orderRepo,
+		pricer:          pricer,
+		stock:           stockReservations{inventory: inventoryService},
+		coupons:         coupons{promotions: promotionRepo},
+		taxes:           taxes{calculator: taxCalculator},
+		customers:       customers{customers: customerRepo},
 	}
 }
 
-func (u *updateOrderUseCase) Execute(ctx context.Context, id string, input dtos.OrderInput) (dtos.OrderOutput, error) {
-	if input.CustomerName == "" {
-		return dtos.OrderOutput{}, errors.New("customer name cannot be empty")
-	}
-	if len(input.Items) == 0 {
-		return dtos.OrderOutput{}, errors.New("order must contain at least one item")
+func (u *updateOrderUseCase) Execute(ctx context.Context, id string, expectedVersion int64, input dtos.OrderInput) (dtos.OrderOutput, error) {
+	order, err := findEditableOrder(ctx, u.orderRepository, id, expectedVersion)
+	if err != nil {
+		return dtos.OrderOutput{}, err
 	}
 
-	order, err := u.orderRepository.FindByID(ctx, id)
+	// Items sent with the ID of one of the lines of the order keep that line.
+	input.Items, err = adoptLegacyItemIDs(input.Items, lineIDsOf(order), itemPointer("id"))
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return dtos.OrderOutput{}, errors.New("order not found")
-		}
 		return dtos.OrderOutput{}, err
 	}
-
-	if order.Status != entity.Pending {
-		return dtos.OrderOutput{}, errors.New("order cannot be updated as it is not pending")
+	if err := u.pricer.PriceOrder(ctx, &input, order.Currency); err != nil {
+		return dtos.OrderOutput{}, err
 	}
-
-	var items []entity.Item
-	for _, itemInput := range input.Items {
-		item, err := entity.NewItem(itemInput.ID, itemInput.Name, itemInput.Quantity, itemInput.Price)
-		if err != nil {
-			return dtos.OrderOutput{}, err
-		}
-		items = append(items, *item)
+	if err := scopeCustomerID(ctx, &input.CustomerID); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := input.Validate(); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.customers.resolve(ctx, &input); err != nil {
+		return dtos.OrderOutput{}, err
 	}
 
-	err = order.UpdateOrderDetails(input.CustomerName, items)
-	if err != nil {
+	previousCode := order.CouponCode
+	if err := applyOrderInput(order, input); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.coupons.apply(ctx, order, input.CouponCode); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.taxes.apply(ctx, order, input.TaxJurisdiction); err != nil {
 		return dtos.OrderOutput{}, err
 	}
 
-	err = u.orderRepository.Save(ctx, order)
+	err = saveOrder(ctx, u.orderRepository, order, u.coupons.redeem(order, previousCode), u.stock.adjust(order))
 	if err != nil {
 		return dtos.OrderOutput{}, err
 	}
 
 	return dtos.FromEntityToOrderOutput(order), nil
 }
+
+// applyOrderInput replaces the customer, items and delivery details of an order with a validated
+// input whose customer was resolved.
+func applyOrderInput(order *entity.Order, input dtos.OrderInput) error {
+	if err := checkCurrencyUnchanged(order, input.Currency); err != nil {
+		return err
+	}
+
+	items, err := buildItems(input.Items, order.Currency, lineIDsOf(order))
+	if err != nil {
+		return err
+	}
+
+	if err := order.UpdateOrderDetails(input.CustomerName, items); err != nil {
+		return err
+	}
+	if err := order.AssignCustomer(input.CustomerID); err != nil {
+		return err
+	}
+	return applyDeliveryDetails(order, input)
+}
+
+// checkCurrencyUnchanged rejects a currency other than the one the order is in.
+func checkCurrencyUnchanged(order *entity.Order, currency string) error {
+	if currency != "" && currency != order.Currency {
+		return fmt.Errorf("%w: order currency %s cannot be changed to %s", entity.ErrCurrencyMismatch, order.Currency, currency)
+	}
+	return nil
+}
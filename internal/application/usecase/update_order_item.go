@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+	"order-service/internal/domain/tax"
+)
+
+type UpdateOrderItemUseCase interface {
+	Execute(ctx context.Context, orderID, itemID string, expectedVersion int64, input dtos.ItemUpdateInput) (dtos.OrderOutput, error)
+}
+
+type updateOrderItemUseCase struct {
+	orderRepository repository.OrderRepository
+	pricer          *ItemPricer
+	stock           stockReservations
+	coupons         coupons
+	taxes           taxes
+}
+
+// NewUpdateOrderItemUseCase builds the use case; pricer, inventoryService, promotionRepo and
+// taxCalculator may be nil when no product catalog, inventory, promotions or taxes are configured.
+func NewUpdateOrderItemUseCase(orderRepo repository.OrderRepository, pricer *ItemPricer, inventoryService inventory.InventoryService, promotionRepo repository.PromotionRepository, taxCalculator tax.Calculator) UpdateOrderItemUseCase {
+	return &updateOrderItemUseCase{
+		orderRepository: orderRepo,
+		pricer:          pricer,
+		stock:           stockReservations{inventory: inventoryService},
+		coupons:         coupons{promotions: promotionRepo},
+		taxes:           taxes{calculator: taxCalculator},
+	}
+}
+
+func (u *updateOrderItemUseCase) Execute(ctx context.Context, orderID, itemID string, expectedVersion int64, input dtos.ItemUpdateInput) (dtos.OrderOutput, error) {
+	if err := input.Validate(); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	order, err := findEditableOrder(ctx, u.orderRepository, orderID, expectedVersion)
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	changes := entity.ItemChanges{Name: input.Name, Quantity: input.Quantity}
+	if input.Price != nil {
+		price := entity.NewMoney(input.Price.Amount, order.Currency)
+		changes.Price = &price
+	}
+
+	if err := u.priceChanges(ctx, order, itemID, &changes); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	if err := order.UpdateItem(itemID, changes); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.coupons.apply(ctx, order, order.CouponCode); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+	if err := u.taxes.apply(ctx, order, order.TaxJurisdiction); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	err = saveOrder(ctx, u.orderRepository, order, u.stock.adjust(order))
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	return dtos.FromEntityToOrderOutput(order), nil
+}
+
+// priceChanges prices the item as it will be after the changes, so renames and client prices follow
+// the catalog like they do for new items.
+func (u *updateOrderItemUseCase) priceChanges(ctx context.Context, order *entity.Order, itemID string, changes *entity.ItemChanges) error {
+	if u.pricer == nil {
+		return nil
+	}
+
+	for _, item := range order.Items {
+		if item.ID != itemID {
+			continue
+		}
+
+		priced := dtos.ItemInput{ID: item.ID, ProductID: item.ProductID, Name: item.Name, Quantity: item.Quantity}
+		if changes.Price != nil {
+			priced.Price = *changes.Price
+		}
+		if err := u.pricer.PriceItem(ctx, &priced, order.Currency); err != nil {
+			return err
+		}
+		changes.Name, changes.Price = &priced.Name, &priced.Price
+		return nil
+	}
+	// Unknown items are reported by entity.Order.UpdateItem.
+	return nil
+}
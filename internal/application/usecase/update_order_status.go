@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+)
+
+var (
+	ErrInvalidStatusUpdate = errors.New("invalid status update")
+	// ErrStatusUpdateOutOfOrder means the order has not reached the status the update follows yet,
+	// e.g. a completed result delivered before the processing one, so it is worth retrying later.
+	ErrStatusUpdateOutOfOrder = errors.New("status update arrived out of order")
+)
+
+type UpdateOrderStatusUseCase interface {
+	Execute(ctx context.Context, input dtos.OrderStatusInput) (dtos.OrderOutput, error)
+}
+
+type updateOrderStatusUseCase struct {
+	orderRepository repository.OrderRepository
+	stock           stockReservations
+}
+
+// NewUpdateOrderStatusUseCase builds the use case; inventoryService may be nil when no inventory is
+// configured.
+func NewUpdateOrderStatusUseCase(orderRepo repository.OrderRepository, inventoryService inventory.InventoryService) UpdateOrderStatusUseCase {
+	return &updateOrderStatusUseCase{
+		orderRepository: orderRepo,
+		stock:           stockReservations{inventory: inventoryService},
+	}
+}
+
+func (u *updateOrderStatusUseCase) Execute(ctx context.Context, input dtos.OrderStatusInput) (dtos.OrderOutput, error) {
+	if input.OrderID == "" {
+		return dtos.OrderOutput{}, fmt.Errorf("%w: order id is required", ErrInvalidStatusUpdate)
+	}
+
+	status, err := entity.ParseOrderStatus(input.Status)
+	if err != nil {
+		return dtos.OrderOutput{}, fmt.Errorf("%w: %v", ErrInvalidStatusUpdate, err)
+	}
+	if status != entity.Processing && status != entity.Completed {
+		return dtos.OrderOutput{}, fmt.Errorf("%w: status %s cannot be set by processing results", ErrInvalidStatusUpdate, status)
+	}
+
+	order, err := u.orderRepository.FindByID(ctx, input.OrderID)
+	if err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	if order.Status == status {
+		return dtos.FromEntityToOrderOutput(order), nil
+	}
+
+	if err := order.ChangeStatus(status, ActorOrderProcessing, input.Reason); err != nil {
+		var transition *entity.InvalidTransitionError
+		if errors.As(err, &transition) && transition.Reason == "" {
+			switch {
+			case entity.OrderStateMachine.Reachable(order.Status, status):
+				return dtos.OrderOutput{}, fmt.Errorf("%w: %v", ErrStatusUpdateOutOfOrder, err)
+			case entity.OrderStateMachine.Reachable(status, order.Status):
+				// A late result the order has already moved past.
+				return dtos.FromEntityToOrderOutput(order), nil
+			}
+		}
+		return dtos.OrderOutput{}, fmt.Errorf("%w: %v", ErrInvalidStatusUpdate, err)
+	}
+
+	// A completed order has been fulfilled, so the stock held for it leaves the inventory.
+	var effects []sideEffect
+	if status == entity.Completed {
+		effects = append(effects, u.stock.consume(order))
+	}
+	if err := saveOrder(ctx, u.orderRepository, order, effects...); err != nil {
+		return dtos.OrderOutput{}, err
+	}
+
+	return dtos.FromEntityToOrderOutput(order), nil
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+)
+
+// AnyVersion disables the optimistic concurrency check, e.g. for "If-Match: *".
+const AnyVersion int64 = 0
+
+func checkVersion(order *entity.Order, expectedVersion int64) error {
+	if expectedVersion != AnyVersion && order.Version != expectedVersion {
+		return fmt.Errorf("%w: order %s is at version %d, not %d", repository.ErrConcurrentModification, order.ID, order.Version, expectedVersion)
+	}
+	return nil
+}
+
+// findEditableOrder loads an order that is about to be modified, checking that the caller may change
+// it and that it is still at the expected version and pending.
+func findEditableOrder(ctx context.Context, orderRepo repository.OrderRepository, id string, expectedVersion int64) (*entity.Order, error) {
+	if err := requireScope(ctx, auth.ScopeOrdersWrite); err != nil {
+		return nil, err
+	}
+
+	order, err := orderRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if err := authorizeOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	if err := checkVersion(order, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	if order.Status != entity.Pending {
+		return nil, ErrOrderNotEditable
+	}
+
+	return order, nil
+}
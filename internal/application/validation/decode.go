@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// Decode unmarshals a JSON document into v, rejecting fields v does not declare and reporting
+// values of the wrong type as field errors. Malformed JSON is returned as the decoder error.
+func Decode(data []byte, v any) error {
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	var errs Errors
+	collectUnknownFields(reflect.TypeOf(v), document, nil, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	err := json.Unmarshal(data, v)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		pointer := ""
+		if typeErr.Field != "" {
+			pointer = Pointer(toTokens(strings.Split(typeErr.Field, "."))...)
+		}
+		errs.Add(pointer, CodeInvalidType, "must be %s", jsonType(typeErr.Type))
+		return errs
+	}
+	return err
+}
+
+func collectUnknownFields(t reflect.Type, value any, path []any, errs *Errors) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(object) {
+			fieldType, known := fields[strings.ToLower(key)]
+			if !known {
+				errs.Add(Pointer(child(path, key)...), CodeUnknownField, "unknown field %q", key)
+				continue
+			}
+			collectUnknownFields(fieldType, object[key], child(path, key), errs)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, key := range sortedKeys(object) {
+			collectUnknownFields(t.Elem(), object[key], child(path, key), errs)
+		}
+	case reflect.Slice, reflect.Array:
+		array, ok := value.([]any)
+		if !ok {
+			return
+		}
+		for i, element := range array {
+			collectUnknownFields(t.Elem(), element, child(path, i), errs)
+		}
+	}
+}
+
+// jsonFields indexes the exported fields of a struct by their lower-cased JSON name, mirroring the
+// case-insensitive matching of encoding/json.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func child(path []any, token any) []any {
+	return append(append(make([]any, 0, len(path)+1), path...), token)
+}
+
+func toTokens(keys []string) []any {
+	tokens := make([]any, len(keys))
+	for i, key := range keys {
+		tokens[i] = key
+	}
+	return tokens
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
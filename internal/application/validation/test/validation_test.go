@@ -0,0 +1,120 @@
+package validation_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderInput_Validate_ReportsEveryField(t *testing.T) {
+	input := dtos.OrderInput{
+		CustomerName: strings.Repeat("a", validation.MaxNameLength+1),
+		Currency:     "JPY",
+		Items: []dtos.ItemInput{
+			{ID: "1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, "")},
+			{ID: "1", Name: " ", Quantity: 0, Price: entity.NewMoney(0, "")},
+			{ID: "3", Name: "Item 3", Quantity: 1, Price: entity.NewMoney(1000, ""), Currency: "EUR"},
+		},
+	}
+
+	err := input.Validate()
+
+	require.ErrorIs(t, err, entity.ErrValidation)
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/customer_name", Code: validation.CodeTooLong, Message: "must not be longer than 255 characters"},
+		{Pointer: "/currency", Code: validation.CodeUnsupportedCurrency, Message: "must be one of BRL, USD, EUR"},
+		{Pointer: "/items/1/name", Code: validation.CodeRequired, Message: "must not be empty"},
+		{Pointer: "/items/1/quantity", Code: validation.CodeTooSmall, Message: "must be at least 1"},
+		{Pointer: "/items/1/price", Code: validation.CodeTooSmall, Message: "must be greater than zero"},
+		{Pointer: "/items/1/id", Code: validation.CodeDuplicate, Message: "duplicates the id of item 0"},
+		{Pointer: "/items/2/currency", Code: validation.CodeCurrencyMismatch, Message: "must match the order currency JPY"},
+	}, err)
+}
+
+func TestOrderInput_Validate_Limits(t *testing.T) {
+	items := make([]dtos.ItemInput, validation.MaxItemsPerOrder+1)
+	for i := range items {
+		items[i] = dtos.ItemInput{Name: "Item", Quantity: 1, Price: entity.NewMoney(100, "")}
+	}
+
+	err := dtos.OrderInput{CustomerName: "João", Items: items}.Validate()
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/items", Code: validation.CodeTooMany, Message: "must not contain more than 100 items"},
+	}, err)
+
+	// Names are limited in characters, not bytes, like the VARCHAR columns.
+	err = dtos.OrderInput{CustomerName: strings.Repeat("ã", validation.MaxNameLength), Items: items[:1]}.Validate()
+	assert.NoError(t, err)
+}
+
+func TestOrderInput_Validate_CustomerID(t *testing.T) {
+	items := []dtos.ItemInput{{Name: "Item", Quantity: 1, Price: entity.NewMoney(100, "")}}
+
+	// The name of an order placed for a customer defaults to the customer's name.
+	assert.NoError(t, dtos.OrderInput{CustomerID: "c1", Items: items}.Validate())
+
+	err := dtos.OrderInput{CustomerID: strings.Repeat("c", validation.MaxCustomerIDLength+1), CustomerName: " ", Items: items}.Validate()
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/customer_id", Code: validation.CodeTooLong, Message: "must not be longer than 36 characters"},
+		{Pointer: "/customer_name", Code: validation.CodeRequired, Message: "must not be empty"},
+	}, err)
+}
+
+func TestOrderInput_Validate_DeliveryDetails(t *testing.T) {
+	start := time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)
+	input := dtos.OrderInput{
+		CustomerName:   "João",
+		Items:          []dtos.ItemInput{{Name: "Item", Quantity: 1, Price: entity.NewMoney(100, "")}},
+		BillingAddress: &dtos.Address{Line1: " ", City: "New York", PostalCode: "1000", Country: "US"},
+		DeliveryMethod: "express",
+		DeliveryWindow: &dtos.DeliveryWindow{Start: start, End: start},
+	}
+
+	err := input.Validate()
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/billing_address/line1", Code: validation.CodeRequired, Message: "must not be empty"},
+		{Pointer: "/billing_address/postal_code", Code: validation.CodeInvalidFormat, Message: "is not a valid postal code for US"},
+		{Pointer: "/shipping_address", Code: validation.CodeRequired, Message: "must be set for express delivery"},
+		{Pointer: "/delivery_window/end", Code: validation.CodeInvalidRange, Message: "must be after the start of the window"},
+	}, err)
+
+	input.ShippingAddress = &dtos.Address{Line1: "Av. Paulista, 1000", City: "São Paulo", PostalCode: "01310-100", Country: "br"}
+	input.BillingAddress = nil
+	input.DeliveryMethod = "drone"
+	input.DeliveryWindow = nil
+	err = input.Validate()
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/shipping_address/country", Code: validation.CodeInvalidFormat, Message: "must be an ISO 3166-1 alpha-2 code such as BR"},
+		{Pointer: "/delivery_method", Code: validation.CodeUnsupportedDelivery, Message: "must be one of standard, express, pickup"},
+	}, err)
+}
+
+func TestDecode(t *testing.T) {
+	var input dtos.OrderInput
+	err := validation.Decode([]byte(`{"Customer_Name": "John", "items": [{"name": "A", "a/b": 1}], "extra": {"x": 1}}`), &input)
+
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/extra", Code: validation.CodeUnknownField, Message: `unknown field "extra"`},
+		{Pointer: "/items/0/a~1b", Code: validation.CodeUnknownField, Message: `unknown field "a/b"`},
+	}, err)
+
+	err = validation.Decode([]byte(`{"customer_name": "John", "items": [{"name": "A", "quantity": 2, "price": "10.50"}]}`), &input)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1050), input.Items[0].Price.Amount)
+
+	err = validation.Decode([]byte(`{"customer_name": 1}`), &input)
+	assert.Equal(t, validation.Errors{
+		{Pointer: "/customer_name", Code: validation.CodeInvalidType, Message: "must be a string"},
+	}, err)
+
+	err = validation.Decode([]byte(`{"customer_name": `), &input)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, entity.ErrValidation)
+}
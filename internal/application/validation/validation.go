@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"order-service/internal/domain/entity"
+)
+
+// Limits mirror the sizes of the columns the values are stored in.
+const (
+	MaxItemsPerOrder      = 100
+	MaxNameLength         = 255
+	MaxItemIDLength       = 36
+	MaxCustomerIDLength   = 36
+	MaxProductIDLength    = 64
+	MaxCouponCodeLength   = 64
+	MaxCategoryLength     = 64
+	MaxJurisdictionLength = 16
+	MaxStateLength        = 64
+	MaxPostalCodeLength   = 16
+	MaxItemQuantity       = 1<<31 - 1
+)
+
+// MaxAPIKeyGracePeriod bounds how long a rotated API key keeps working next to its replacement.
+const MaxAPIKeyGracePeriod = 7 * 24 * time.Hour
+
+const (
+	CodeRequired            = "required"
+	CodeTooLong             = "too_long"
+	CodeTooMany             = "too_many"
+	CodeTooSmall            = "too_small"
+	CodeTooLarge            = "too_large"
+	CodeDuplicate           = "duplicate"
+	CodeUnsupportedCurrency = "unsupported_currency"
+	CodeCurrencyMismatch    = "currency_mismatch"
+	CodeUnknownField        = "unknown_field"
+	CodeInvalidType         = "invalid_type"
+	CodeUnknownProduct      = "unknown_product"
+	CodeUnavailable         = "unavailable"
+	CodePriceMismatch       = "price_mismatch"
+	CodeUnknownCoupon       = "unknown_coupon"
+	CodeCouponInactive      = "coupon_inactive"
+	CodeCouponNotApplicable = "coupon_not_applicable"
+	CodeCouponExhausted     = "coupon_exhausted"
+	CodeUnknownJurisdiction = "unknown_jurisdiction"
+	CodeInvalidFormat       = "invalid_format"
+	CodeInvalidRange        = "invalid_range"
+	CodeUnsupportedDelivery = "unsupported_delivery_method"
+	CodeUnknownCustomer     = "unknown_customer"
+	CodeUnknownScope        = "unknown_scope"
+	CodeProductIDMismatch   = "product_id_mismatch"
+)
+
+// FieldError describes one invalid value; Pointer is a JSON pointer (RFC 6901) into the request body.
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors collects every field error found in a request. It matches entity.ErrValidation.
+type Errors []FieldError
+
+func (e *Errors) Add(pointer, code, format string, args ...any) {
+	*e = append(*e, FieldError{Pointer: pointer, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// Err returns nil when no errors were collected, so callers can return it directly.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Pointer+": "+fieldErr.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+func (e Errors) Is(target error) bool {
+	return target == entity.ErrValidation
+}
+
+// Pointer builds a JSON pointer from object keys and array indexes, escaping keys as required.
+func Pointer(tokens ...any) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		switch t := token.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(t))
+		default:
+			key := strings.ReplaceAll(fmt.Sprint(t), "~", "~0")
+			b.WriteString(strings.ReplaceAll(key, "/", "~1"))
+		}
+	}
+	return b.String()
+}
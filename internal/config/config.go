@@ -3,24 +3,42 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/spf13/viper"
 )
 
 type Conf struct {
-	DBDriver             string `mapstructure:"DB_DRIVER"`
-	DBHost               string `mapstructure:"DB_HOST"`
-	DBPort               string `mapstructure:"DB_PORT"`
-	DBUser               string `mapstructure:"DB_USER"`
-	DBPassword           string `mapstructure:"DB_PASSWORD"`
-	DBName               string `mapstructure:"DB_NAME"`
-	BrokerPort           string `mapstructure:"BROKER_PORT"`
-	BrokerManagementPort string `mapstructure:"BROKER_MANAGEMENT_PORT"`
-	BrokerUser           string `mapstructure:"BROKER_USER"`
-	BrokerPassword       string `mapstructure:"BROKER_PASSWORD"`
-	BrokerHost           string `mapstructure:"BROKER_HOST"`
-	WebServerPort        string `mapstructure:"WEB_SERVER_PORT"`
+	DBDriver             string        `mapstructure:"DB_DRIVER"`
+	DBHost               string        `mapstructure:"DB_HOST"`
+	DBPort               string        `mapstructure:"DB_PORT"`
+	DBUser               string        `mapstructure:"DB_USER"`
+	DBPassword           string        `mapstructure:"DB_PASSWORD"`
+	DBName               string        `mapstructure:"DB_NAME"`
+	BrokerPort           string        `mapstructure:"BROKER_PORT"`
+	BrokerManagementPort string        `mapstructure:"BROKER_MANAGEMENT_PORT"`
+	BrokerUser           string        `mapstructure:"BROKER_USER"`
+	BrokerPassword       string        `mapstructure:"BROKER_PASSWORD"`
+	BrokerHost           string        `mapstructure:"BROKER_HOST"`
+	WebServerPort        string        `mapstructure:"WEB_SERVER_PORT"`
+	OutboxPollInterval   time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	OutboxBatchSize      int           `mapstructure:"OUTBOX_BATCH_SIZE"`
+	OutboxMaxAttempts    int           `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
+	ConsumerMaxRetries   int           `mapstructure:"CONSUMER_MAX_RETRIES"`
+	ConsumerPrefetch     int           `mapstructure:"CONSUMER_PREFETCH"`
+	ExchangeRatesFile    string        `mapstructure:"EXCHANGE_RATES_FILE"`
+	IdempotencyKeyTTL    time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	CatalogFile          string        `mapstructure:"CATALOG_FILE"`
+	CatalogURL           string        `mapstructure:"CATALOG_URL"`
+	CatalogPriceMode     string        `mapstructure:"CATALOG_PRICE_MODE"`
+	CatalogTimeout       time.Duration `mapstructure:"CATALOG_TIMEOUT"`
+	TaxRulesFile         string        `mapstructure:"TAX_RULES_FILE"`
+	AuthDisabled         bool          `mapstructure:"AUTH_DISABLED"`
+	JWTSecret            string        `mapstructure:"JWT_HS256_SECRET"`
+	JWKSFile             string        `mapstructure:"JWT_JWKS_FILE"`
+	JWTIssuer            string        `mapstructure:"JWT_ISSUER"`
+	JWTAudience          string        `mapstructure:"JWT_AUDIENCE"`
 }
 
 func LoadConfig(env string) (*Conf, error) {
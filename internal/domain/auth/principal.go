@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrUnauthenticated is returned when a request carries no credentials or credentials that
+	// cannot be verified.
+	ErrUnauthenticated = errors.New("authentication required")
+
+	// ErrForbidden is returned when the principal of a request may not act on a resource.
+	ErrForbidden = errors.New("not allowed to access this resource")
+)
+
+type Role string
+
+const (
+	// RoleSupport may see and change every order and customer.
+	RoleSupport Role = "support"
+
+	// RoleAdmin may issue, rotate and revoke API keys.
+	RoleAdmin Role = "admin"
+
+	// RoleService is held by callers authenticated with an API key. Services reach the orders of
+	// every customer, but only within the scopes of their key.
+	RoleService Role = "service"
+)
+
+// Principal is the authenticated caller of a request. Customers have the CustomerID of the customer
+// they act as and only reach that customer's orders. Scopes are only set for services.
+type Principal struct {
+	Subject    string
+	CustomerID string
+	Roles      []Role
+	Scopes     []Scope
+}
+
+func (p Principal) HasRole(role Role) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ActsForAllCustomers reports whether the principal reaches the data of every customer.
+func (p Principal) ActsForAllCustomers() bool {
+	return p.HasRole(RoleSupport) || p.HasRole(RoleService)
+}
+
+// CanActFor reports whether the principal may see and change the data of a customer.
+func (p Principal) CanActFor(customerID string) bool {
+	if p.ActsForAllCustomers() {
+		return true
+	}
+	return p.CustomerID != "" && p.CustomerID == customerID
+}
+
+// Allows reports whether the principal may perform the operations of a scope. Only services are
+// limited by scopes; customers and support are limited by the customers they act for.
+func (p Principal) Allows(scope Scope) bool {
+	if !p.HasRole(RoleService) {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator verifies the credentials of one Authorization scheme, e.g. the token of a
+// "Bearer" header.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials string) (Principal, error)
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal of an authenticated request. Requests the service makes on its
+// own behalf, and requests to an API running without authentication, have none.
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
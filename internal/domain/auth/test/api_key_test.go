@@ -0,0 +1,75 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	now := time.Now()
+	key, secret, err := auth.NewAPIKey("key-1", "nightly export", []auth.Scope{auth.ScopeOrdersRead}, now)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(secret, auth.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(secret, key.Prefix))
+	assert.Len(t, key.Prefix, len(auth.APIKeyPrefix)+8)
+	assert.Equal(t, auth.HashAPIKey(secret), key.Hash)
+	assert.NotContains(t, key.Hash, secret)
+	assert.True(t, key.Active(now))
+
+	_, other, err := auth.NewAPIKey("key-2", "nightly export", []auth.Scope{auth.ScopeOrdersRead}, now)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestAPIKey_Active(t *testing.T) {
+	now := time.Now()
+
+	t.Run("expired", func(t *testing.T) {
+		key := &auth.APIKey{}
+		key.ExpireBy(now.Add(time.Hour))
+		assert.True(t, key.Active(now))
+		assert.False(t, key.Active(now.Add(time.Hour)))
+	})
+
+	t.Run("expiry only moves earlier", func(t *testing.T) {
+		key := &auth.APIKey{}
+		key.ExpireBy(now.Add(time.Hour))
+		key.ExpireBy(now.Add(48 * time.Hour))
+		assert.Equal(t, now.Add(time.Hour), *key.ExpiresAt)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		key := &auth.APIKey{}
+		key.Revoke(now)
+		key.Revoke(now.Add(time.Hour))
+		assert.False(t, key.Active(now))
+		assert.Equal(t, now, *key.RevokedAt, "revoking again keeps the first time")
+	})
+}
+
+func TestPrincipal_Allows(t *testing.T) {
+	service := auth.Principal{Roles: []auth.Role{auth.RoleService}, Scopes: []auth.Scope{auth.ScopeOrdersRead}}
+	assert.True(t, service.Allows(auth.ScopeOrdersRead))
+	assert.False(t, service.Allows(auth.ScopeOrdersWrite))
+	assert.True(t, service.CanActFor("c1"))
+
+	customer := auth.Principal{CustomerID: "c1"}
+	assert.True(t, customer.Allows(auth.ScopeOrdersCancel), "customers are limited by ownership, not scopes")
+	assert.False(t, customer.CanActFor("c2"))
+}
+
+func TestParseScope(t *testing.T) {
+	scope, err := auth.ParseScope("orders:cancel")
+	require.NoError(t, err)
+	assert.Equal(t, auth.ScopeOrdersCancel, scope)
+
+	_, err = auth.ParseScope("orders:delete")
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"order-service/internal/domain/entity"
+)
+
+var ErrCatalogUnavailable = errors.New("product catalog unavailable")
+
+// Product is the catalog entry for a SKU, the source of truth for item names, prices and tax
+// categories.
+type Product struct {
+	ID        string
+	Name      string
+	Category  string
+	Price     entity.Money
+	Available bool
+}
+
+// ProductCatalog looks products up by ID. Unknown IDs are left out of the result rather than
+// reported as errors.
+type ProductCatalog interface {
+	Products(ctx context.Context, productIDs []string) (map[string]Product, error)
+}
+
+// PriceMode tells what to do with prices clients send for catalog products.
+type PriceMode string
+
+const (
+	// PriceModeOverride replaces client prices with catalog prices.
+	PriceModeOverride PriceMode = "override"
+	// PriceModeReject rejects items whose client price differs from the catalog price.
+	PriceModeReject PriceMode = "reject"
+)
+
+func ParsePriceMode(mode string) (PriceMode, error) {
+	switch PriceMode(mode) {
+	case "", PriceModeOverride:
+		return PriceModeOverride, nil
+	case PriceModeReject:
+		return PriceModeReject, nil
+	default:
+		return "", fmt.Errorf("invalid catalog price mode %q, expected %q or %q", mode, PriceModeOverride, PriceModeReject)
+	}
+}
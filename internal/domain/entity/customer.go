@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Customer is the person or company placing orders. Orders still keep the customer name they were
+// placed with, while CustomerID on an order links it to its customer.
+type Customer struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewCustomer(id, name, email string) (*Customer, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, NewValidationError("customer name cannot be empty")
+	}
+
+	now := time.Now()
+	return &Customer{
+		ID:        id,
+		Name:      name,
+		Email:     strings.TrimSpace(email),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// NormalizedName is the name of the customer as compared when looking customers up by name.
+func (c Customer) NormalizedName() string {
+	return NormalizeCustomerName(c.Name)
+}
+
+// NormalizeCustomerName lowercases a name and collapses its whitespace, so "John  Doe" and
+// "john doe" compare equal. The customers migration groups existing orders the same way.
+func NormalizeCustomerName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// AssignCustomer links a pending order to the customer placing it; an empty customerID keeps the
+// current customer, like an empty name in UpdateOrderDetails. The change completes the other changes
+// of a request, so it is recorded with them, see recordAmendment.
+func (o *Order) AssignCustomer(customerID string) error {
+	if o.Status != Pending {
+		return ErrOrderNotPending
+	}
+	if customerID == "" || customerID == o.CustomerID {
+		return nil
+	}
+
+	previous := o.snapshot()
+	o.CustomerID = customerID
+	o.recordAmendment(previous)
+	return nil
+}
@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCountry    error = NewValidationError("invalid country code")
+	ErrInvalidPostalCode error = NewValidationError("invalid postal code")
+)
+
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// postalCodeFormats are the postal code formats of the countries they are checked for; postal codes
+// of other countries are only required to be present.
+var postalCodeFormats = map[string]*regexp.Regexp{
+	"BR": regexp.MustCompile(`^\d{5}-?\d{3}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"CA": regexp.MustCompile(`^(?i)[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+	"GB": regexp.MustCompile(`^(?i)[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^(?i)\d{4} ?[A-Z]{2}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+}
+
+// ValidateCountry checks that country is an ISO 3166-1 alpha-2 code such as "BR".
+func ValidateCountry(country string) error {
+	if !countryCodePattern.MatchString(country) {
+		return fmt.Errorf("%w: %q", ErrInvalidCountry, country)
+	}
+	return nil
+}
+
+// ValidatePostalCode checks a postal code against the format of its country.
+func ValidatePostalCode(country, postalCode string) error {
+	if format, ok := postalCodeFormats[country]; ok && !format.MatchString(postalCode) {
+		return fmt.Errorf("%w: %q is not a postal code of %s", ErrInvalidPostalCode, postalCode, country)
+	}
+	return nil
+}
+
+// Address is a postal address. Line2 and State are optional, as is PostalCode in countries whose
+// format is not known.
+type Address struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country"`
+}
+
+func (a Address) Validate() error {
+	if strings.TrimSpace(a.Line1) == "" || strings.TrimSpace(a.City) == "" {
+		return NewValidationError("address line1 and city cannot be empty")
+	}
+	if err := ValidateCountry(a.Country); err != nil {
+		return err
+	}
+	return ValidatePostalCode(a.Country, a.PostalCode)
+}
+
+type DeliveryMethod string
+
+const (
+	DeliveryStandard DeliveryMethod = "standard"
+	DeliveryExpress  DeliveryMethod = "express"
+	// DeliveryPickup leaves the order to be collected, so it needs no shipping address.
+	DeliveryPickup DeliveryMethod = "pickup"
+)
+
+var DeliveryMethods = []DeliveryMethod{DeliveryStandard, DeliveryExpress, DeliveryPickup}
+
+func (m DeliveryMethod) IsValid() bool {
+	for _, method := range DeliveryMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryWindow is the period the customer asked for the order to be delivered in.
+type DeliveryWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Equal reports whether both windows, either of which may be nil, are the same period.
+func (w *DeliveryWindow) Equal(other *DeliveryWindow) bool {
+	if w == nil || other == nil {
+		return w == other
+	}
+	return w.Start.Equal(other.Start) && w.End.Equal(other.End)
+}
+
+// DeliveryDetails tell the warehouse where and how to deliver an order and where to bill it. They are
+// all optional, but a delivery method other than pickup needs a shipping address.
+type DeliveryDetails struct {
+	ShippingAddress *Address        `json:"shipping_address,omitempty"`
+	BillingAddress  *Address        `json:"billing_address,omitempty"`
+	DeliveryMethod  DeliveryMethod  `json:"delivery_method,omitempty"`
+	DeliveryWindow  *DeliveryWindow `json:"delivery_window,omitempty"`
+}
+
+func (d DeliveryDetails) Validate() error {
+	for _, address := range []*Address{d.ShippingAddress, d.BillingAddress} {
+		if address == nil {
+			continue
+		}
+		if err := address.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if d.DeliveryMethod != "" {
+		if !d.DeliveryMethod.IsValid() {
+			return NewValidationError("unknown delivery method %q", d.DeliveryMethod)
+		}
+		if d.DeliveryMethod != DeliveryPickup && d.ShippingAddress == nil {
+			return NewValidationError("%s delivery needs a shipping address", d.DeliveryMethod)
+		}
+	}
+
+	if d.DeliveryWindow != nil && !d.DeliveryWindow.Start.Before(d.DeliveryWindow.End) {
+		return NewValidationError("delivery window must start before it ends")
+	}
+	return nil
+}
+
+func (d DeliveryDetails) Equal(other DeliveryDetails) bool {
+	return equalPointed(d.ShippingAddress, other.ShippingAddress) &&
+		equalPointed(d.BillingAddress, other.BillingAddress) &&
+		d.DeliveryMethod == other.DeliveryMethod &&
+		d.DeliveryWindow.Equal(other.DeliveryWindow)
+}
+
+func equalPointed[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// UpdateDeliveryDetails replaces the delivery details of a pending order. They complete the other
+// changes of a request, so they are recorded with them, see recordAmendment.
+func (o *Order) UpdateDeliveryDetails(details DeliveryDetails) error {
+	if o.Status != Pending {
+		return ErrOrderNotPending
+	}
+	if err := details.Validate(); err != nil {
+		return err
+	}
+	if details.Equal(o.DeliveryDetails) {
+		return nil
+	}
+
+	previous := o.snapshot()
+	o.DeliveryDetails = details
+	o.recordAmendment(previous)
+	return nil
+}
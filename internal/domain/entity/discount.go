@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Discount lowers the amount due for an order. A discount with an ItemID applies to that item, the
+// others to the order as a whole.
+type Discount struct {
+	Code        string `json:"code"`
+	ItemID      string `json:"item_id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Amount      Money  `json:"amount"`
+}
+
+func (o *Order) DiscountTotal() Money {
+	total := NewMoney(0, o.Currency)
+	for _, discount := range o.Discounts {
+		total = total.Add(discount.Amount)
+	}
+	return total
+}
+
+// ApplyDiscounts replaces the coupon code of a pending order and the discounts it grants. Item
+// discounts cannot exceed the total of their item, nor all discounts the subtotal of the order.
+func (o *Order) ApplyDiscounts(couponCode string, discounts []Discount) error {
+	if o.Status != Pending {
+		return ErrOrderNotPending
+	}
+
+	itemDiscounts := make(map[string]Money)
+	total := NewMoney(0, o.Currency)
+	for _, discount := range discounts {
+		if !discount.Amount.IsPositive() {
+			return NewValidationError("discount %s must be greater than zero", discount.Code)
+		}
+		if discount.Amount.Currency != o.Currency {
+			return fmt.Errorf("%w: discount %s is in %s but the order is in %s", ErrCurrencyMismatch, discount.Code, discount.Amount.Currency, o.Currency)
+		}
+		if discount.ItemID != "" {
+			index, found := o.findItem(discount.ItemID)
+			if !found {
+				return fmt.Errorf("%w: discount %s is for %s", ErrItemNotFound, discount.Code, discount.ItemID)
+			}
+			itemDiscounts[discount.ItemID] = itemDiscounts[discount.ItemID].Add(discount.Amount)
+			if itemDiscounts[discount.ItemID].Amount > o.Items[index].Total().Amount {
+				return NewValidationError("discounts on item %s exceed its total", discount.ItemID)
+			}
+		}
+		total = total.Add(discount.Amount)
+	}
+	if total.Amount > o.Subtotal().Amount {
+		return NewValidationError("discounts exceed the order subtotal")
+	}
+
+	if len(discounts) == 0 {
+		discounts = nil
+	}
+	if couponCode == o.CouponCode && slices.Equal(discounts, o.Discounts) {
+		return nil
+	}
+
+	previous := o.snapshot()
+	o.CouponCode = couponCode
+	o.Discounts = discounts
+	o.recordAmendment(previous)
+	return nil
+}
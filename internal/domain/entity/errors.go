@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrValidation is matched by every error caused by invalid order data.
+	ErrValidation = errors.New("validation failed")
+
+	ErrOrderNotPending = errors.New("order cannot be modified as it is not pending")
+
+	ErrItemNotFound  = errors.New("order item not found")
+	ErrDuplicateItem = errors.New("order already contains an item with this id")
+	ErrLastItem      = errors.New("the last item of an order cannot be removed")
+)
+
+type ValidationError struct {
+	Message string
+}
+
+func NewValidationError(format string, args ...any) *ValidationError {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
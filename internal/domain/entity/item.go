@@ -1,56 +1,70 @@
 package entity
 
 import (
-	"errors"
 	"fmt"
 )
 
+// Item is a line of an order. Its ID is unique within the order only; ProductID identifies the
+// product, or SKU, the line is for, and Category the tax category of that product. Tax is the tax
+// due on the line, see Order.ApplyTaxes.
 type Item struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
+	ID        string `json:"id"`
+	ProductID string `json:"product_id,omitempty"`
+	Name      string `json:"name"`
+	Category  string `json:"category,omitempty"`
+	Quantity  int    `json:"quantity"`
+	Price     Money  `json:"price"`
+	Tax       Money  `json:"tax"`
 }
 
-func NewItem(id, name string, quantity int, price float64) (*Item, error) {
+func NewItem(id, productID, name string, quantity int, price Money) (*Item, error) {
 	if name == "" {
-		return nil, errors.New("item name cannot be empty")
+		return nil, NewValidationError("item name cannot be empty")
 	}
 	if quantity <= 0 {
-		return nil, errors.New("item quantity must be greater than zero")
+		return nil, NewValidationError("item quantity must be greater than zero")
 	}
-	if price <= 0 {
-		return nil, errors.New("item price must be greater than zero")
+	if !price.IsPositive() {
+		return nil, NewValidationError("item price must be greater than zero")
 	}
 
 	return &Item{
-		ID:       id,
-		Name:     name,
-		Quantity: quantity,
-		Price:    price,
+		ID:        id,
+		ProductID: productID,
+		Name:      name,
+		Quantity:  quantity,
+		Price:     price,
 	}, nil
 }
 
-func (i *Item) Total() float64 {
-	return float64(i.Quantity) * i.Price
+func (i *Item) Total() Money {
+	return i.Price.Multiply(i.Quantity)
+}
+
+func (i *Item) UpdateName(newName string) error {
+	if newName == "" {
+		return NewValidationError("item name cannot be empty")
+	}
+	i.Name = newName
+	return nil
 }
 
 func (i *Item) UpdateQuantity(newQuantity int) error {
 	if newQuantity <= 0 {
-		return errors.New("quantity must be greater than zero")
+		return NewValidationError("quantity must be greater than zero")
 	}
 	i.Quantity = newQuantity
 	return nil
 }
 
-func (i *Item) UpdatePrice(newPrice float64) error {
-	if newPrice <= 0 {
-		return errors.New("price must be greater than zero")
+func (i *Item) UpdatePrice(newPrice Money) error {
+	if !newPrice.IsPositive() {
+		return NewValidationError("price must be greater than zero")
 	}
 	i.Price = newPrice
 	return nil
 }
 
 func (i *Item) String() string {
-	return fmt.Sprintf("Item{id: %s, name: %s, quantity: %d, price: %.2f}", i.ID, i.Name, i.Quantity, i.Price)
+	return fmt.Sprintf("Item{id: %s, product_id: %s, name: %s, quantity: %d, price: %s}", i.ID, i.ProductID, i.Name, i.Quantity, i.Price)
 }
@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultCurrency = "BRL"
+
+	// minorUnitDigits is the number of decimal places of every supported currency.
+	minorUnitDigits = 2
+	minorUnits      = 100
+)
+
+var (
+	ErrInvalidAmount       error = NewValidationError("invalid money amount")
+	ErrUnsupportedCurrency error = NewValidationError("unsupported currency")
+	ErrCurrencyMismatch    error = NewValidationError("currency mismatch")
+)
+
+var SupportedCurrencies = []string{"BRL", "USD", "EUR"}
+
+func ValidateCurrency(currency string) error {
+	for _, supported := range SupportedCurrencies {
+		if currency == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+}
+
+// Money is an exact amount in the minor unit (e.g. cents) of an ISO 4217 currency.
+// It is encoded in JSON as a plain decimal number so API clients keep sending and
+// receiving prices such as 19.99.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "19.99" into Money, rejecting values
+// with more decimal places than the currency supports.
+func ParseMoney(value, currency string) (Money, error) {
+	value = strings.TrimSpace(value)
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok || strings.Contains(value, "/") {
+		return Money{}, fmt.Errorf("%w: %q is not a number", ErrInvalidAmount, value)
+	}
+
+	rat.Mul(rat, big.NewRat(minorUnits, 1))
+	if !rat.IsInt() {
+		return Money{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, value, minorUnitDigits)
+	}
+	if !rat.Num().IsInt64() {
+		return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, value)
+	}
+
+	return Money{Amount: rat.Num().Int64(), Currency: currency}, nil
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Add sums two amounts of the same currency. A zero amount without currency
+// takes the currency of the other operand.
+func (m Money) Add(other Money) Money {
+	currency := m.Currency
+	if currency == "" {
+		currency = other.Currency
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: currency}
+}
+
+// Subtract takes other from m, keeping the currency of m.
+func (m Money) Subtract(other Money) Money {
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}
+}
+
+func (m Money) Multiply(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Convert applies an exchange rate, rounding half away from zero to the minor unit of the target currency.
+func (m Money) Convert(currency string, rate *big.Rat) Money {
+	converted := new(big.Rat).Mul(big.NewRat(m.Amount, 1), rate)
+
+	quotient, remainder := new(big.Int).QuoRem(converted.Num(), converted.Denom(), new(big.Int))
+	doubled := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	if doubled.Cmp(converted.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(converted.Sign())))
+	}
+
+	return Money{Amount: quotient.Int64(), Currency: currency}
+}
+
+// Decimal formats the amount in major units, e.g. 1999 as "19.99".
+func (m Money) Decimal() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/minorUnits, minorUnitDigits, amount%minorUnits)
+}
+
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string. The currency is left
+// untouched, as it is carried alongside the amount rather than inside it.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	value := string(data)
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+		}
+		value = unquoted
+	}
+
+	parsed, err := ParseMoney(value, m.Currency)
+	if err != nil {
+		return err
+	}
+	m.Amount = parsed.Amount
+	return nil
+}
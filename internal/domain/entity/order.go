@@ -1,7 +1,7 @@
 package entity
 
 import (
-	"errors"
+	"fmt"
 	"time"
 )
 
@@ -14,90 +14,251 @@ const (
 	Canceled
 )
 
+var OrderStatuses = []OrderStatus{Pending, Processing, Completed, Canceled}
+
 func (s OrderStatus) String() string {
 	return [...]string{"pending", "processing", "completed", "canceled"}[s]
 }
 
 type Order struct {
-	ID           string      `json:"id"`
-	CustomerName string      `json:"customer_name"`
-	Items        []Item      `json:"items"`
-	Status       OrderStatus `json:"status"`
-	CreatedAt    time.Time   `json:"created_at"`
-	UpdatedAt    time.Time   `json:"updated_at"`
+	ID              string      `json:"id"`
+	CustomerID      string      `json:"customer_id,omitempty"`
+	CustomerName    string      `json:"customer_name"`
+	Currency        string      `json:"currency"`
+	Items           []Item      `json:"items"`
+	CouponCode      string      `json:"coupon_code,omitempty"`
+	Discounts       []Discount  `json:"discounts,omitempty"`
+	TaxJurisdiction string      `json:"tax_jurisdiction,omitempty"`
+	Status          OrderStatus `json:"status"`
+	Version         int64       `json:"version"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+
+	DeliveryDetails
+
+	events []OrderEvent
 }
 
-func NewOrder(ID, customerName string, items []Item) (*Order, error) {
+func NewOrder(ID, customerName, currency string, items []Item) (*Order, error) {
+	if err := ValidateCurrency(currency); err != nil {
+		return nil, err
+	}
+
 	if len(items) == 0 {
-		return nil, errors.New("order must contain at least one item")
+		return nil, NewValidationError("order must contain at least one item")
 	}
 
-	for _, item := range items {
-		if item.Quantity <= 0 || item.Price <= 0 {
-			return nil, errors.New("invalid item quantity or price")
-		}
+	if err := validateItems(items, currency); err != nil {
+		return nil, err
 	}
 
-	return &Order{
+	order := &Order{
 		ID:           ID,
 		CustomerName: customerName,
+		Currency:     currency,
 		Items:        items,
 		Status:       Pending,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
-	}, nil
+	}
+	order.recordEvent(OrderCreatedEvent, nil)
+
+	return order, nil
 }
 
 func (o *Order) IsValid() error {
 	if o.ID == "" {
-		return errors.New("invalid order id")
+		return NewValidationError("invalid order id")
 	}
 	return nil
 }
 
-func (o *Order) Total() float64 {
-	var total float64
+// Subtotal is the sum of the item totals, before discounts.
+func (o *Order) Subtotal() Money {
+	subtotal := NewMoney(0, o.Currency)
 	for _, item := range o.Items {
-		total += float64(item.Quantity) * item.Price
+		subtotal = subtotal.Add(item.Total())
 	}
-	return total
+	return subtotal
+}
+
+// Total is the amount due for the order, its subtotal minus its discounts.
+func (o *Order) Total() Money {
+	return o.Subtotal().Subtract(o.DiscountTotal())
 }
 
 func (o *Order) UpdateOrderDetails(newCustomerName string, newItems []Item) error {
 	if o.Status != Pending {
-		return errors.New("order cannot be modified as it is not pending")
+		return ErrOrderNotPending
 	}
 
+	previous := o.snapshot()
+
 	if newCustomerName != "" {
 		o.CustomerName = newCustomerName
 	}
 
 	if len(newItems) == 0 {
-		return errors.New("order must contain at least one item")
+		return NewValidationError("order must contain at least one item")
 	}
 
-	for _, item := range newItems {
-		if item.Quantity <= 0 || item.Price <= 0 {
-			return errors.New("invalid item quantity or price")
-		}
+	if err := validateItems(newItems, o.Currency); err != nil {
+		return err
 	}
 
 	o.Items = newItems
 	o.UpdatedAt = time.Now()
+	o.recordEvent(OrderUpdatedEvent, &previous)
+
+	return nil
+}
+
+// ItemChanges lists the fields UpdateItem changes; nil fields keep their value.
+type ItemChanges struct {
+	Name     *string
+	Quantity *int
+	Price    *Money
+}
+
+func (o *Order) AddItem(item Item) error {
+	if o.Status != Pending {
+		return ErrOrderNotPending
+	}
+
+	if _, found := o.findItem(item.ID); found {
+		return fmt.Errorf("%w: %s", ErrDuplicateItem, item.ID)
+	}
+
+	if err := validateItems([]Item{item}, o.Currency); err != nil {
+		return err
+	}
+
+	previous := o.snapshot()
+	o.Items = append(o.Items, item)
+	o.UpdatedAt = time.Now()
+	o.recordEvent(OrderUpdatedEvent, &previous)
 
 	return nil
 }
 
+func (o *Order) UpdateItem(itemID string, changes ItemChanges) error {
+	if o.Status != Pending {
+		return ErrOrderNotPending
+	}
+
+	index, found := o.findItem(itemID)
+	if !found {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+
+	item := o.Items[index]
+	if changes.Name != nil {
+		if err := item.UpdateName(*changes.Name); err != nil {
+			return err
+		}
+	}
+	if changes.Quantity != nil {
+		if err := item.UpdateQuantity(*changes.Quantity); err != nil {
+			return err
+		}
+	}
+	if changes.Price != nil {
+		if err := item.UpdatePrice(*changes.Price); err != nil {
+			return err
+		}
+	}
+	if err := validateItems([]Item{item}, o.Currency); err != nil {
+		return err
+	}
+
+	previous := o.snapshot()
+	o.Items[index] = item
+	o.UpdatedAt = time.Now()
+	o.recordEvent(OrderUpdatedEvent, &previous)
+
+	return nil
+}
+
+// RemoveItem removes an item from a pending order, which must keep at least one item.
+func (o *Order) RemoveItem(itemID string) error {
+	if o.Status != Pending {
+		return ErrOrderNotPending
+	}
+
+	index, found := o.findItem(itemID)
+	if !found {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+
+	if len(o.Items) == 1 {
+		return ErrLastItem
+	}
+
+	previous := o.snapshot()
+	o.Items = append(o.Items[:index:index], o.Items[index+1:]...)
+	o.UpdatedAt = time.Now()
+	o.recordEvent(OrderUpdatedEvent, &previous)
+
+	return nil
+}
+
+func (o *Order) findItem(itemID string) (int, bool) {
+	for i, item := range o.Items {
+		if item.ID == itemID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func validateItems(items []Item, currency string) error {
+	for _, item := range items {
+		if item.Quantity <= 0 || !item.Price.IsPositive() {
+			return NewValidationError("invalid item quantity or price")
+		}
+		if item.Price.Currency != currency {
+			return fmt.Errorf("%w: item %s is priced in %s but the order is in %s", ErrCurrencyMismatch, item.ID, item.Price.Currency, currency)
+		}
+	}
+	return nil
+}
+
 func (o *Order) SetStatus(status OrderStatus) error {
+	return o.ChangeStatus(status, "", "")
+}
+
+// ChangeStatus is SetStatus recording who asked for the change and why, for the status history.
+func (o *Order) ChangeStatus(status OrderStatus, changedBy, reason string) error {
 	if o.Status == status {
 		return nil
 	}
 
-	if o.Status == Completed || o.Status == Canceled {
-		return errors.New("cannot change status of completed or canceled order")
+	if err := OrderStateMachine.Can(o, status); err != nil {
+		return err
 	}
 
+	previous := o.snapshot()
 	o.Status = status
 	o.UpdatedAt = time.Now()
+	o.recordEventBy(statusEventType(status), &previous, changedBy, reason)
 	return nil
 }
+
+func (o *Order) AllowedTransitions() []OrderStatus {
+	return OrderStateMachine.AllowedTransitions(o)
+}
+
+func ParseOrderStatus(status string) (OrderStatus, error) {
+	switch status {
+	case "pending":
+		return Pending, nil
+	case "processing":
+		return Processing, nil
+	case "completed":
+		return Completed, nil
+	case "canceled":
+		return Canceled, nil
+	default:
+		return Pending, NewValidationError("invalid order status: %s", status)
+	}
+}
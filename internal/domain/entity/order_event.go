@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventType string
+
+const (
+	OrderCreatedEvent       EventType = "order_created"
+	OrderUpdatedEvent       EventType = "order_updated"
+	OrderStatusChangedEvent EventType = "order_status_changed"
+	OrderCanceledEvent      EventType = "order_canceled"
+	OrderCompletedEvent     EventType = "order_completed"
+)
+
+var EventTypes = []EventType{
+	OrderCreatedEvent,
+	OrderUpdatedEvent,
+	OrderStatusChangedEvent,
+	OrderCanceledEvent,
+	OrderCompletedEvent,
+}
+
+type OrderEvent struct {
+	Type       EventType `json:"type"`
+	OrderID    string    `json:"order_id"`
+	Previous   *Order    `json:"previous,omitempty"`
+	Current    Order     `json:"current"`
+	ChangedBy  string    `json:"changed_by,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// StatusChange reports the status transition carried by the event, if any.
+func (e OrderEvent) StatusChange() (StatusChange, bool) {
+	change := StatusChange{
+		To:        e.Current.Status,
+		ChangedBy: e.ChangedBy,
+		Reason:    e.Reason,
+		ChangedAt: e.OccurredAt,
+	}
+
+	if e.Previous == nil {
+		return change, e.Type == OrderCreatedEvent
+	}
+	if e.Previous.Status == e.Current.Status {
+		return StatusChange{}, false
+	}
+
+	from := e.Previous.Status
+	change.From = &from
+	return change, true
+}
+
+// Payload returns the message body published for the event. Created events keep
+// publishing the bare order so existing consumers of order_created are unaffected.
+func (e OrderEvent) Payload() ([]byte, error) {
+	if e.Type == OrderCreatedEvent {
+		return json.Marshal(e.Current)
+	}
+	return json.Marshal(e)
+}
+
+func statusEventType(status OrderStatus) EventType {
+	switch status {
+	case Canceled:
+		return OrderCanceledEvent
+	case Completed:
+		return OrderCompletedEvent
+	default:
+		return OrderStatusChangedEvent
+	}
+}
+
+func (o *Order) recordEvent(eventType EventType, previous *Order) {
+	o.recordEventBy(eventType, previous, "", "")
+}
+
+func (o *Order) recordEventBy(eventType EventType, previous *Order, changedBy, reason string) {
+	o.events = append(o.events, OrderEvent{
+		Type:       eventType,
+		OrderID:    o.ID,
+		Previous:   previous,
+		Current:    o.snapshot(),
+		ChangedBy:  changedBy,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+}
+
+// recordAmendment records a change that completes others made in the same unit of work, such as the
+// discounts and taxes that follow from new items or the delivery details of a new order. The pending
+// created or updated event is brought up to date instead of recording another one.
+func (o *Order) recordAmendment(previous Order) {
+	o.UpdatedAt = time.Now()
+	if last := len(o.events) - 1; last >= 0 && (o.events[last].Type == OrderCreatedEvent || o.events[last].Type == OrderUpdatedEvent) {
+		o.events[last].Current = o.snapshot()
+		return
+	}
+	o.recordEvent(OrderUpdatedEvent, &previous)
+}
+
+func (o *Order) snapshot() Order {
+	snapshot := *o
+	snapshot.Items = append([]Item(nil), o.Items...)
+	snapshot.Discounts = append([]Discount(nil), o.Discounts...)
+	snapshot.events = nil
+	return snapshot
+}
+
+func (o *Order) Events() []OrderEvent {
+	return o.events
+}
+
+func (o *Order) ClearEvents() {
+	o.events = nil
+}
@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+type InvalidTransitionError struct {
+	From   OrderStatus
+	To     OrderStatus
+	Reason string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("cannot change order status from %s to %s: %s", e.From, e.To, e.Reason)
+	}
+	return fmt.Sprintf("cannot change order status from %s to %s", e.From, e.To)
+}
+
+func (e *InvalidTransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// TransitionGuard vetoes an otherwise allowed transition by returning an error describing why.
+type TransitionGuard func(order *Order, to OrderStatus) error
+
+type StateMachine struct {
+	transitions map[OrderStatus]map[OrderStatus][]TransitionGuard
+}
+
+func NewStateMachine() *StateMachine {
+	return &StateMachine{
+		transitions: make(map[OrderStatus]map[OrderStatus][]TransitionGuard),
+	}
+}
+
+func (m *StateMachine) Allow(from OrderStatus, to ...OrderStatus) *StateMachine {
+	if m.transitions[from] == nil {
+		m.transitions[from] = make(map[OrderStatus][]TransitionGuard)
+	}
+	for _, status := range to {
+		if _, exists := m.transitions[from][status]; !exists {
+			m.transitions[from][status] = nil
+		}
+	}
+	return m
+}
+
+func (m *StateMachine) Guard(from, to OrderStatus, guard TransitionGuard) *StateMachine {
+	m.Allow(from, to)
+	m.transitions[from][to] = append(m.transitions[from][to], guard)
+	return m
+}
+
+func (m *StateMachine) Can(order *Order, to OrderStatus) error {
+	guards, allowed := m.transitions[order.Status][to]
+	if !allowed {
+		return &InvalidTransitionError{From: order.Status, To: to}
+	}
+
+	for _, guard := range guards {
+		if err := guard(order, to); err != nil {
+			return &InvalidTransitionError{From: order.Status, To: to, Reason: err.Error()}
+		}
+	}
+
+	return nil
+}
+
+// AllowedTransitions lists, in status order, every status the order can move to right now.
+func (m *StateMachine) AllowedTransitions(order *Order) []OrderStatus {
+	allowed := []OrderStatus{}
+	for _, status := range OrderStatuses {
+		if _, exists := m.transitions[order.Status][status]; !exists {
+			continue
+		}
+		if m.Can(order, status) == nil {
+			allowed = append(allowed, status)
+		}
+	}
+	return allowed
+}
+
+// Reachable reports whether to can be reached from from through one or more allowed transitions,
+// ignoring guards.
+func (m *StateMachine) Reachable(from, to OrderStatus) bool {
+	visited := map[OrderStatus]bool{from: true}
+	queue := []OrderStatus{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for next := range m.transitions[current] {
+			if next == to {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+var OrderStateMachine = NewStateMachine().
+	Allow(Pending, Processing, Canceled).
+	Allow(Processing, Completed).
+	Guard(Pending, Processing, requireItems)
+
+func requireItems(order *Order, _ OrderStatus) error {
+	if len(order.Items) == 0 {
+		return errors.New("order has no items")
+	}
+	return nil
+}
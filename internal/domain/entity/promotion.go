@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrPromotionNotApplicable = errors.New("promotion does not apply to the order")
+	ErrPromotionExhausted     = errors.New("promotion usage limit reached")
+)
+
+type PromotionRule string
+
+const (
+	// PromotionPercentage takes PercentOff percent off.
+	PromotionPercentage PromotionRule = "percentage"
+	// PromotionFixed takes AmountOff off the order, or off every unit of the product.
+	PromotionFixed PromotionRule = "fixed"
+	// PromotionBuyXGetY makes FreeQuantity units free for every BuyQuantity units of the product
+	// bought, counted within each item.
+	PromotionBuyXGetY PromotionRule = "buy_x_get_y"
+)
+
+var PromotionRules = []PromotionRule{PromotionPercentage, PromotionFixed, PromotionBuyXGetY}
+
+// Promotion is a discount granted by a coupon code. With a ProductID it discounts the items of that
+// product, otherwise the whole order. UsageLimit caps how many orders may redeem it; zero means no
+// limit.
+type Promotion struct {
+	Code          string
+	Description   string
+	Rule          PromotionRule
+	ProductID     string
+	PercentOff    int
+	AmountOff     Money
+	BuyQuantity   int
+	FreeQuantity  int
+	ValidFrom     *time.Time
+	ValidUntil    *time.Time
+	UsageLimit    int
+	TimesRedeemed int
+}
+
+// ActiveAt reports whether now falls in the validity window, whose ends are optional.
+func (p Promotion) ActiveAt(now time.Time) bool {
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return false
+	}
+	return p.ValidUntil == nil || now.Before(*p.ValidUntil)
+}
+
+// Discounts works out the discounts the promotion grants the current items of an order. Whether the
+// promotion is active is left to the caller, see ActiveAt.
+func (p Promotion) Discounts(order *Order) ([]Discount, error) {
+	var discounts []Discount
+	if p.ProductID == "" {
+		if p.Rule == PromotionBuyXGetY {
+			return nil, fmt.Errorf("%w: %s has no product", ErrPromotionNotApplicable, p.Code)
+		}
+		amount, err := p.discount(order.Subtotal(), 1, order.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if amount.IsPositive() {
+			discounts = append(discounts, Discount{Code: p.Code, Description: p.Description, Amount: amount})
+		}
+	}
+
+	for _, item := range order.Items {
+		if p.ProductID == "" || item.ProductID != p.ProductID {
+			continue
+		}
+		amount, err := p.itemDiscount(item, order.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if amount.IsPositive() {
+			discounts = append(discounts, Discount{Code: p.Code, ItemID: item.ID, Description: p.Description, Amount: amount})
+		}
+	}
+
+	if len(discounts) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPromotionNotApplicable, p.Code)
+	}
+	return discounts, nil
+}
+
+func (p Promotion) itemDiscount(item Item, currency string) (Money, error) {
+	if p.Rule != PromotionBuyXGetY {
+		return p.discount(item.Total(), item.Quantity, currency)
+	}
+	if p.BuyQuantity <= 0 || p.FreeQuantity <= 0 {
+		return Money{}, fmt.Errorf("promotion %s has invalid quantities", p.Code)
+	}
+	free := item.Quantity / (p.BuyQuantity + p.FreeQuantity) * p.FreeQuantity
+	return item.Price.Multiply(free), nil
+}
+
+// discount takes the percentage off base, or the fixed amount off each of units, never more than base.
+func (p Promotion) discount(base Money, units int, currency string) (Money, error) {
+	var amount Money
+	switch p.Rule {
+	case PromotionPercentage:
+		if p.PercentOff <= 0 || p.PercentOff > 100 {
+			return Money{}, fmt.Errorf("promotion %s has an invalid percentage", p.Code)
+		}
+		amount = NewMoney(base.Amount*int64(p.PercentOff)/100, currency)
+	case PromotionFixed:
+		if p.AmountOff.Currency != currency {
+			return Money{}, fmt.Errorf("%w: %s is in %s", ErrPromotionNotApplicable, p.Code, p.AmountOff.Currency)
+		}
+		amount = p.AmountOff.Multiply(units)
+	default:
+		return Money{}, fmt.Errorf("promotion %s has unknown rule %q", p.Code, p.Rule)
+	}
+
+	if amount.Amount > base.Amount {
+		amount.Amount = base.Amount
+	}
+	return amount, nil
+}
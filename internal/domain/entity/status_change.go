@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type StatusChange struct {
+	From      *OrderStatus
+	To        OrderStatus
+	ChangedBy string
+	Reason    string
+	ChangedAt time.Time
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Tax is the tax due on the order, the sum of the taxes of its items.
+func (o *Order) Tax() Money {
+	total := NewMoney(0, o.Currency)
+	for _, item := range o.Items {
+		total = total.Add(item.Tax)
+	}
+	return total
+}
+
+// TotalWithTax is the amount due for the order including tax.
+func (o *Order) TotalWithTax() Money {
+	return o.Total().Add(o.Tax())
+}
+
+// NetItemTotals returns the amount due for each item after discounts, the amounts taxes are worked
+// out on. Item discounts come off their item and order discounts are spread over the items in
+// proportion to what is left of them, the cents that do not divide evenly going to the first items.
+func (o *Order) NetItemTotals() []Money {
+	net := make([]Money, len(o.Items))
+	var orderDiscount int64
+	for i, item := range o.Items {
+		net[i] = item.Total()
+	}
+	for _, discount := range o.Discounts {
+		if discount.ItemID == "" {
+			orderDiscount += discount.Amount.Amount
+			continue
+		}
+		if index, found := o.findItem(discount.ItemID); found {
+			net[index] = net[index].Subtract(discount.Amount)
+		}
+	}
+
+	var base int64
+	for _, amount := range net {
+		base += amount.Amount
+	}
+	if orderDiscount == 0 || base <= 0 {
+		return net
+	}
+
+	shares := make([]int64, len(net))
+	remaining := orderDiscount
+	for i, amount := range net {
+		share := new(big.Int).Mul(big.NewInt(orderDiscount), big.NewInt(amount.Amount))
+		shares[i] = share.Quo(share, big.NewInt(base)).Int64()
+		remaining -= shares[i]
+	}
+	for i := 0; remaining > 0 && i < len(net); i++ {
+		if shares[i] < net[i].Amount {
+			shares[i]++
+			remaining--
+		}
+	}
+	for i := range net {
+		net[i].Amount -= shares[i]
+	}
+	return net
+}
+
+// ApplyTaxes records the jurisdiction a pending order is taxed in and the tax of each of its items,
+// given in item order.
+func (o *Order) ApplyTaxes(jurisdiction string, taxes []Money) error {
+	if o.Status != Pending {
+		return ErrOrderNotPending
+	}
+	if len(taxes) != len(o.Items) {
+		return fmt.Errorf("got %d taxes for %d items", len(taxes), len(o.Items))
+	}
+	for i, tax := range taxes {
+		if tax.Amount < 0 {
+			return NewValidationError("tax of item %s must not be negative", o.Items[i].ID)
+		}
+		if tax.Currency != o.Currency {
+			return fmt.Errorf("%w: tax of item %s is in %s but the order is in %s", ErrCurrencyMismatch, o.Items[i].ID, tax.Currency, o.Currency)
+		}
+	}
+
+	unchanged := jurisdiction == o.TaxJurisdiction && slices.EqualFunc(o.Items, taxes, func(item Item, tax Money) bool {
+		return item.Tax.Amount == tax.Amount
+	})
+	if unchanged {
+		return nil
+	}
+
+	previous := o.snapshot()
+	o.TaxJurisdiction = jurisdiction
+	for i := range o.Items {
+		o.Items[i].Tax = taxes[i]
+	}
+	o.recordAmendment(previous)
+	return nil
+}
@@ -0,0 +1,38 @@
+package entity_test
+
+import (
+	"testing"
+
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCustomer(t *testing.T) {
+	customer, err := entity.NewCustomer("c1", " John Doe ", "")
+	require.NoError(t, err)
+	assert.Equal(t, "John Doe", customer.Name)
+
+	_, err = entity.NewCustomer("c2", " ", "")
+	assert.ErrorIs(t, err, entity.ErrValidation)
+}
+
+func TestNormalizeCustomerName(t *testing.T) {
+	assert.Equal(t, "joão da silva", entity.NormalizeCustomerName("  João  DA\tSilva "))
+	assert.Equal(t, "", entity.NormalizeCustomerName(" "))
+}
+
+func TestOrder_AssignCustomer(t *testing.T) {
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, order.AssignCustomer("c1"))
+	require.NoError(t, order.AssignCustomer(""))
+
+	assert.Equal(t, "c1", order.CustomerID, "an empty id keeps the customer")
+	require.Len(t, order.Events(), 1, "the created event is brought up to date")
+	assert.Equal(t, "c1", order.Events()[0].Current.CustomerID)
+}
@@ -0,0 +1,106 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePostalCode(t *testing.T) {
+	tests := []struct {
+		country    string
+		postalCode string
+		valid      bool
+	}{
+		{"BR", "01310-100", true},
+		{"BR", "01310100", true},
+		{"BR", "1310-100", false},
+		{"US", "10001", true},
+		{"US", "10001-1234", true},
+		{"US", "1000", false},
+		{"CA", "K1A 0B1", true},
+		{"GB", "SW1A 1AA", true},
+		{"PT", "1000-001", true},
+		{"PT", "1000001", false},
+		{"AR", "C1002", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+" "+tt.postalCode, func(t *testing.T) {
+			err := entity.ValidatePostalCode(tt.country, tt.postalCode)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, entity.ErrInvalidPostalCode)
+			}
+		})
+	}
+}
+
+func TestDeliveryDetails_Validate(t *testing.T) {
+	address := &entity.Address{Line1: "Av. Paulista, 1000", City: "São Paulo", PostalCode: "01310-100", Country: "BR"}
+	start := time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		details entity.DeliveryDetails
+		wantErr error
+	}{
+		{name: "empty", details: entity.DeliveryDetails{}},
+		{name: "standard", details: entity.DeliveryDetails{ShippingAddress: address, DeliveryMethod: entity.DeliveryStandard}},
+		{name: "pickup without address", details: entity.DeliveryDetails{DeliveryMethod: entity.DeliveryPickup}},
+		{name: "express without address", details: entity.DeliveryDetails{DeliveryMethod: entity.DeliveryExpress}, wantErr: entity.ErrValidation},
+		{name: "unknown method", details: entity.DeliveryDetails{ShippingAddress: address, DeliveryMethod: "drone"}, wantErr: entity.ErrValidation},
+		{name: "invalid country", details: entity.DeliveryDetails{BillingAddress: &entity.Address{Line1: "Main St", City: "Springfield", Country: "usa"}}, wantErr: entity.ErrInvalidCountry},
+		{name: "invalid postal code", details: entity.DeliveryDetails{ShippingAddress: &entity.Address{Line1: "Main St", City: "Springfield", PostalCode: "ABC", Country: "US"}}, wantErr: entity.ErrInvalidPostalCode},
+		{name: "window ends before it starts", details: entity.DeliveryDetails{DeliveryWindow: &entity.DeliveryWindow{Start: start, End: start.Add(-time.Hour)}}, wantErr: entity.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.details.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrder_UpdateDeliveryDetails(t *testing.T) {
+	order := newPromotionOrder(t)
+	details := entity.DeliveryDetails{
+		ShippingAddress: &entity.Address{Line1: "Av. Paulista, 1000", City: "São Paulo", PostalCode: "01310-100", Country: "BR"},
+		DeliveryMethod:  entity.DeliveryStandard,
+	}
+
+	require.NoError(t, order.UpdateDeliveryDetails(details))
+
+	assert.Equal(t, details, order.DeliveryDetails)
+	require.Len(t, order.Events(), 1, "the created event is brought up to date")
+	payload, err := order.Events()[0].Payload()
+	require.NoError(t, err)
+	var created map[string]any
+	require.NoError(t, json.Unmarshal(payload, &created))
+	assert.Equal(t, "01310-100", created["shipping_address"].(map[string]any)["postal_code"])
+	assert.Equal(t, "standard", created["delivery_method"])
+
+	order.ClearEvents()
+	require.NoError(t, order.UpdateDeliveryDetails(details))
+	assert.Empty(t, order.Events(), "unchanged details record nothing")
+}
+
+func TestOrder_UpdateDeliveryDetails_NotPending(t *testing.T) {
+	order := newPromotionOrder(t)
+	order.Status = entity.Canceled
+
+	err := order.UpdateDeliveryDetails(entity.DeliveryDetails{DeliveryMethod: entity.DeliveryPickup})
+
+	assert.ErrorIs(t, err, entity.ErrOrderNotPending)
+}
@@ -10,39 +10,39 @@ import (
 )
 
 func TestNewItem_ValidItem(t *testing.T) {
-	item, err := entity.NewItem("1", "Product A", 2, 50.0)
+	item, err := entity.NewItem("1", "SKU-1", "Product A", 2, entity.NewMoney(5000, entity.DefaultCurrency))
 
 	require.NoError(t, err)
 
 	assert.Equal(t, "1", item.ID)
 	assert.Equal(t, "Product A", item.Name)
 	assert.Equal(t, 2, item.Quantity)
-	assert.Equal(t, 50.0, item.Price)
+	assert.Equal(t, entity.NewMoney(5000, entity.DefaultCurrency), item.Price)
 }
 
 func TestNewItem_InvalidItem(t *testing.T) {
-	item, err := entity.NewItem("1", "Product A", -2, 50.0)
+	item, err := entity.NewItem("1", "SKU-1", "Product A", -2, entity.NewMoney(5000, entity.DefaultCurrency))
 
 	require.Error(t, err)
 	assert.Nil(t, item)
 }
 
 func TestNewItem_EmptyName(t *testing.T) {
-	item, err := entity.NewItem("1", "", 2, 50.0)
+	item, err := entity.NewItem("1", "SKU-1", "", 2, entity.NewMoney(5000, entity.DefaultCurrency))
 
 	require.Error(t, err)
 	assert.Nil(t, item)
 }
 
 func TestItem_Total(t *testing.T) {
-	item, err := entity.NewItem("1", "Product A", 2, 50.0)
+	item, err := entity.NewItem("1", "SKU-1", "Product A", 2, entity.NewMoney(5000, entity.DefaultCurrency))
 	require.NoError(t, err)
 
-	assert.Equal(t, 100.0, item.Total())
+	assert.Equal(t, entity.NewMoney(10000, entity.DefaultCurrency), item.Total())
 }
 
 func TestItem_UpdateQuantity(t *testing.T) {
-	item, err := entity.NewItem("1", "Product A", 2, 50.0)
+	item, err := entity.NewItem("1", "SKU-1", "Product A", 2, entity.NewMoney(5000, entity.DefaultCurrency))
 	require.NoError(t, err)
 
 	err = item.UpdateQuantity(3)
@@ -52,7 +52,7 @@ func TestItem_UpdateQuantity(t *testing.T) {
 }
 
 func TestItem_UpdateQuantity_Invalid(t *testing.T) {
-	item, err := entity.NewItem("1", "Product A", 2, 50.0)
+	item, err := entity.NewItem("1", "SKU-1", "Product A", 2, entity.NewMoney(5000, entity.DefaultCurrency))
 	require.NoError(t, err)
 
 	err = item.UpdateQuantity(-1)
@@ -60,19 +60,19 @@ func TestItem_UpdateQuantity_Invalid(t *testing.T) {
 }
 
 func TestItem_UpdatePrice(t *testing.T) {
-	item, err := entity.NewItem("1", "Product A", 2, 50.0)
+	item, err := entity.NewItem("1", "SKU-1", "Product A", 2, entity.NewMoney(5000, entity.DefaultCurrency))
 	require.NoError(t, err)
 
-	err = item.UpdatePrice(60.0)
+	err = item.UpdatePrice(entity.NewMoney(6000, entity.DefaultCurrency))
 	require.NoError(t, err)
 
-	assert.Equal(t, 60.0, item.Price)
+	assert.Equal(t, entity.NewMoney(6000, entity.DefaultCurrency), item.Price)
 }
 
 func TestItem_UpdatePrice_Invalid(t *testing.T) {
-	item, err := entity.NewItem("1", "Product A", 2, 50.0)
+	item, err := entity.NewItem("1", "SKU-1", "Product A", 2, entity.NewMoney(5000, entity.DefaultCurrency))
 	require.NoError(t, err)
 
-	err = item.UpdatePrice(-10.0)
+	err = item.UpdatePrice(entity.NewMoney(-1000, entity.DefaultCurrency))
 	require.Error(t, err)
 }
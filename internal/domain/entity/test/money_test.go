@@ -0,0 +1,89 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{value: "19.99", expected: 1999},
+		{value: "10", expected: 1000},
+		{value: "0.1", expected: 10},
+		{value: "1e2", expected: 10000},
+		{value: "-5.50", expected: -550},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			money, err := entity.ParseMoney(tt.value, "BRL")
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.NewMoney(tt.expected, "BRL"), money)
+		})
+	}
+}
+
+func TestParseMoney_Invalid(t *testing.T) {
+	for _, value := range []string{"abc", "19.999", "1/3", ""} {
+		t.Run(value, func(t *testing.T) {
+			_, err := entity.ParseMoney(value, "BRL")
+
+			assert.ErrorIs(t, err, entity.ErrInvalidAmount)
+		})
+	}
+}
+
+func TestMoney_ArithmeticIsExact(t *testing.T) {
+	price, err := entity.ParseMoney("19.99", "BRL")
+	require.NoError(t, err)
+
+	total := price.Multiply(3).Add(entity.NewMoney(1, "BRL"))
+
+	assert.Equal(t, int64(5998), total.Amount)
+	assert.Equal(t, "59.98", total.Decimal())
+	assert.Equal(t, "59.98 BRL", total.String())
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	assert.Equal(t, "0.05", entity.NewMoney(5, "BRL").Decimal())
+	assert.Equal(t, "-1.50", entity.NewMoney(-150, "BRL").Decimal())
+	assert.Equal(t, "100.00", entity.NewMoney(10000, "BRL").Decimal())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var item entity.Item
+	err := json.Unmarshal([]byte(`{"id": "1", "name": "Product A", "quantity": 3, "price": 19.99}`), &item)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1999), item.Price.Amount)
+
+	err = json.Unmarshal([]byte(`{"price": "5.10"}`), &item)
+	require.NoError(t, err)
+	assert.Equal(t, int64(510), item.Price.Amount)
+
+	err = json.Unmarshal([]byte(`{"price": 5.105}`), &item)
+	assert.ErrorIs(t, err, entity.ErrInvalidAmount)
+
+	body, err := json.Marshal(entity.Item{ID: "1", Price: entity.NewMoney(1999, "BRL")})
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"price":19.99`)
+}
+
+func TestMoney_Convert(t *testing.T) {
+	price := entity.NewMoney(5997, "BRL")
+
+	assert.Equal(t, entity.NewMoney(1199, "USD"), price.Convert("USD", big.NewRat(1, 5)))
+	assert.Equal(t, entity.NewMoney(1079, "EUR"), price.Convert("EUR", big.NewRat(18, 100)))
+	assert.Equal(t, entity.NewMoney(-1079, "EUR"), entity.NewMoney(-5997, "BRL").Convert("EUR", big.NewRat(18, 100)))
+	assert.Equal(t, entity.NewMoney(1, "USD"), entity.NewMoney(5, "BRL").Convert("USD", big.NewRat(1, 10)))
+	assert.Equal(t, entity.NewMoney(-1, "USD"), entity.NewMoney(-5, "BRL").Convert("USD", big.NewRat(1, 10)))
+}
@@ -0,0 +1,101 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStateMachine_Transitions(t *testing.T) {
+	tests := []struct {
+		from    entity.OrderStatus
+		to      entity.OrderStatus
+		allowed bool
+	}{
+		{from: entity.Pending, to: entity.Processing, allowed: true},
+		{from: entity.Pending, to: entity.Canceled, allowed: true},
+		{from: entity.Pending, to: entity.Completed, allowed: false},
+		{from: entity.Processing, to: entity.Completed, allowed: true},
+		{from: entity.Processing, to: entity.Pending, allowed: false},
+		{from: entity.Processing, to: entity.Canceled, allowed: false},
+		{from: entity.Completed, to: entity.Canceled, allowed: false},
+		{from: entity.Canceled, to: entity.Pending, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			order := &entity.Order{
+				ID:     "12345",
+				Status: tt.from,
+				Items:  []entity.Item{{ID: "1", Name: "Product A", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+			}
+
+			err := order.SetStatus(tt.to)
+
+			if tt.allowed {
+				require.NoError(t, err)
+				assert.Equal(t, tt.to, order.Status)
+				return
+			}
+
+			require.ErrorIs(t, err, entity.ErrInvalidTransition)
+			var transitionErr *entity.InvalidTransitionError
+			require.True(t, errors.As(err, &transitionErr))
+			assert.Equal(t, tt.from, transitionErr.From)
+			assert.Equal(t, tt.to, transitionErr.To)
+			assert.Equal(t, tt.from, order.Status)
+		})
+	}
+}
+
+func TestOrderStateMachine_Guard(t *testing.T) {
+	order := &entity.Order{ID: "12345", Status: entity.Pending}
+
+	err := order.SetStatus(entity.Processing)
+
+	var transitionErr *entity.InvalidTransitionError
+	require.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, "order has no items", transitionErr.Reason)
+	assert.Equal(t, entity.Pending, order.Status)
+}
+
+func TestStateMachine_CustomGuard(t *testing.T) {
+	machine := entity.NewStateMachine().
+		Allow(entity.Pending, entity.Canceled).
+		Guard(entity.Pending, entity.Processing, func(order *entity.Order, to entity.OrderStatus) error {
+			return errors.New("processing is paused")
+		})
+
+	order := &entity.Order{ID: "12345", Status: entity.Pending}
+
+	assert.NoError(t, machine.Can(order, entity.Canceled))
+	assert.ErrorIs(t, machine.Can(order, entity.Processing), entity.ErrInvalidTransition)
+	assert.Equal(t, []entity.OrderStatus{entity.Canceled}, machine.AllowedTransitions(order))
+}
+
+func TestOrder_AllowedTransitions(t *testing.T) {
+	order := &entity.Order{
+		ID:     "12345",
+		Status: entity.Pending,
+		Items:  []entity.Item{{ID: "1", Name: "Product A", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}},
+	}
+	assert.Equal(t, []entity.OrderStatus{entity.Processing, entity.Canceled}, order.AllowedTransitions())
+
+	order.Status = entity.Processing
+	assert.Equal(t, []entity.OrderStatus{entity.Completed}, order.AllowedTransitions())
+
+	order.Status = entity.Canceled
+	assert.Empty(t, order.AllowedTransitions())
+}
+
+func TestOrderStateMachine_Reachable(t *testing.T) {
+	assert.True(t, entity.OrderStateMachine.Reachable(entity.Pending, entity.Completed))
+	assert.True(t, entity.OrderStateMachine.Reachable(entity.Processing, entity.Completed))
+	assert.False(t, entity.OrderStateMachine.Reachable(entity.Completed, entity.Processing))
+	assert.False(t, entity.OrderStateMachine.Reachable(entity.Canceled, entity.Processing))
+	assert.False(t, entity.OrderStateMachine.Reachable(entity.Pending, entity.Pending))
+}
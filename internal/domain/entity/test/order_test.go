@@ -11,11 +11,11 @@ import (
 
 func TestNewOrder_ValidOrder(t *testing.T) {
 	items := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: 2, Price: 50.0},
-		{ID: "2", Name: "Product B", Quantity: 1, Price: 30.0},
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+		{ID: "2", Name: "Product B", Quantity: 1, Price: entity.NewMoney(3000, entity.DefaultCurrency)},
 	}
 
-	order, err := entity.NewOrder("12345", "João Silva", items)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
 
 	require.NoError(t, err)
 
@@ -23,22 +23,63 @@ func TestNewOrder_ValidOrder(t *testing.T) {
 	assert.Equal(t, "João Silva", order.CustomerName)
 	assert.Equal(t, entity.Pending, order.Status)
 	assert.Len(t, order.Items, 2)
-	assert.Equal(t, 130.0, order.Total())
+	assert.Equal(t, entity.NewMoney(13000, entity.DefaultCurrency), order.Total())
+}
+
+func TestNewOrder_RecordsCreatedEvent(t *testing.T) {
+	items := []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+	}
+
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
+	require.NoError(t, err)
+
+	events := order.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, entity.OrderCreatedEvent, events[0].Type)
+	assert.Equal(t, "12345", events[0].OrderID)
+
+	order.ClearEvents()
+	assert.Empty(t, order.Events())
 }
 
 func TestNewOrder_InvalidItems(t *testing.T) {
 	items := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: -2, Price: 50.0},
+		{ID: "1", Name: "Product A", Quantity: -2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
 	}
 
-	order, err := entity.NewOrder("12345", "João Silva", items)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
 
 	require.Error(t, err)
 	assert.Nil(t, order)
 }
 
+func TestNewOrder_Currency(t *testing.T) {
+	order, err := entity.NewOrder("12345", "João Silva", "USD", []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, "USD")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", order.Currency)
+	assert.Equal(t, entity.NewMoney(10000, "USD"), order.Total())
+
+	_, err = entity.NewOrder("12345", "João Silva", "JPY", []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, "JPY")},
+	})
+	assert.ErrorIs(t, err, entity.ErrUnsupportedCurrency)
+
+	_, err = entity.NewOrder("12345", "João Silva", "USD", []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, "EUR")},
+	})
+	assert.ErrorIs(t, err, entity.ErrCurrencyMismatch)
+
+	err = order.UpdateOrderDetails("João Silva", []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 1, Price: entity.NewMoney(5000, "BRL")},
+	})
+	assert.ErrorIs(t, err, entity.ErrCurrencyMismatch)
+}
+
 func TestNewOrder_EmptyItems(t *testing.T) {
-	order, err := entity.NewOrder("12345", "João Silva", nil)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, nil)
 
 	require.Error(t, err)
 	assert.Nil(t, order)
@@ -46,9 +87,9 @@ func TestNewOrder_EmptyItems(t *testing.T) {
 
 func TestOrder_IsValid(t *testing.T) {
 	items := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: 2, Price: 50.0},
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
 	}
-	order, err := entity.NewOrder("12345", "João Silva", items)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
 	require.NoError(t, err)
 
 	err = order.IsValid()
@@ -61,26 +102,26 @@ func TestOrder_IsValid(t *testing.T) {
 
 func TestOrder_Total(t *testing.T) {
 	items := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: 2, Price: 50.0},
-		{ID: "2", Name: "Product B", Quantity: 1, Price: 30.0},
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+		{ID: "2", Name: "Product B", Quantity: 1, Price: entity.NewMoney(3000, entity.DefaultCurrency)},
 	}
-	order, err := entity.NewOrder("12345", "João Silva", items)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
 	require.NoError(t, err)
 
-	assert.Equal(t, 130.0, order.Total())
+	assert.Equal(t, entity.NewMoney(13000, entity.DefaultCurrency), order.Total())
 }
 
 func TestOrder_UpdateOrderDetails(t *testing.T) {
 	items := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: 2, Price: 50.0},
-		{ID: "2", Name: "Product B", Quantity: 1, Price: 30.0},
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+		{ID: "2", Name: "Product B", Quantity: 1, Price: entity.NewMoney(3000, entity.DefaultCurrency)},
 	}
-	order, err := entity.NewOrder("12345", "João Silva", items)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
 	require.NoError(t, err)
 
 	newItems := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: 3, Price: 45.0},
-		{ID: "3", Name: "Product C", Quantity: 2, Price: 25.0},
+		{ID: "1", Name: "Product A", Quantity: 3, Price: entity.NewMoney(4500, entity.DefaultCurrency)},
+		{ID: "3", Name: "Product C", Quantity: 2, Price: entity.NewMoney(2500, entity.DefaultCurrency)},
 	}
 
 	err = order.UpdateOrderDetails("Maria Silva", newItems)
@@ -97,15 +138,15 @@ func TestOrder_UpdateOrderDetails(t *testing.T) {
 
 func TestOrder_UpdateOrderDetails_NotPending(t *testing.T) {
 	items := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: 2, Price: 50.0},
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
 	}
-	order, err := entity.NewOrder("12345", "João Silva", items)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
 	require.NoError(t, err)
 
 	order.Status = entity.Processing
 
 	newItems := []entity.Item{
-		{ID: "2", Name: "Product B", Quantity: 2, Price: 40.0},
+		{ID: "2", Name: "Product B", Quantity: 2, Price: entity.NewMoney(4000, entity.DefaultCurrency)},
 	}
 
 	err = order.UpdateOrderDetails("Maria Silva", newItems)
@@ -115,13 +156,13 @@ func TestOrder_UpdateOrderDetails_NotPending(t *testing.T) {
 
 func TestOrder_UpdateOrderDetails_InvalidItem(t *testing.T) {
 	items := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: 2, Price: 50.0},
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
 	}
-	order, err := entity.NewOrder("12345", "João Silva", items)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
 	require.NoError(t, err)
 
 	newItems := []entity.Item{
-		{ID: "2", Name: "Product B", Quantity: -1, Price: 40.0},
+		{ID: "2", Name: "Product B", Quantity: -1, Price: entity.NewMoney(4000, entity.DefaultCurrency)},
 	}
 
 	err = order.UpdateOrderDetails("Maria Silva", newItems)
@@ -131,12 +172,203 @@ func TestOrder_UpdateOrderDetails_InvalidItem(t *testing.T) {
 
 func TestOrder_UpdateOrderDetails_EmptyItems(t *testing.T) {
 	items := []entity.Item{
-		{ID: "1", Name: "Product A", Quantity: 2, Price: 50.0},
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
 	}
-	order, err := entity.NewOrder("12345", "João Silva", items)
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
 	require.NoError(t, err)
 
 	err = order.UpdateOrderDetails("Maria Silva", nil)
 	require.Error(t, err)
 	assert.Equal(t, "order must contain at least one item", err.Error())
 }
+
+func TestOrder_UpdateOrderDetails_RecordsUpdatedEvent(t *testing.T) {
+	items := []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+	}
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, items)
+	require.NoError(t, err)
+	order.ClearEvents()
+
+	err = order.UpdateOrderDetails("Maria Silva", []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 3, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+
+	events := order.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, entity.OrderUpdatedEvent, events[0].Type)
+	require.NotNil(t, events[0].Previous)
+	assert.Equal(t, "João Silva", events[0].Previous.CustomerName)
+	assert.Equal(t, 2, events[0].Previous.Items[0].Quantity)
+	assert.Equal(t, "Maria Silva", events[0].Current.CustomerName)
+	assert.Equal(t, 3, events[0].Current.Items[0].Quantity)
+}
+
+func newItemsOrder(t *testing.T) *entity.Order {
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+		{ID: "2", Name: "Product B", Quantity: 1, Price: entity.NewMoney(3000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	order.ClearEvents()
+	return order
+}
+
+func TestOrder_AddItem(t *testing.T) {
+	order := newItemsOrder(t)
+
+	err := order.AddItem(entity.Item{ID: "3", Name: "Product C", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)})
+
+	require.NoError(t, err)
+	assert.Len(t, order.Items, 3)
+	assert.Equal(t, entity.NewMoney(14000, entity.DefaultCurrency), order.Total())
+	events := order.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, entity.OrderUpdatedEvent, events[0].Type)
+	assert.Len(t, events[0].Previous.Items, 2)
+
+	err = order.AddItem(entity.Item{ID: "1", Name: "Product A", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)})
+	assert.ErrorIs(t, err, entity.ErrDuplicateItem)
+
+	err = order.AddItem(entity.Item{ID: "4", Name: "Product D", Quantity: 1, Price: entity.NewMoney(1000, "USD")})
+	assert.ErrorIs(t, err, entity.ErrCurrencyMismatch)
+	assert.Len(t, order.Items, 3)
+}
+
+func TestOrder_UpdateItem(t *testing.T) {
+	order := newItemsOrder(t)
+	quantity := 4
+	price := entity.NewMoney(2500, entity.DefaultCurrency)
+
+	err := order.UpdateItem("2", entity.ItemChanges{Quantity: &quantity, Price: &price})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, order.Items[1].Quantity)
+	assert.Equal(t, price, order.Items[1].Price)
+	assert.Equal(t, "Product B", order.Items[1].Name)
+	require.Len(t, order.Events(), 1)
+	assert.Equal(t, 1, order.Events()[0].Previous.Items[1].Quantity)
+
+	zero := 0
+	err = order.UpdateItem("2", entity.ItemChanges{Quantity: &zero, Price: &entity.Money{Amount: 100, Currency: entity.DefaultCurrency}})
+	assert.ErrorIs(t, err, entity.ErrValidation)
+	assert.Equal(t, 4, order.Items[1].Quantity)
+	assert.Equal(t, price, order.Items[1].Price)
+
+	err = order.UpdateItem("missing", entity.ItemChanges{Quantity: &quantity})
+	assert.ErrorIs(t, err, entity.ErrItemNotFound)
+}
+
+func TestOrder_RemoveItem(t *testing.T) {
+	order := newItemsOrder(t)
+
+	require.NoError(t, order.RemoveItem("1"))
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, "2", order.Items[0].ID)
+	require.Len(t, order.Events(), 1)
+	assert.Len(t, order.Events()[0].Previous.Items, 2)
+
+	assert.ErrorIs(t, order.RemoveItem("1"), entity.ErrItemNotFound)
+	assert.ErrorIs(t, order.RemoveItem("2"), entity.ErrLastItem)
+	assert.Len(t, order.Items, 1)
+}
+
+func TestOrder_ItemChanges_NotPending(t *testing.T) {
+	order := newItemsOrder(t)
+	require.NoError(t, order.SetStatus(entity.Processing))
+	quantity := 3
+
+	assert.ErrorIs(t, order.AddItem(entity.Item{ID: "3", Name: "Product C", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}), entity.ErrOrderNotPending)
+	assert.ErrorIs(t, order.UpdateItem("1", entity.ItemChanges{Quantity: &quantity}), entity.ErrOrderNotPending)
+	assert.ErrorIs(t, order.RemoveItem("1"), entity.ErrOrderNotPending)
+}
+
+func TestOrder_SetStatus_RecordsStatusEvents(t *testing.T) {
+	tests := []struct {
+		from     entity.OrderStatus
+		status   entity.OrderStatus
+		expected entity.EventType
+	}{
+		{from: entity.Pending, status: entity.Processing, expected: entity.OrderStatusChangedEvent},
+		{from: entity.Processing, status: entity.Completed, expected: entity.OrderCompletedEvent},
+		{from: entity.Pending, status: entity.Canceled, expected: entity.OrderCanceledEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, []entity.Item{
+				{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+			})
+			require.NoError(t, err)
+			order.Status = tt.from
+			order.ClearEvents()
+
+			require.NoError(t, order.SetStatus(tt.status))
+
+			events := order.Events()
+			require.Len(t, events, 1)
+			assert.Equal(t, tt.expected, events[0].Type)
+			assert.Equal(t, tt.from, events[0].Previous.Status)
+			assert.Equal(t, tt.status, events[0].Current.Status)
+		})
+	}
+}
+
+func TestOrder_SetStatus_SameStatusRecordsNoEvent(t *testing.T) {
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	order.ClearEvents()
+
+	require.NoError(t, order.SetStatus(entity.Pending))
+	assert.Empty(t, order.Events())
+}
+
+func TestOrderEvent_Payload(t *testing.T) {
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+
+	created, err := order.Events()[0].Payload()
+	require.NoError(t, err)
+	assert.Contains(t, string(created), `"id":"12345"`)
+	assert.NotContains(t, string(created), `"previous"`)
+
+	order.ClearEvents()
+	require.NoError(t, order.SetStatus(entity.Canceled))
+
+	canceled, err := order.Events()[0].Payload()
+	require.NoError(t, err)
+	assert.Contains(t, string(canceled), `"type":"order_canceled"`)
+	assert.Contains(t, string(canceled), `"previous"`)
+	assert.Contains(t, string(canceled), `"current"`)
+}
+
+func TestOrderEvent_StatusChange(t *testing.T) {
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, []entity.Item{
+		{ID: "1", Name: "Product A", Quantity: 2, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+
+	created, ok := order.Events()[0].StatusChange()
+	require.True(t, ok)
+	assert.Nil(t, created.From)
+	assert.Equal(t, entity.Pending, created.To)
+
+	order.ClearEvents()
+	require.NoError(t, order.UpdateOrderDetails("Maria Silva", order.Items))
+	_, ok = order.Events()[0].StatusChange()
+	assert.False(t, ok)
+
+	order.ClearEvents()
+	require.NoError(t, order.ChangeStatus(entity.Canceled, "support", "customer request"))
+	canceled, ok := order.Events()[0].StatusChange()
+	require.True(t, ok)
+	assert.Equal(t, entity.Pending, *canceled.From)
+	assert.Equal(t, entity.Canceled, canceled.To)
+	assert.Equal(t, "support", canceled.ChangedBy)
+	assert.Equal(t, "customer request", canceled.Reason)
+}
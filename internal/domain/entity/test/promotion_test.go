@@ -0,0 +1,139 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPromotionOrder(t *testing.T) *entity.Order {
+	order, err := entity.NewOrder("12345", "João Silva", entity.DefaultCurrency, []entity.Item{
+		{ID: "1", ProductID: "sku-a", Name: "Product A", Quantity: 5, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		{ID: "2", ProductID: "sku-b", Name: "Product B", Quantity: 1, Price: entity.NewMoney(3000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	return order
+}
+
+func TestPromotion_Discounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		promotion entity.Promotion
+		expected  []entity.Discount
+	}{
+		{
+			name:      "percentage off the order",
+			promotion: entity.Promotion{Code: "TEN", Rule: entity.PromotionPercentage, PercentOff: 10},
+			expected:  []entity.Discount{{Code: "TEN", Amount: entity.NewMoney(800, entity.DefaultCurrency)}},
+		},
+		{
+			name:      "percentage off a product",
+			promotion: entity.Promotion{Code: "TEN-A", Rule: entity.PromotionPercentage, PercentOff: 10, ProductID: "sku-a"},
+			expected:  []entity.Discount{{Code: "TEN-A", ItemID: "1", Amount: entity.NewMoney(500, entity.DefaultCurrency)}},
+		},
+		{
+			name:      "fixed amount off the order",
+			promotion: entity.Promotion{Code: "FIVE", Rule: entity.PromotionFixed, AmountOff: entity.NewMoney(500, entity.DefaultCurrency)},
+			expected:  []entity.Discount{{Code: "FIVE", Amount: entity.NewMoney(500, entity.DefaultCurrency)}},
+		},
+		{
+			name:      "fixed amount off every unit, capped at the item total",
+			promotion: entity.Promotion{Code: "BIG", Rule: entity.PromotionFixed, AmountOff: entity.NewMoney(4000, entity.DefaultCurrency), ProductID: "sku-b"},
+			expected:  []entity.Discount{{Code: "BIG", ItemID: "2", Amount: entity.NewMoney(3000, entity.DefaultCurrency)}},
+		},
+		{
+			name:      "buy two get one",
+			promotion: entity.Promotion{Code: "3FOR2", Rule: entity.PromotionBuyXGetY, ProductID: "sku-a", BuyQuantity: 2, FreeQuantity: 1},
+			expected:  []entity.Discount{{Code: "3FOR2", ItemID: "1", Amount: entity.NewMoney(1000, entity.DefaultCurrency)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discounts, err := tt.promotion.Discounts(newPromotionOrder(t))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, discounts)
+		})
+	}
+}
+
+func TestPromotion_DiscountsNotApplicable(t *testing.T) {
+	tests := []struct {
+		name      string
+		promotion entity.Promotion
+	}{
+		{name: "product not ordered", promotion: entity.Promotion{Code: "X", Rule: entity.PromotionPercentage, PercentOff: 10, ProductID: "sku-x"}},
+		{name: "too few units", promotion: entity.Promotion{Code: "X", Rule: entity.PromotionBuyXGetY, ProductID: "sku-b", BuyQuantity: 1, FreeQuantity: 1}},
+		{name: "other currency", promotion: entity.Promotion{Code: "X", Rule: entity.PromotionFixed, AmountOff: entity.NewMoney(500, "USD")}},
+		{name: "buy x get y without product", promotion: entity.Promotion{Code: "X", Rule: entity.PromotionBuyXGetY, BuyQuantity: 1, FreeQuantity: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.promotion.Discounts(newPromotionOrder(t))
+
+			assert.ErrorIs(t, err, entity.ErrPromotionNotApplicable)
+		})
+	}
+}
+
+func TestPromotion_ActiveAt(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	promotion := entity.Promotion{ValidFrom: &from, ValidUntil: &until}
+
+	assert.False(t, promotion.ActiveAt(from.Add(-time.Second)))
+	assert.True(t, promotion.ActiveAt(from))
+	assert.False(t, promotion.ActiveAt(until))
+	assert.True(t, entity.Promotion{}.ActiveAt(until))
+}
+
+func TestOrder_ApplyDiscounts(t *testing.T) {
+	order := newPromotionOrder(t)
+	discounts := []entity.Discount{{Code: "TEN", Amount: entity.NewMoney(800, entity.DefaultCurrency)}}
+
+	require.NoError(t, order.ApplyDiscounts("TEN", discounts))
+
+	assert.Equal(t, "TEN", order.CouponCode)
+	assert.Equal(t, entity.NewMoney(8000, entity.DefaultCurrency), order.Subtotal())
+	assert.Equal(t, entity.NewMoney(800, entity.DefaultCurrency), order.DiscountTotal())
+	assert.Equal(t, entity.NewMoney(7200, entity.DefaultCurrency), order.Total())
+
+	events := order.Events()
+	require.Len(t, events, 1, "the created event is brought up to date")
+	assert.Equal(t, discounts, events[0].Current.Discounts)
+
+	order.ClearEvents()
+	require.NoError(t, order.ApplyDiscounts("", nil))
+	assert.Empty(t, order.CouponCode)
+	assert.Equal(t, entity.NewMoney(8000, entity.DefaultCurrency), order.Total())
+	require.Len(t, order.Events(), 1)
+	assert.Equal(t, entity.OrderUpdatedEvent, order.Events()[0].Type)
+}
+
+func TestOrder_ApplyDiscountsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		discount entity.Discount
+	}{
+		{name: "not positive", discount: entity.Discount{Code: "X", Amount: entity.NewMoney(0, entity.DefaultCurrency)}},
+		{name: "other currency", discount: entity.Discount{Code: "X", Amount: entity.NewMoney(100, "USD")}},
+		{name: "unknown item", discount: entity.Discount{Code: "X", ItemID: "9", Amount: entity.NewMoney(100, entity.DefaultCurrency)}},
+		{name: "above the item total", discount: entity.Discount{Code: "X", ItemID: "2", Amount: entity.NewMoney(3001, entity.DefaultCurrency)}},
+		{name: "above the subtotal", discount: entity.Discount{Code: "X", Amount: entity.NewMoney(8001, entity.DefaultCurrency)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newPromotionOrder(t)
+
+			assert.Error(t, order.ApplyDiscounts("X", []entity.Discount{tt.discount}))
+			assert.Empty(t, order.Discounts)
+		})
+	}
+}
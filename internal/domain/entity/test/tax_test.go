@@ -0,0 +1,48 @@
+package entity_test
+
+import (
+	"testing"
+
+	"order-service/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_NetItemTotals(t *testing.T) {
+	order := newPromotionOrder(t)
+	require.NoError(t, order.ApplyDiscounts("MIX", []entity.Discount{
+		{Code: "MIX", ItemID: "1", Amount: entity.NewMoney(1000, entity.DefaultCurrency)},
+		{Code: "MIX", Amount: entity.NewMoney(701, entity.DefaultCurrency)},
+	}))
+
+	net := order.NetItemTotals()
+
+	// 4000 and 3000 are left after the item discount; 701 is spread as 400.57 and 300.43.
+	assert.Equal(t, []entity.Money{
+		entity.NewMoney(3599, entity.DefaultCurrency),
+		entity.NewMoney(2700, entity.DefaultCurrency),
+	}, net)
+	assert.Equal(t, order.Total(), net[0].Add(net[1]))
+}
+
+func TestOrder_ApplyTaxes(t *testing.T) {
+	order := newPromotionOrder(t)
+	taxes := []entity.Money{entity.NewMoney(900, entity.DefaultCurrency), entity.NewMoney(540, entity.DefaultCurrency)}
+
+	require.NoError(t, order.ApplyTaxes("BR-SP", taxes))
+
+	assert.Equal(t, "BR-SP", order.TaxJurisdiction)
+	assert.Equal(t, entity.NewMoney(1440, entity.DefaultCurrency), order.Tax())
+	assert.Equal(t, entity.NewMoney(9440, entity.DefaultCurrency), order.TotalWithTax())
+	require.Len(t, order.Events(), 1, "the created event is brought up to date")
+	assert.Equal(t, entity.NewMoney(540, entity.DefaultCurrency), order.Events()[0].Current.Items[1].Tax)
+
+	order.ClearEvents()
+	require.NoError(t, order.ApplyTaxes("BR-SP", taxes))
+	assert.Empty(t, order.Events(), "unchanged taxes record no event")
+
+	assert.Error(t, order.ApplyTaxes("BR-SP", taxes[:1]))
+	assert.Error(t, order.ApplyTaxes("BR-SP", []entity.Money{entity.NewMoney(-1, entity.DefaultCurrency), taxes[1]}))
+	assert.ErrorIs(t, order.ApplyTaxes("BR-SP", []entity.Money{entity.NewMoney(1, "USD"), taxes[1]}), entity.ErrCurrencyMismatch)
+}
@@ -0,0 +1,14 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"math/big"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// RateProvider returns how many units of the target currency one unit of the source currency buys.
+type RateProvider interface {
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
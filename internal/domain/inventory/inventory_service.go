@@ -0,0 +1,43 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// InsufficientStockError reports the first product that cannot cover a reservation.
+type InsufficientStockError struct {
+	ProductID string
+	Requested int
+	Available int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("%s: product %s has %d available, %d requested", ErrInsufficientStock, e.ProductID, e.Available, e.Requested)
+}
+
+func (e *InsufficientStockError) Is(target error) bool {
+	return target == ErrInsufficientStock
+}
+
+// Reservation is the quantity of a product held for an order.
+type Reservation struct {
+	ProductID string
+	Quantity  int
+}
+
+// InventoryService holds stock for orders. Every call is atomic: when one product cannot be reserved,
+// none of the reservations of the call change. Products the inventory does not track are not limited.
+type InventoryService interface {
+	// Reserve holds stock for the items of a new order.
+	Reserve(ctx context.Context, orderID string, reservations []Reservation) error
+	// Adjust replaces the reservations of an order, holding or freeing only the differences.
+	Adjust(ctx context.Context, orderID string, reservations []Reservation) error
+	// Release frees every reservation of an order.
+	Release(ctx context.Context, orderID string) error
+	// Consume takes the stock reserved for a fulfilled order out of stock and drops its reservations.
+	Consume(ctx context.Context, orderID string) error
+}
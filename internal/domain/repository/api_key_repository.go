@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/domain/auth"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *auth.APIKey) error
+	FindByID(ctx context.Context, id string) (*auth.APIKey, error)
+	// FindByHash finds the key with a hash made by auth.HashAPIKey.
+	FindByHash(ctx context.Context, hash string) (*auth.APIKey, error)
+	// List returns every key, newest first.
+	List(ctx context.Context) ([]auth.APIKey, error)
+	// TouchLastUsed records that a key was used at a time.
+	TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error
+}
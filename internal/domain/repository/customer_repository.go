@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/domain/entity"
+)
+
+var ErrCustomerNotFound = errors.New("customer not found")
+
+type CustomerQuery struct {
+	Page int
+	Size int
+	// Name matches customers whose normalized name contains the normalized value.
+	Name string
+}
+
+func (q CustomerQuery) Offset() int {
+	if q.Page <= 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.Size
+}
+
+type CustomerPage struct {
+	Customers []entity.Customer
+	Total     int
+}
+
+type CustomerRepository interface {
+	Save(ctx context.Context, customer *entity.Customer) error
+
+	FindByID(ctx context.Context, id string) (*entity.Customer, error)
+
+	List(ctx context.Context, query CustomerQuery) (CustomerPage, error)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord is an idempotency key as used by one caller: keys of different owners, the
+// subjects of the callers, never collide. LockedUntil is when the request that reserved the key loses
+// it, should it never store its response.
+type IdempotencyRecord struct {
+	Owner       string
+	Key         string
+	RequestHash string
+	Response    []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	LockedUntil time.Time
+}
+
+// Completed reports whether the request that reserved the key has stored its response.
+func (r IdempotencyRecord) Completed() bool {
+	return r.Response != nil
+}
+
+type IdempotencyRepository interface {
+	// Reserve stores the record unless a live record already exists for the owner and key, in which
+	// case the existing record is returned and reserved is false. Expired records are replaced, as are
+	// records whose request is still in progress past their LockedUntil.
+	Reserve(ctx context.Context, record IdempotencyRecord) (existing IdempotencyRecord, reserved bool, err error)
+	Complete(ctx context.Context, owner, key string, response []byte) error
+	Release(ctx context.Context, owner, key string) error
+}
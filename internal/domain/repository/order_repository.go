@@ -3,16 +3,79 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"order-service/internal/domain/entity"
 )
 
-var ErrNotFound = errors.New("order not found")
+var (
+	ErrNotFound = errors.New("order not found")
+
+	// ErrConcurrentModification is returned by Save when the order changed since it was loaded.
+	ErrConcurrentModification = errors.New("order was modified concurrently")
+)
+
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+type SortField string
+
+const (
+	SortByCreatedAt    SortField = "created_at"
+	SortByUpdatedAt    SortField = "updated_at"
+	SortByCustomerName SortField = "customer_name"
+	SortByStatus       SortField = "status"
+)
+
+type OrderQuery struct {
+	Page          int
+	Size          int
+	Status        *entity.OrderStatus
+	CustomerID    string
+	CustomerName  string
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	SortBy        SortField
+	SortDirection SortDirection
+	Keyset        bool
+	After         *OrderCursor
+}
+
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func (q OrderQuery) Offset() int {
+	if q.Page <= 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.Size
+}
+
+type OrderPage struct {
+	Orders     []entity.Order
+	Total      int
+	NextCursor *OrderCursor
+}
+
+// SideEffect is a change made alongside saving an order, such as reserving its stock.
+type SideEffect func(ctx context.Context) error
 
 type OrderRepository interface {
-	Save(ctx context.Context, order *entity.Order) error
+	// Save stores the order when it is still at the version it was loaded with. The side effects run
+	// in turn once that version is checked and before the order is written, in the same transaction
+	// for the repositories that share its database, so when one fails or the save loses a race with
+	// another writer none of them are kept.
+	Save(ctx context.Context, order *entity.Order, effects ...SideEffect) error
 
 	FindByID(ctx context.Context, id string) (*entity.Order, error)
 
-	List(ctx context.Context) ([]entity.Order, error)
+	List(ctx context.Context, query OrderQuery) (OrderPage, error)
+
+	FindStatusHistory(ctx context.Context, orderID string) ([]entity.StatusChange, error)
 }
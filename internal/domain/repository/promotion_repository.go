@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"order-service/internal/domain/entity"
+)
+
+var ErrPromotionNotFound = errors.New("promotion not found")
+
+type PromotionRepository interface {
+	FindByCode(ctx context.Context, code string) (*entity.Promotion, error)
+
+	// Redeem counts a use of the promotion by an order, failing with entity.ErrPromotionExhausted when
+	// its usage limit is reached. Redeeming it again for the same order changes nothing.
+	Redeem(ctx context.Context, code, orderID string) error
+
+	// Release gives back the use of the promotion by an order, if it had one.
+	Release(ctx context.Context, code, orderID string) error
+}
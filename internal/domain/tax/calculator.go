@@ -0,0 +1,75 @@
+package tax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"order-service/internal/domain/entity"
+)
+
+var ErrUnknownJurisdiction = errors.New("unknown tax jurisdiction")
+
+// Line is an order line to tax: the amount due for it after discounts and the product category that
+// selects its rate.
+type Line struct {
+	Category string
+	Amount   entity.Money
+}
+
+// Assessment holds the tax of every line, in the order the lines were given, and the jurisdiction
+// they were taxed in.
+type Assessment struct {
+	Jurisdiction string
+	Taxes        []entity.Money
+}
+
+// Calculator works out the tax due on order lines in a jurisdiction. An empty jurisdiction selects
+// the default one of the calculator, which the assessment reports.
+type Calculator interface {
+	Calculate(ctx context.Context, jurisdiction string, lines []Line) (Assessment, error)
+}
+
+// Rounding tells how a tax amount is rounded to the minor unit of its currency.
+type Rounding string
+
+const (
+	// RoundHalfUp rounds halves away from zero.
+	RoundHalfUp Rounding = "half_up"
+	// RoundHalfEven rounds halves to the even minor unit.
+	RoundHalfEven Rounding = "half_even"
+	// RoundDown drops fractions of the minor unit.
+	RoundDown Rounding = "down"
+	// RoundUp raises fractions of the minor unit to the next one.
+	RoundUp Rounding = "up"
+)
+
+func ParseRounding(rounding string) (Rounding, error) {
+	switch Rounding(rounding) {
+	case "", RoundHalfUp:
+		return RoundHalfUp, nil
+	case RoundHalfEven, RoundDown, RoundUp:
+		return Rounding(rounding), nil
+	default:
+		return "", fmt.Errorf("invalid tax rounding %q, expected %q, %q, %q or %q", rounding, RoundHalfUp, RoundHalfEven, RoundDown, RoundUp)
+	}
+}
+
+// Round rounds a non-negative amount of minor units to a whole number of them.
+func (r Rounding) Round(amount *big.Rat) int64 {
+	quotient, remainder := new(big.Int).QuoRem(amount.Num(), amount.Denom(), new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient.Int64()
+	}
+
+	doubled := new(big.Int).Mul(remainder, big.NewInt(2))
+	half := doubled.Cmp(amount.Denom())
+	switch {
+	case r == RoundUp,
+		r == RoundHalfUp && half >= 0,
+		r == RoundHalfEven && (half > 0 || half == 0 && quotient.Bit(0) == 1):
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient.Int64()
+}
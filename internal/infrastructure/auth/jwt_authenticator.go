@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"order-service/internal/domain/auth"
+)
+
+// clockSkew is how far the clocks of the token issuer and this service may drift apart.
+const clockSkew = 30 * time.Second
+
+// JWTConfig sets the tokens a JWTAuthenticator accepts. HMACSecret enables HS256 and Keys, the RSA
+// public keys by key ID, enable RS256. Issuer and Audience are only checked when set.
+type JWTConfig struct {
+	HMACSecret []byte
+	Keys       map[string]*rsa.PublicKey
+	Issuer     string
+	Audience   string
+}
+
+// JWTAuthenticator verifies the bearer tokens of requests. Tokens must be signed with HS256 or
+// RS256, expire, and name their subject; the customer_id and roles claims make up the principal.
+type JWTAuthenticator struct {
+	config JWTConfig
+}
+
+func NewJWTAuthenticator(config JWTConfig) (*JWTAuthenticator, error) {
+	if len(config.HMACSecret) == 0 && len(config.Keys) == 0 {
+		return nil, errors.New("JWT authentication needs an HS256 secret or RS256 keys")
+	}
+	return &JWTAuthenticator{config: config}, nil
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type tokenClaims struct {
+	Subject    string   `json:"sub"`
+	Issuer     string   `json:"iss"`
+	Audience   audience `json:"aud"`
+	ExpiresAt  *float64 `json:"exp"`
+	NotBefore  *float64 `json:"nbf"`
+	CustomerID string   `json:"customer_id"`
+	Roles      []string `json:"roles"`
+}
+
+// audience is the aud claim, which is either one string or a list of them.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.New("aud must be a string or a list of strings")
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, aud := range a {
+		if aud == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (auth.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return auth.Principal{}, invalidToken("malformed token")
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return auth.Principal{}, invalidToken("malformed header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return auth.Principal{}, invalidToken("malformed signature")
+	}
+	if err := a.verify(header, parts[0]+"."+parts[1], signature); err != nil {
+		return auth.Principal{}, err
+	}
+
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return auth.Principal{}, invalidToken("malformed claims")
+	}
+	if err := a.validate(claims); err != nil {
+		return auth.Principal{}, err
+	}
+
+	principal := auth.Principal{Subject: claims.Subject, CustomerID: claims.CustomerID}
+	for _, role := range claims.Roles {
+		principal.Roles = append(principal.Roles, auth.Role(role))
+	}
+	return principal, nil
+}
+
+// verify checks the signature with the algorithm named by the token, provided that algorithm is
+// configured, so that an RSA public key is never used as an HMAC secret.
+func (a *JWTAuthenticator) verify(header tokenHeader, signed string, signature []byte) error {
+	switch header.Algorithm {
+	case "HS256":
+		if len(a.config.HMACSecret) == 0 {
+			return invalidToken("HS256 tokens are not accepted")
+		}
+		mac := hmac.New(sha256.New, a.config.HMACSecret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return invalidToken("invalid signature")
+		}
+		return nil
+	case "RS256":
+		key, err := a.rsaKey(header.KeyID)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return invalidToken("invalid signature")
+		}
+		return nil
+	default:
+		return invalidToken(fmt.Sprintf("unsupported algorithm %q", header.Algorithm))
+	}
+}
+
+// rsaKey finds the key a token was signed with. Tokens without a key ID are accepted only when there
+// is a single key.
+func (a *JWTAuthenticator) rsaKey(keyID string) (*rsa.PublicKey, error) {
+	if keyID == "" && len(a.config.Keys) == 1 {
+		for _, key := range a.config.Keys {
+			return key, nil
+		}
+	}
+	key, ok := a.config.Keys[keyID]
+	if !ok {
+		return nil, invalidToken(fmt.Sprintf("unknown key %q", keyID))
+	}
+	return key, nil
+}
+
+func (a *JWTAuthenticator) validate(claims tokenClaims) error {
+	now := time.Now()
+	switch {
+	case claims.Subject == "":
+		return invalidToken("missing subject")
+	case claims.ExpiresAt == nil:
+		return invalidToken("missing expiration")
+	case now.Add(-clockSkew).After(numericDate(*claims.ExpiresAt)):
+		return invalidToken("token expired")
+	case claims.NotBefore != nil && now.Add(clockSkew).Before(numericDate(*claims.NotBefore)):
+		return invalidToken("token not valid yet")
+	case a.config.Issuer != "" && claims.Issuer != a.config.Issuer:
+		return invalidToken("unexpected issuer")
+	case a.config.Audience != "" && !claims.Audience.contains(a.config.Audience):
+		return invalidToken("unexpected audience")
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// numericDate converts a JWT NumericDate, in seconds since the epoch, to a time.
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+func invalidToken(reason string) error {
+	return fmt.Errorf("%w: %s", auth.ErrUnauthenticated, reason)
+}
@@ -0,0 +1,123 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainauth "order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+	"order-service/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAPIKeys keeps API keys by hash and counts the recorded uses.
+type memoryAPIKeys struct {
+	keys    map[string]*domainauth.APIKey
+	touches int
+}
+
+func (m *memoryAPIKeys) Save(ctx context.Context, key *domainauth.APIKey) error {
+	m.keys[key.Hash] = key
+	return nil
+}
+
+func (m *memoryAPIKeys) FindByID(ctx context.Context, id string) (*domainauth.APIKey, error) {
+	for _, key := range m.keys {
+		if key.ID == id {
+			return key, nil
+		}
+	}
+	return nil, repository.ErrAPIKeyNotFound
+}
+
+func (m *memoryAPIKeys) FindByHash(ctx context.Context, hash string) (*domainauth.APIKey, error) {
+	key, ok := m.keys[hash]
+	if !ok {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (m *memoryAPIKeys) List(ctx context.Context) ([]domainauth.APIKey, error) {
+	return nil, nil
+}
+
+func (m *memoryAPIKeys) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	m.touches++
+	key, err := m.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	key.LastUsedAt = &usedAt
+	return nil
+}
+
+func issueKey(t *testing.T, keys *memoryAPIKeys, scopes ...domainauth.Scope) (*domainauth.APIKey, string) {
+	key, secret, err := domainauth.NewAPIKey("key-1", "nightly export", scopes, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, keys.Save(context.Background(), key))
+	return key, secret
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	keys := &memoryAPIKeys{keys: map[string]*domainauth.APIKey{}}
+	_, secret := issueKey(t, keys, domainauth.ScopeOrdersRead)
+	authenticator := auth.NewAPIKeyAuthenticator(keys)
+
+	principal, err := authenticator.Authenticate(context.Background(), secret)
+
+	require.NoError(t, err)
+	assert.Equal(t, "api-key:key-1", principal.Subject)
+	assert.True(t, principal.CanActFor("any-customer"))
+	assert.True(t, principal.Allows(domainauth.ScopeOrdersRead))
+	assert.False(t, principal.Allows(domainauth.ScopeOrdersCancel))
+	assert.False(t, principal.HasRole(domainauth.RoleSupport))
+}
+
+func TestAPIKeyAuthenticator_RecordsLastUse(t *testing.T) {
+	keys := &memoryAPIKeys{keys: map[string]*domainauth.APIKey{}}
+	key, secret := issueKey(t, keys, domainauth.ScopeOrdersRead)
+	authenticator := auth.NewAPIKeyAuthenticator(keys)
+
+	for i := 0; i < 3; i++ {
+		_, err := authenticator.Authenticate(context.Background(), secret)
+		require.NoError(t, err)
+	}
+
+	require.NotNil(t, key.LastUsedAt)
+	assert.Equal(t, 1, keys.touches, "uses within a minute are recorded once")
+}
+
+func TestAPIKeyAuthenticator_Rejects(t *testing.T) {
+	keys := &memoryAPIKeys{keys: map[string]*domainauth.APIKey{}}
+	key, secret := issueKey(t, keys, domainauth.ScopeOrdersRead)
+	authenticator := auth.NewAPIKeyAuthenticator(keys)
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := authenticator.Authenticate(context.Background(), domainauth.APIKeyPrefix+"unknown")
+		assert.ErrorIs(t, err, domainauth.ErrUnauthenticated)
+	})
+
+	t.Run("malformed key", func(t *testing.T) {
+		_, err := authenticator.Authenticate(context.Background(), "not-a-key")
+		assert.ErrorIs(t, err, domainauth.ErrUnauthenticated)
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		key.ExpireBy(time.Now().Add(-time.Second))
+		defer func() { key.ExpiresAt = nil }()
+
+		_, err := authenticator.Authenticate(context.Background(), secret)
+		assert.ErrorIs(t, err, domainauth.ErrUnauthenticated)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		key.Revoke(time.Now())
+
+		_, err := authenticator.Authenticate(context.Background(), secret)
+		assert.ErrorIs(t, err, domainauth.ErrUnauthenticated)
+	})
+}
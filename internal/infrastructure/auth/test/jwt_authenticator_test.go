@@ -0,0 +1,168 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainauth "order-service/internal/domain/auth"
+	"order-service/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var hmacSecret = []byte("test-secret-that-is-long-enough-for-hs256")
+
+func encodeSegment(t *testing.T, v any) string {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret []byte, claims map[string]any) string {
+	signed := encodeSegment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, keyID string, claims map[string]any) string {
+	signed := encodeSegment(t, map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// writeJWKS generates an RSA key and writes its public part to a JWKS file.
+func writeJWKS(t *testing.T, keyID string) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := map[string]any{"keys": []map[string]string{
+		{"kty": "EC", "kid": "ignored", "crv": "P-256"},
+		{
+			"kty": "RSA", "kid": keyID, "use": "sig", "alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		},
+	}}
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return key, path
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":         "user-1",
+		"iss":         "https://auth.example.com",
+		"aud":         []string{"order-service", "billing"},
+		"exp":         time.Now().Add(time.Hour).Unix(),
+		"customer_id": "c1",
+		"roles":       []string{"support"},
+	}
+}
+
+func TestJWTAuthenticator_HS256(t *testing.T) {
+	authenticator, err := auth.NewJWTAuthenticator(auth.JWTConfig{HMACSecret: hmacSecret, Issuer: "https://auth.example.com", Audience: "order-service"})
+	require.NoError(t, err)
+
+	principal, err := authenticator.Authenticate(context.Background(), signHS256(t, hmacSecret, validClaims()))
+
+	require.NoError(t, err)
+	assert.Equal(t, domainauth.Principal{Subject: "user-1", CustomerID: "c1", Roles: []domainauth.Role{domainauth.RoleSupport}}, principal)
+}
+
+func TestJWTAuthenticator_RS256(t *testing.T) {
+	key, path := writeJWKS(t, "2024-05")
+	keys, err := auth.LoadJWKS(path)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	authenticator, err := auth.NewJWTAuthenticator(auth.JWTConfig{Keys: keys})
+	require.NoError(t, err)
+
+	principal, err := authenticator.Authenticate(context.Background(), signRS256(t, key, "2024-05", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", principal.Subject)
+
+	_, err = authenticator.Authenticate(context.Background(), signRS256(t, key, "2023-01", validClaims()))
+	assert.ErrorIs(t, err, domainauth.ErrUnauthenticated, "unknown key ID")
+}
+
+func TestJWTAuthenticator_Rejects(t *testing.T) {
+	key, path := writeJWKS(t, "k1")
+	keys, err := auth.LoadJWKS(path)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsaOnly, err := auth.NewJWTAuthenticator(auth.JWTConfig{Keys: keys})
+	require.NoError(t, err)
+	hmacOnly, err := auth.NewJWTAuthenticator(auth.JWTConfig{HMACSecret: hmacSecret, Issuer: "https://auth.example.com", Audience: "order-service"})
+	require.NoError(t, err)
+
+	with := func(changes map[string]any) map[string]any {
+		claims := validClaims()
+		for name, value := range changes {
+			if value == nil {
+				delete(claims, name)
+				continue
+			}
+			claims[name] = value
+		}
+		return claims
+	}
+	publicKeyBytes := key.PublicKey.N.Bytes()
+	unsigned := encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, validClaims()) + "."
+
+	tests := []struct {
+		name          string
+		authenticator *auth.JWTAuthenticator
+		token         string
+	}{
+		{"malformed", hmacOnly, "not-a-token"},
+		{"alg none", hmacOnly, unsigned},
+		{"wrong secret", hmacOnly, signHS256(t, []byte("another-secret"), validClaims())},
+		{"HS256 signed with the RSA public key", rsaOnly, signHS256(t, publicKeyBytes, validClaims())},
+		{"RS256 signed with another key", rsaOnly, signRS256(t, otherKey, "k1", validClaims())},
+		{"expired", hmacOnly, signHS256(t, hmacSecret, with(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()}))},
+		{"without expiration", hmacOnly, signHS256(t, hmacSecret, with(map[string]any{"exp": nil}))},
+		{"not valid yet", hmacOnly, signHS256(t, hmacSecret, with(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()}))},
+		{"without subject", hmacOnly, signHS256(t, hmacSecret, with(map[string]any{"sub": nil}))},
+		{"other issuer", hmacOnly, signHS256(t, hmacSecret, with(map[string]any{"iss": "https://evil.example.com"}))},
+		{"other audience", hmacOnly, signHS256(t, hmacSecret, with(map[string]any{"aud": "billing"}))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.authenticator.Authenticate(context.Background(), tt.token)
+			assert.ErrorIs(t, err, domainauth.ErrUnauthenticated)
+		})
+	}
+}
+
+func TestNewJWTAuthenticator_RequiresKeys(t *testing.T) {
+	_, err := auth.NewJWTAuthenticator(auth.JWTConfig{})
+	assert.Error(t, err)
+}
+
+func TestParseJWKS_InvalidKey(t *testing.T) {
+	_, err := auth.ParseJWKS([]byte(`{"keys": [{"kty": "RSA", "kid": "k1", "n": "", "e": "AQAB"}]}`))
+	assert.Error(t, err)
+
+	_, err = auth.ParseJWKS([]byte(`{"keys": []}`))
+	assert.Error(t, err)
+}
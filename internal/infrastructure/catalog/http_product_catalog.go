@@ -0,0 +1,68 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"order-service/internal/domain/catalog"
+)
+
+const maxCatalogResponseBytes = 1 << 20
+
+// HTTPProductCatalog queries a catalog service with GET {baseURL}/products?id=a&id=b, which answers
+// with {"products": [...]} as described by productRecord, leaving unknown IDs out.
+type HTTPProductCatalog struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHTTPProductCatalog(baseURL string, timeout time.Duration) *HTTPProductCatalog {
+	return &HTTPProductCatalog{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *HTTPProductCatalog) Products(ctx context.Context, productIDs []string) (map[string]catalog.Product, error) {
+	found := make(map[string]catalog.Product, len(productIDs))
+	if len(productIDs) == 0 {
+		return found, nil
+	}
+
+	query := url.Values{"id": productIDs}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/products?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", catalog.ErrCatalogUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: catalog responded with status %d", catalog.ErrCatalogUnavailable, resp.StatusCode)
+	}
+
+	var list productList
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCatalogResponseBytes)).Decode(&list); err != nil {
+		return nil, fmt.Errorf("%w: invalid catalog response: %v", catalog.ErrCatalogUnavailable, err)
+	}
+
+	for _, record := range list.Products {
+		product, err := record.toProduct()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", catalog.ErrCatalogUnavailable, err)
+		}
+		found[product.ID] = product
+	}
+	return found, nil
+}
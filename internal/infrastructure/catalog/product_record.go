@@ -0,0 +1,45 @@
+package catalog
+
+import (
+	"fmt"
+
+	"order-service/internal/domain/catalog"
+	"order-service/internal/domain/entity"
+)
+
+// productRecord is how products are written in catalog files and HTTP responses, e.g.
+// {"id": "123459", "name": "Product A", "category": "books", "price": "19.99", "currency": "BRL",
+// "available": true}. Products are available unless "available" is false.
+type productRecord struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	Price     string `json:"price"`
+	Currency  string `json:"currency"`
+	Available *bool  `json:"available"`
+}
+
+type productList struct {
+	Products []productRecord `json:"products"`
+}
+
+func (r productRecord) toProduct() (catalog.Product, error) {
+	if r.ID == "" {
+		return catalog.Product{}, fmt.Errorf("catalog product %q has no id", r.Name)
+	}
+	if err := entity.ValidateCurrency(r.Currency); err != nil {
+		return catalog.Product{}, fmt.Errorf("catalog product %s: %w", r.ID, err)
+	}
+	price, err := entity.ParseMoney(r.Price, r.Currency)
+	if err != nil {
+		return catalog.Product{}, fmt.Errorf("catalog product %s: %w", r.ID, err)
+	}
+
+	return catalog.Product{
+		ID:        r.ID,
+		Name:      r.Name,
+		Category:  r.Category,
+		Price:     price,
+		Available: r.Available == nil || *r.Available,
+	}, nil
+}
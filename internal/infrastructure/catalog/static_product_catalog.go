@@ -0,0 +1,57 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"order-service/internal/domain/catalog"
+)
+
+// StaticProductCatalog serves products from memory, typically loaded from a JSON file.
+type StaticProductCatalog struct {
+	products map[string]catalog.Product
+}
+
+func NewStaticProductCatalog(products []catalog.Product) *StaticProductCatalog {
+	c := &StaticProductCatalog{products: make(map[string]catalog.Product, len(products))}
+	for _, product := range products {
+		c.products[product.ID] = product
+	}
+	return c
+}
+
+// LoadStaticProductCatalog reads a file in the format {"products": [...]} described by productRecord.
+func LoadStaticProductCatalog(path string) (*StaticProductCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read product catalog: %w", err)
+	}
+
+	var file productList
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse product catalog: %w", err)
+	}
+
+	products := make([]catalog.Product, 0, len(file.Products))
+	for _, record := range file.Products {
+		product, err := record.toProduct()
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return NewStaticProductCatalog(products), nil
+}
+
+func (c *StaticProductCatalog) Products(ctx context.Context, productIDs []string) (map[string]catalog.Product, error) {
+	found := make(map[string]catalog.Product, len(productIDs))
+	for _, id := range productIDs {
+		if product, ok := c.products[id]; ok {
+			found[id] = product
+		}
+	}
+	return found, nil
+}
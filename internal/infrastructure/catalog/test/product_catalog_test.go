@@ -0,0 +1,75 @@
+package catalog_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domaincatalog "order-service/internal/domain/catalog"
+	"order-service/internal/domain/entity"
+	"order-service/internal/infrastructure/catalog"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const products = `{"products": [
+	{"id": "123459", "name": "Product A", "category": "books", "price": "19.99", "currency": "BRL"},
+	{"id": "456893", "name": "Product B", "price": "49.99", "currency": "BRL", "available": false}
+]}`
+
+func TestStaticProductCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	require.NoError(t, os.WriteFile(path, []byte(products), 0o600))
+
+	productCatalog, err := catalog.LoadStaticProductCatalog(path)
+	require.NoError(t, err)
+
+	found, err := productCatalog.Products(context.Background(), []string{"123459", "456893", "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domaincatalog.Product{
+		"123459": {ID: "123459", Name: "Product A", Category: "books", Price: entity.NewMoney(1999, "BRL"), Available: true},
+		"456893": {ID: "456893", Name: "Product B", Price: entity.NewMoney(4999, "BRL"), Available: false},
+	}, found)
+}
+
+func TestStaticProductCatalog_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"products": [{"id": "1", "price": "1.999", "currency": "BRL"}]}`), 0o600))
+
+	_, err := catalog.LoadStaticProductCatalog(path)
+	assert.Error(t, err)
+}
+
+func TestHTTPProductCatalog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/products", r.URL.Path)
+		assert.Equal(t, []string{"123459", "a,b"}, r.URL.Query()["id"])
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(products))
+	}))
+	defer server.Close()
+
+	found, err := catalog.NewHTTPProductCatalog(server.URL+"/", time.Second).Products(context.Background(), []string{"123459", "a,b"})
+	require.NoError(t, err)
+	assert.Equal(t, entity.NewMoney(1999, "BRL"), found["123459"].Price)
+	assert.True(t, found["123459"].Available)
+}
+
+func TestHTTPProductCatalog_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := catalog.NewHTTPProductCatalog(server.URL, time.Second).Products(context.Background(), []string{"123459"})
+	assert.ErrorIs(t, err, domaincatalog.ErrCatalogUnavailable)
+
+	server.Close()
+	_, err = catalog.NewHTTPProductCatalog(server.URL, time.Second).Products(context.Background(), []string{"123459"})
+	assert.ErrorIs(t, err, domaincatalog.ErrCatalogUnavailable)
+}
@@ -0,0 +1,37 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrInvalidMessage = errors.New("invalid message")
+
+type Handler interface {
+	Handle(ctx context.Context, body []byte) error
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as not worth retrying, so the message goes straight to the dead-letter queue.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent) || errors.Is(err, ErrInvalidMessage)
+}
@@ -0,0 +1,372 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	DefaultMaxRetries = 5
+	DefaultPrefetch   = 10
+
+	retryCountHeader = "x-retry-count"
+	baseRetryDelay   = 500 * time.Millisecond
+	maxRetryDelay    = 30 * time.Second
+
+	baseReconnectDelay = time.Second
+	maxReconnectDelay  = 30 * time.Second
+)
+
+// Dialer opens a new connection to the broker. The consumer dials its own connection and redials it
+// after the broker closes it.
+type Dialer func() (*amqp091.Connection, error)
+
+// RabbitMQConsumer consumes a queue on its own connection. When the connection or channel drops it
+// reconnects, declares its queues again and resumes consuming.
+type RabbitMQConsumer struct {
+	mu         sync.Mutex
+	dial       Dialer
+	connection *amqp091.Connection
+	channel    *amqp091.Channel
+	closed     bool
+	queueName  string
+	maxRetries int
+	prefetch   int
+}
+
+// NewRabbitMQConsumer connects and declares the queue along with its retry and dead-letter queues, so
+// a broker that cannot be reached or rejects the topology fails at startup.
+func NewRabbitMQConsumer(dial Dialer, queueName string, maxRetries, prefetch int) (*RabbitMQConsumer, error) {
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	if prefetch <= 0 {
+		prefetch = DefaultPrefetch
+	}
+
+	consumer := &RabbitMQConsumer{
+		dial:       dial,
+		queueName:  queueName,
+		maxRetries: maxRetries,
+		prefetch:   prefetch,
+	}
+	if err := consumer.open(); err != nil {
+		return nil, err
+	}
+	return consumer, nil
+}
+
+// open dials a new connection, replacing a closed one, and opens a channel with the queues declared.
+func (c *RabbitMQConsumer) open() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return errors.New("consumer is closed")
+	}
+	if c.connection == nil || c.connection.IsClosed() {
+		conn, err := c.dial()
+		if err != nil {
+			return err
+		}
+		c.connection = conn
+	}
+
+	channel, err := c.connection.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open a channel: %w", err)
+	}
+	if err := c.declare(channel); err != nil {
+		channel.Close()
+		return err
+	}
+
+	c.channel = channel
+	return nil
+}
+
+func (c *RabbitMQConsumer) declare(channel *amqp091.Channel) error {
+	deadLetterExchange := c.queueName + ".dlx"
+	deadLetterQueue := c.queueName + ".dlq"
+
+	err := channel.ExchangeDeclare(
+		deadLetterExchange,
+		"fanout",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+
+	_, err = channel.QueueDeclare(
+		deadLetterQueue,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+
+	err = channel.QueueBind(
+		deadLetterQueue,
+		"",
+		deadLetterExchange,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
+	_, err = channel.QueueDeclare(
+		c.queueName,
+		true,
+		false,
+		false,
+		false,
+		amqp091.Table{"x-dead-letter-exchange": deadLetterExchange},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare a queue: %w", err)
+	}
+
+	// Retries wait in a retry queue per delay until the queue TTL expires and are then dead-lettered
+	// back to the main queue, so a failing message never holds up the consumer. The broker only expires
+	// messages at the head of a queue, so every message in a retry queue must share its delay.
+	for retries := 1; retries <= c.maxRetries; retries++ {
+		delay := retryDelay(retries)
+		if retries > 1 && delay == retryDelay(retries-1) {
+			continue
+		}
+		_, err = channel.QueueDeclare(
+			c.retryQueue(delay),
+			true,
+			false,
+			false,
+			false,
+			amqp091.Table{
+				"x-message-ttl":             delay.Milliseconds(),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": c.queueName,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare retry queue: %w", err)
+		}
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		return fmt.Errorf("failed to put channel in confirm mode: %w", err)
+	}
+
+	if err := channel.Qos(c.prefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set channel qos: %w", err)
+	}
+	return nil
+}
+
+// Start consumes until ctx ends or the consumer is closed, reconnecting with a growing delay whenever
+// the connection or channel is lost.
+func (c *RabbitMQConsumer) Start(ctx context.Context, handler Handler) error {
+	delay := baseReconnectDelay
+	for {
+		consumed, err := c.consume(ctx, handler)
+		if ctx.Err() != nil || c.isClosed() {
+			return nil
+		}
+		if consumed {
+			delay = baseReconnectDelay
+		}
+
+		log.Printf("Consumer of %s lost the broker, reconnecting in %s: %v", c.queueName, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+
+		if err := c.open(); err != nil {
+			log.Printf("Error reconnecting consumer of %s: %v", c.queueName, err)
+		}
+	}
+}
+
+// consume handles deliveries until they stop, reporting whether consuming started at all.
+func (c *RabbitMQConsumer) consume(ctx context.Context, handler Handler) (bool, error) {
+	c.mu.Lock()
+	channel := c.channel
+	c.mu.Unlock()
+	if channel == nil || channel.IsClosed() {
+		return false, errors.New("channel is closed")
+	}
+
+	deliveries, err := channel.Consume(
+		c.queueName,
+		"",
+		false,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return true, errors.New("delivery channel closed")
+			}
+			c.dispatch(ctx, channel, handler, delivery)
+		}
+	}
+}
+
+func (c *RabbitMQConsumer) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *RabbitMQConsumer) dispatch(ctx context.Context, channel *amqp091.Channel, handler Handler, delivery amqp091.Delivery) {
+	err := handler.Handle(ctx, delivery.Body)
+	if err == nil {
+		c.ack(delivery)
+		return
+	}
+
+	if IsPermanent(err) {
+		log.Printf("Dead-lettering message from %s: %v", c.queueName, err)
+		c.reject(delivery)
+		return
+	}
+
+	retries := retryCount(delivery.Headers)
+	if retries >= c.maxRetries {
+		log.Printf("Dead-lettering message from %s after %d retries: %v", c.queueName, retries, err)
+		c.reject(delivery)
+		return
+	}
+
+	if err := c.retry(ctx, channel, delivery, retries+1); err != nil {
+		log.Printf("Error scheduling retry for message from %s, requeueing: %v", c.queueName, err)
+		if err := delivery.Nack(false, true); err != nil {
+			log.Printf("Error requeueing message: %v", err)
+		}
+		return
+	}
+
+	log.Printf("Retrying message from %s in %s (attempt %d): %v", c.queueName, retryDelay(retries+1), retries+1, err)
+	c.ack(delivery)
+}
+
+// retryQueue names the retry queue that holds messages for delay, such as "<queue>.retry.500ms".
+func (c *RabbitMQConsumer) retryQueue(delay time.Duration) string {
+	return c.queueName + ".retry." + delay.String()
+}
+
+// retry parks a copy of the delivery in the retry queue of its delay and waits for the broker to confirm it, so
+// the original is only acknowledged once the copy is safe.
+func (c *RabbitMQConsumer) retry(ctx context.Context, channel *amqp091.Channel, delivery amqp091.Delivery, retries int) error {
+	headers := amqp091.Table{}
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+	headers[retryCountHeader] = int32(retries)
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		"",
+		c.retryQueue(retryDelay(retries)),
+		false,
+		false,
+		amqp091.Publishing{
+			Headers:      headers,
+			ContentType:  delivery.ContentType,
+			DeliveryMode: amqp091.Persistent,
+			MessageId:    delivery.MessageId,
+			Body:         delivery.Body,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return errors.New("retry was not confirmed by the broker")
+	}
+	return nil
+}
+
+func (c *RabbitMQConsumer) ack(delivery amqp091.Delivery) {
+	if err := delivery.Ack(false); err != nil {
+		log.Printf("Error acknowledging message: %v", err)
+	}
+}
+
+func (c *RabbitMQConsumer) reject(delivery amqp091.Delivery) {
+	if err := delivery.Reject(false); err != nil {
+		log.Printf("Error rejecting message: %v", err)
+	}
+}
+
+// Close stops the consumer and closes its connection.
+func (c *RabbitMQConsumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	if c.connection == nil || c.connection.IsClosed() {
+		return nil
+	}
+	if err := c.connection.Close(); err != nil {
+		return fmt.Errorf("failed to close connection: %w", err)
+	}
+	return nil
+}
+
+func retryDelay(retries int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < retries; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
+
+func retryCount(headers amqp091.Table) int {
+	switch value := headers[retryCountHeader].(type) {
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case int:
+		return value
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,44 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	"order-service/internal/domain/repository"
+)
+
+type ProcessingResultHandler struct {
+	updateOrderStatusUseCase usecase.UpdateOrderStatusUseCase
+}
+
+func NewProcessingResultHandler(updateOrderStatusUseCase usecase.UpdateOrderStatusUseCase) *ProcessingResultHandler {
+	return &ProcessingResultHandler{
+		updateOrderStatusUseCase: updateOrderStatusUseCase,
+	}
+}
+
+func (h *ProcessingResultHandler) Handle(ctx context.Context, body []byte) error {
+	var input dtos.OrderStatusInput
+	if err := json.Unmarshal(body, &input); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	if input.OrderID == "" || input.Status == "" {
+		return fmt.Errorf("%w: order_id and status are required", ErrInvalidMessage)
+	}
+
+	output, err := h.updateOrderStatusUseCase.Execute(ctx, input)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidStatusUpdate) || errors.Is(err, repository.ErrNotFound) {
+			return Permanent(err)
+		}
+		return err
+	}
+
+	log.Printf("Order %s moved to %s by processing result", output.ID, output.Status)
+	return nil
+}
@@ -0,0 +1,82 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	"order-service/internal/domain/repository"
+	"order-service/internal/infrastructure/consumer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockUpdateOrderStatusUseCase struct {
+	output dtos.OrderOutput
+	err    error
+	input  dtos.OrderStatusInput
+}
+
+func (m *mockUpdateOrderStatusUseCase) Execute(ctx context.Context, input dtos.OrderStatusInput) (dtos.OrderOutput, error) {
+	m.input = input
+	return m.output, m.err
+}
+
+func TestProcessingResultHandler_Success(t *testing.T) {
+	mockUseCase := &mockUpdateOrderStatusUseCase{
+		output: dtos.OrderOutput{ID: "123", Status: "completed"},
+	}
+	handler := consumer.NewProcessingResultHandler(mockUseCase)
+
+	err := handler.Handle(context.Background(), []byte(`{"order_id": "123", "status": "completed"}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "123", mockUseCase.input.OrderID)
+	assert.Equal(t, "completed", mockUseCase.input.Status)
+}
+
+func TestProcessingResultHandler_InvalidMessages(t *testing.T) {
+	bodies := map[string]string{
+		"malformed json": `{"order_id": `,
+		"missing id":     `{"status": "completed"}`,
+		"missing status": `{"order_id": "123"}`,
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			handler := consumer.NewProcessingResultHandler(&mockUpdateOrderStatusUseCase{})
+
+			err := handler.Handle(context.Background(), []byte(body))
+
+			assert.ErrorIs(t, err, consumer.ErrInvalidMessage)
+			assert.True(t, consumer.IsPermanent(err))
+		})
+	}
+}
+
+func TestProcessingResultHandler_UseCaseErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{name: "invalid transition", err: fmt.Errorf("%w: cannot change status", usecase.ErrInvalidStatusUpdate), permanent: true},
+		{name: "order not found", err: repository.ErrNotFound, permanent: true},
+		{name: "out of order", err: fmt.Errorf("%w: order is still pending", usecase.ErrStatusUpdateOutOfOrder), permanent: false},
+		{name: "database error", err: errors.New("database error"), permanent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := consumer.NewProcessingResultHandler(&mockUpdateOrderStatusUseCase{err: tt.err})
+
+			err := handler.Handle(context.Background(), []byte(`{"order_id": "123", "status": "completed"}`))
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.permanent, consumer.IsPermanent(err))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+
+	"github.com/lib/pq"
+)
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at, last_used_at`
+
+type APIKeyRepositorySql struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepositorySql(db *sql.DB) *APIKeyRepositorySql {
+	return &APIKeyRepositorySql{db: db}
+}
+
+// Save inserts the key or updates its expiry and revocation. The time it was last used is only
+// written by TouchLastUsed, so saving a key read earlier does not move it back.
+func (r *APIKeyRepositorySql) Save(ctx context.Context, key *auth.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, scopes = EXCLUDED.scopes, expires_at = EXCLUDED.expires_at, revoked_at = EXCLUDED.revoked_at
+	`
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	_, err := r.db.ExecContext(ctx, query, key.ID, key.Name, key.Prefix, key.Hash, pq.Array(scopes), key.CreatedAt, key.ExpiresAt, key.RevokedAt)
+	return err
+}
+
+func (r *APIKeyRepositorySql) FindByID(ctx context.Context, id string) (*auth.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", repository.ErrAPIKeyNotFound, id)
+	}
+	return key, err
+}
+
+func (r *APIKeyRepositorySql) FindByHash(ctx context.Context, hash string) (*auth.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+func (r *APIKeyRepositorySql) List(ctx context.Context) ([]auth.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC, id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []auth.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+// TouchLastUsed never moves the time a key was last used back, as concurrent requests may record
+// their times out of order.
+func (r *APIKeyRepositorySql) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2)
+	`
+	_, err := r.db.ExecContext(ctx, query, id, usedAt)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row rowScanner) (*auth.APIKey, error) {
+	var key auth.APIKey
+	var scopes []string
+	var expiresAt, revokedAt, lastUsedAt sql.NullTime
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, pq.Array(&scopes), &key.CreatedAt, &expiresAt, &revokedAt, &lastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, auth.Scope(scope))
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	return &key, nil
+}
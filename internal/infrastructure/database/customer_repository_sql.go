@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+)
+
+type CustomerRepositorySql struct {
+	db *sql.DB
+}
+
+func NewCustomerRepositorySql(db *sql.DB) *CustomerRepositorySql {
+	return &CustomerRepositorySql{db: db}
+}
+
+func (r *CustomerRepositorySql) Save(ctx context.Context, customer *entity.Customer) error {
+	query := `
+		INSERT INTO customers (id, name, normalized_name, email, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, normalized_name = EXCLUDED.normalized_name, email = EXCLUDED.email, updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.ExecContext(ctx, query, customer.ID, customer.Name, customer.NormalizedName(), customer.Email, customer.CreatedAt, customer.UpdatedAt)
+	return err
+}
+
+func (r *CustomerRepositorySql) FindByID(ctx context.Context, id string) (*entity.Customer, error) {
+	query := `
+		SELECT id, name, COALESCE(email, ''), created_at, updated_at
+		FROM customers
+		WHERE id = $1
+	`
+	var customer entity.Customer
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&customer.ID, &customer.Name, &customer.Email, &customer.CreatedAt, &customer.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", repository.ErrCustomerNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (r *CustomerRepositorySql) List(ctx context.Context, query repository.CustomerQuery) (repository.CustomerPage, error) {
+	where := ""
+	var args []interface{}
+	if name := entity.NormalizeCustomerName(query.Name); name != "" {
+		args = append(args, "%"+name+"%")
+		where = " WHERE normalized_name LIKE $1"
+	}
+
+	countQuery := `SELECT COUNT(*) FROM customers` + where
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return repository.CustomerPage{}, err
+	}
+
+	customerQuery := fmt.Sprintf(`
+		SELECT id, name, COALESCE(email, ''), created_at, updated_at
+		FROM customers%s
+		ORDER BY normalized_name, id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	args = append(args, query.Size, query.Offset())
+
+	rows, err := r.db.QueryContext(ctx, customerQuery, args...)
+	if err != nil {
+		return repository.CustomerPage{}, err
+	}
+	defer rows.Close()
+
+	page := repository.CustomerPage{Customers: []entity.Customer{}, Total: total}
+	for rows.Next() {
+		var customer entity.Customer
+		if err := rows.Scan(&customer.ID, &customer.Name, &customer.Email, &customer.CreatedAt, &customer.UpdatedAt); err != nil {
+			return repository.CustomerPage{}, err
+		}
+		page.Customers = append(page.Customers, customer)
+	}
+
+	return page, rows.Err()
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"order-service/internal/domain/repository"
+)
+
+type IdempotencyRepositorySql struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepositorySql(db *sql.DB) *IdempotencyRepositorySql {
+	return &IdempotencyRepositorySql{db: db}
+}
+
+func (r *IdempotencyRepositorySql) Reserve(ctx context.Context, record repository.IdempotencyRecord) (repository.IdempotencyRecord, bool, error) {
+	reserveQuery := `
+		INSERT INTO idempotency_keys (owner, key, request_hash, created_at, expires_at, locked_until)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (owner, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response = NULL, created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			OR (idempotency_keys.response IS NULL AND idempotency_keys.locked_until <= EXCLUDED.created_at)
+		RETURNING key
+	`
+	var key string
+	err := r.db.QueryRowContext(ctx, reserveQuery, record.Owner, record.Key, record.RequestHash, record.CreatedAt, record.ExpiresAt, record.LockedUntil).Scan(&key)
+	if err == nil {
+		return record, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return repository.IdempotencyRecord{}, false, err
+	}
+
+	findQuery := `
+		SELECT owner, key, request_hash, response, created_at, expires_at, COALESCE(locked_until, created_at)
+		FROM idempotency_keys
+		WHERE owner = $1 AND key = $2
+	`
+	var existing repository.IdempotencyRecord
+	err = r.db.QueryRowContext(ctx, findQuery, record.Owner, record.Key).Scan(
+		&existing.Owner, &existing.Key, &existing.RequestHash, &existing.Response, &existing.CreatedAt, &existing.ExpiresAt, &existing.LockedUntil,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The live record was released between both queries, so the key is free again.
+		return r.Reserve(ctx, record)
+	}
+	if err != nil {
+		return repository.IdempotencyRecord{}, false, err
+	}
+
+	return existing, false, nil
+}
+
+func (r *IdempotencyRepositorySql) Complete(ctx context.Context, owner, key string, response []byte) error {
+	query := `UPDATE idempotency_keys SET response = $3, locked_until = NULL WHERE owner = $1 AND key = $2`
+	_, err := r.db.ExecContext(ctx, query, owner, key, response)
+	return err
+}
+
+func (r *IdempotencyRepositorySql) Release(ctx context.Context, owner, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE owner = $1 AND key = $2 AND response IS NULL`
+	_, err := r.db.ExecContext(ctx, query, owner, key)
+	return err
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"order-service/internal/domain/inventory"
+
+	"github.com/lib/pq"
+)
+
+// InventoryServiceSql keeps stock in the stock table, where available stock is on_hand - reserved,
+// and the quantities held for each order in stock_reservations. Called as a side effect of saving an
+// order it works in the transaction of the save.
+type InventoryServiceSql struct {
+	db *sql.DB
+}
+
+func NewInventoryServiceSql(db *sql.DB) *InventoryServiceSql {
+	return &InventoryServiceSql{db: db}
+}
+
+func (s *InventoryServiceSql) Reserve(ctx context.Context, orderID string, reservations []inventory.Reservation) error {
+	return s.setReservations(ctx, orderID, reservations)
+}
+
+func (s *InventoryServiceSql) Adjust(ctx context.Context, orderID string, reservations []inventory.Reservation) error {
+	return s.setReservations(ctx, orderID, reservations)
+}
+
+func (s *InventoryServiceSql) Release(ctx context.Context, orderID string) error {
+	return s.setReservations(ctx, orderID, nil)
+}
+
+// Consume takes the reserved quantities of an order off hand and drops its reservations in one transaction.
+func (s *InventoryServiceSql) Consume(ctx context.Context, orderID string) error {
+	tx, err := beginTx(ctx, s.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := lockReservations(ctx, tx.Tx, orderID)
+	if err != nil {
+		return err
+	}
+	if len(current) == 0 {
+		return nil
+	}
+
+	productIDs := make([]string, 0, len(current))
+	for productID := range current {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+	if _, err := lockStock(ctx, tx.Tx, productIDs); err != nil {
+		return err
+	}
+
+	quantities := make([]int, len(productIDs))
+	for i, productID := range productIDs {
+		quantities[i] = current[productID]
+	}
+
+	stockQuery := `
+		UPDATE stock SET on_hand = stock.on_hand - d.quantity, reserved = stock.reserved - d.quantity
+		FROM unnest($1::varchar[], $2::int[]) AS d(product_id, quantity)
+		WHERE stock.product_id = d.product_id
+	`
+	if _, err := tx.ExecContext(ctx, stockQuery, pq.Array(productIDs), pq.Array(quantities)); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM stock_reservations WHERE order_id = $1`, orderID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type stockLevel struct {
+	onHand   int
+	reserved int
+}
+
+// setReservations makes the reservations of an order match the wanted quantities, changing the
+// reserved stock of each product by the difference in one transaction.
+func (s *InventoryServiceSql) setReservations(ctx context.Context, orderID string, reservations []inventory.Reservation) error {
+	tx, err := beginTx(ctx, s.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := lockReservations(ctx, tx.Tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]int, len(reservations))
+	for _, reservation := range reservations {
+		wanted[reservation.ProductID] += reservation.Quantity
+	}
+
+	var productIDs []string
+	for productID, quantity := range wanted {
+		if quantity != current[productID] {
+			productIDs = append(productIDs, productID)
+		}
+	}
+	for productID := range current {
+		if _, ok := wanted[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+	sort.Strings(productIDs)
+
+	levels, err := lockStock(ctx, tx.Tx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	var changed, deltas []int
+	var changedIDs []string
+	for _, productID := range productIDs {
+		level, tracked := levels[productID]
+		if !tracked {
+			continue
+		}
+		delta := wanted[productID] - current[productID]
+		if available := level.onHand - level.reserved; delta > available {
+			return &inventory.InsufficientStockError{ProductID: productID, Requested: wanted[productID], Available: available + current[productID]}
+		}
+		changedIDs = append(changedIDs, productID)
+		deltas = append(deltas, delta)
+		changed = append(changed, wanted[productID])
+	}
+	if len(changedIDs) == 0 {
+		return nil
+	}
+
+	stockQuery := `
+		UPDATE stock SET reserved = stock.reserved + d.delta
+		FROM unnest($1::varchar[], $2::int[]) AS d(product_id, delta)
+		WHERE stock.product_id = d.product_id
+	`
+	if _, err := tx.ExecContext(ctx, stockQuery, pq.Array(changedIDs), pq.Array(deltas)); err != nil {
+		return err
+	}
+
+	reservationQuery := `
+		WITH changed AS (
+			SELECT product_id, quantity FROM unnest($2::varchar[], $3::int[]) AS c(product_id, quantity)
+		), removed AS (
+			DELETE FROM stock_reservations r USING changed c
+			WHERE r.order_id = $1 AND r.product_id = c.product_id AND c.quantity = 0
+		)
+		INSERT INTO stock_reservations (order_id, product_id, quantity)
+		SELECT $1, product_id, quantity FROM changed WHERE quantity > 0
+		ON CONFLICT (order_id, product_id) DO UPDATE SET quantity = EXCLUDED.quantity
+	`
+	if _, err := tx.ExecContext(ctx, reservationQuery, orderID, pq.Array(changedIDs), pq.Array(changed)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func lockReservations(ctx context.Context, tx *sql.Tx, orderID string) (map[string]int, error) {
+	query := `SELECT product_id, quantity FROM stock_reservations WHERE order_id = $1 FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	current := make(map[string]int)
+	for rows.Next() {
+		var productID string
+		var quantity int
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			return nil, err
+		}
+		current[productID] = quantity
+	}
+	return current, rows.Err()
+}
+
+// lockStock locks the stock rows of the products in product ID order, so concurrent reservations
+// touching the same products cannot deadlock.
+func lockStock(ctx context.Context, tx *sql.Tx, productIDs []string) (map[string]stockLevel, error) {
+	query := `
+		SELECT product_id, on_hand, reserved FROM stock
+		WHERE product_id = ANY($1)
+		ORDER BY product_id
+		FOR UPDATE
+	`
+	rows, err := tx.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := make(map[string]stockLevel, len(productIDs))
+	for rows.Next() {
+		var productID string
+		var level stockLevel
+		if err := rows.Scan(&productID, &level.onHand, &level.reserved); err != nil {
+			return nil, err
+		}
+		levels[productID] = level
+	}
+	return levels, rows.Err()
+}
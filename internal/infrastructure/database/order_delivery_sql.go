@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"order-service/internal/domain/entity"
+
+	"github.com/lib/pq"
+)
+
+const (
+	shippingAddress = "shipping"
+	billingAddress  = "billing"
+)
+
+// saveDeliveryDetails replaces the addresses and delivery of an order, which are rewritten together
+// like its discounts.
+func saveDeliveryDetails(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	if order.Version > 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM order_addresses WHERE order_id = $1`, order.ID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM order_deliveries WHERE order_id = $1`, order.ID); err != nil {
+			return err
+		}
+	}
+
+	addressInsertQuery := `
+		INSERT INTO order_addresses (order_id, kind, line1, line2, city, state, postal_code, country)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, NULLIF($6, ''), NULLIF($7, ''), $8)
+	`
+	addresses := map[string]*entity.Address{shippingAddress: order.ShippingAddress, billingAddress: order.BillingAddress}
+	for kind, address := range addresses {
+		if address == nil {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, addressInsertQuery, order.ID, kind, address.Line1, address.Line2, address.City, address.State, address.PostalCode, address.Country)
+		if err != nil {
+			return err
+		}
+	}
+
+	if order.DeliveryMethod == "" && order.DeliveryWindow == nil {
+		return nil
+	}
+	var windowStart, windowEnd sql.NullTime
+	if window := order.DeliveryWindow; window != nil {
+		windowStart = sql.NullTime{Time: window.Start, Valid: true}
+		windowEnd = sql.NullTime{Time: window.End, Valid: true}
+	}
+	deliveryInsertQuery := `
+		INSERT INTO order_deliveries (order_id, method, window_start, window_end)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
+	`
+	_, err := tx.ExecContext(ctx, deliveryInsertQuery, order.ID, string(order.DeliveryMethod), windowStart, windowEnd)
+	return err
+}
+
+// loadDeliveryDetails reads the addresses and deliveries of orders with one query each.
+func (r *OrderRepositorySql) loadDeliveryDetails(ctx context.Context, orders []entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(orders))
+	orderIndex := make(map[string]int, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+		orderIndex[order.ID] = i
+	}
+
+	if err := r.loadAddresses(ctx, orders, ids, orderIndex); err != nil {
+		return err
+	}
+
+	deliveryQuery := `
+		SELECT order_id, COALESCE(method, ''), window_start, window_end
+		FROM order_deliveries
+		WHERE order_id = ANY($1)
+	`
+	rows, err := r.db.QueryContext(ctx, deliveryQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID, method string
+		var windowStart, windowEnd sql.NullTime
+		if err := rows.Scan(&orderID, &method, &windowStart, &windowEnd); err != nil {
+			return err
+		}
+		i, exists := orderIndex[orderID]
+		if !exists {
+			continue
+		}
+		orders[i].DeliveryMethod = entity.DeliveryMethod(method)
+		if windowStart.Valid && windowEnd.Valid {
+			orders[i].DeliveryWindow = &entity.DeliveryWindow{Start: windowStart.Time, End: windowEnd.Time}
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *OrderRepositorySql) loadAddresses(ctx context.Context, orders []entity.Order, ids []string, orderIndex map[string]int) error {
+	addressQuery := `
+		SELECT order_id, kind, line1, COALESCE(line2, ''), city, COALESCE(state, ''), COALESCE(postal_code, ''), country
+		FROM order_addresses
+		WHERE order_id = ANY($1)
+	`
+	rows, err := r.db.QueryContext(ctx, addressQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID, kind string
+		var address entity.Address
+		if err := rows.Scan(&orderID, &kind, &address.Line1, &address.Line2, &address.City, &address.State, &address.PostalCode, &address.Country); err != nil {
+			return err
+		}
+		i, exists := orderIndex[orderID]
+		if !exists {
+			continue
+		}
+		switch kind {
+		case shippingAddress:
+			orders[i].ShippingAddress = &address
+		case billingAddress:
+			orders[i].BillingAddress = &address
+		}
+	}
+
+	return rows.Err()
+}
@@ -4,10 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"strings"
 
 	"order-service/internal/domain/entity"
 	"order-service/internal/domain/repository"
+	"order-service/internal/infrastructure/outbox"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type OrderRepositorySql struct {
@@ -18,7 +24,7 @@ func NewOrderRepositorySql(db *sql.DB) *OrderRepositorySql {
 	return &OrderRepositorySql{db: db}
 }
 
-func (r *OrderRepositorySql) Save(ctx context.Context, order *entity.Order) error {
+func (r *OrderRepositorySql) Save(ctx context.Context, order *entity.Order, effects ...repository.SideEffect) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -29,40 +35,326 @@ func (r *OrderRepositorySql) Save(ctx context.Context, order *entity.Order) erro
 		}
 	}()
 
-	orderQuery := `
-		INSERT INTO orders (id, customer_name, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (id) DO UPDATE
-		SET customer_name = $2, status = $3, updated_at = $5
+	if err := applySideEffects(ctx, tx, order, effects); err != nil {
+		return err
+	}
+
+	if err := saveOrderRow(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err := saveItems(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err := saveDiscounts(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err := saveDeliveryDetails(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err := saveStatusHistory(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err := saveOutboxEvents(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.Version++
+	order.ClearEvents()
+	return nil
+}
+
+// applySideEffects runs the side effects of a save in its transaction. An existing order is locked at
+// its loaded version first, so a writer that lost the race changes nothing and concurrent saves of one
+// order apply their side effects one after the other.
+func applySideEffects(ctx context.Context, tx *sql.Tx, order *entity.Order, effects []repository.SideEffect) error {
+	if len(effects) == 0 {
+		return nil
+	}
+
+	if order.Version > 0 {
+		var version int64
+		err := tx.QueryRowContext(ctx, `SELECT version FROM orders WHERE id = $1 FOR UPDATE`, order.ID).Scan(&version)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err != nil || version != order.Version {
+			return fmt.Errorf("%w: order %s is no longer at version %d", repository.ErrConcurrentModification, order.ID, order.Version)
+		}
+	}
+
+	txCtx := withTx(ctx, tx)
+	for _, effect := range effects {
+		if err := effect(txCtx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveOrderRow inserts new orders (version 0) and updates existing ones only when the stored version
+// still matches, so concurrent writers cannot overwrite each other.
+func saveOrderRow(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	if order.Version == 0 {
+		insertQuery := `
+			INSERT INTO orders (id, customer_id, customer_name, currency, coupon_code, tax_jurisdiction, status, created_at, updated_at, version)
+			VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, $8, $9, 1)
+			ON CONFLICT (id) DO NOTHING
+		`
+		result, err := tx.ExecContext(ctx, insertQuery, order.ID, order.CustomerID, order.CustomerName, order.Currency, order.CouponCode, order.TaxJurisdiction, order.Status.String(), order.CreatedAt, order.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		return checkVersionedWrite(result, order)
+	}
+
+	updateQuery := `
+		UPDATE orders
+		SET customer_id = NULLIF($2, ''), customer_name = $3, coupon_code = NULLIF($4, ''), tax_jurisdiction = NULLIF($5, ''), status = $6,
+			updated_at = $7, version = version + 1
+		WHERE id = $1 AND version = $8
 	`
-	_, err = tx.ExecContext(ctx, orderQuery, order.ID, order.CustomerName, order.Status.String(), order.CreatedAt, order.UpdatedAt)
+	result, err := tx.ExecContext(ctx, updateQuery, order.ID, order.CustomerID, order.CustomerName, order.CouponCode, order.TaxJurisdiction, order.Status.String(), order.UpdatedAt, order.Version)
 	if err != nil {
 		return err
 	}
+	return checkVersionedWrite(result, order)
+}
 
-	itemDeleteQuery := `DELETE FROM order_items WHERE order_id = $1`
-	_, err = tx.ExecContext(ctx, itemDeleteQuery, order.ID)
+func checkVersionedWrite(result sql.Result, order *entity.Order) error {
+	affected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if affected == 0 {
+		return fmt.Errorf("%w: order %s is no longer at version %d", repository.ErrConcurrentModification, order.ID, order.Version)
+	}
+	return nil
+}
 
-	itemInsertQuery := `
-		INSERT INTO order_items (id, order_id, name, quantity, price)
-		VALUES ($1, $2, $3, $4, $5)
-	`
+// saveItems writes only the items that were added, changed or removed since the order was stored,
+// with at most one statement per kind of change.
+func saveItems(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	stored := make(map[string]entity.Item)
+	if order.Version > 0 {
+		var err error
+		if stored, err = storedItems(ctx, tx, order.ID); err != nil {
+			return err
+		}
+	}
+
+	var added, changed itemBatch
 	for _, item := range order.Items {
-		_, err = tx.ExecContext(ctx, itemInsertQuery, item.ID, order.ID, item.Name, item.Quantity, item.Price)
+		previous, exists := stored[item.ID]
+		delete(stored, item.ID)
+
+		switch {
+		case !exists:
+			added.add(item)
+		case previous != item:
+			changed.add(item)
+		}
+	}
+
+	if len(stored) > 0 {
+		removed := make([]string, 0, len(stored))
+		for id := range stored {
+			removed = append(removed, id)
+		}
+		itemDeleteQuery := `DELETE FROM order_items WHERE order_id = $1 AND id = ANY($2)`
+		if _, err := tx.ExecContext(ctx, itemDeleteQuery, order.ID, pq.Array(removed)); err != nil {
+			return err
+		}
+	}
+
+	if len(changed.ids) > 0 {
+		itemUpdateQuery := `
+			UPDATE order_items AS i
+			SET product_id = NULLIF(c.product_id, ''), name = c.name, category = NULLIF(c.category, ''), quantity = c.quantity,
+				price_minor = c.price_minor, currency = c.currency, tax_minor = c.tax_minor
+			FROM unnest($2::varchar[], $3::varchar[], $4::varchar[], $5::varchar[], $6::int[], $7::bigint[], $8::char(3)[], $9::bigint[])
+				AS c(id, product_id, name, category, quantity, price_minor, currency, tax_minor)
+			WHERE i.order_id = $1 AND i.id = c.id
+		`
+		if _, err := tx.ExecContext(ctx, itemUpdateQuery, changed.args(order.ID)...); err != nil {
+			return err
+		}
+	}
+
+	if len(added.ids) > 0 {
+		itemInsertQuery := `
+			INSERT INTO order_items (id, order_id, product_id, name, category, quantity, price_minor, currency, tax_minor)
+			SELECT a.id, $1, NULLIF(a.product_id, ''), a.name, NULLIF(a.category, ''), a.quantity, a.price_minor, a.currency, a.tax_minor
+			FROM unnest($2::varchar[], $3::varchar[], $4::varchar[], $5::varchar[], $6::int[], $7::bigint[], $8::char(3)[], $9::bigint[])
+				AS a(id, product_id, name, category, quantity, price_minor, currency, tax_minor)
+		`
+		if _, err := tx.ExecContext(ctx, itemInsertQuery, added.args(order.ID)...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// itemBatch holds item columns as arrays, to be expanded with unnest into one row per item.
+type itemBatch struct {
+	ids        []string
+	productIDs []string
+	names      []string
+	categories []string
+	quantities []int64
+	prices     []int64
+	currencies []string
+	taxes      []int64
+}
+
+func (b *itemBatch) add(item entity.Item) {
+	b.ids = append(b.ids, item.ID)
+	b.productIDs = append(b.productIDs, item.ProductID)
+	b.names = append(b.names, item.Name)
+	b.categories = append(b.categories, item.Category)
+	b.quantities = append(b.quantities, int64(item.Quantity))
+	b.prices = append(b.prices, item.Price.Amount)
+	b.currencies = append(b.currencies, item.Price.Currency)
+	b.taxes = append(b.taxes, item.Tax.Amount)
+}
+
+func (b *itemBatch) args(orderID string) []any {
+	return []any{
+		orderID, pq.Array(b.ids), pq.Array(b.productIDs), pq.Array(b.names), pq.Array(b.categories),
+		pq.Array(b.quantities), pq.Array(b.prices), pq.Array(b.currencies), pq.Array(b.taxes),
+	}
+}
+
+func storedItems(ctx context.Context, tx *sql.Tx, orderID string) (map[string]entity.Item, error) {
+	itemQuery := `
+		SELECT id, COALESCE(product_id, ''), name, COALESCE(category, ''), quantity, price_minor, currency, tax_minor
+		FROM order_items
+		WHERE order_id = $1
+	`
+	rows, err := tx.QueryContext(ctx, itemQuery, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make(map[string]entity.Item)
+	for rows.Next() {
+		var item entity.Item
+		if err := scanItem(rows, &item); err != nil {
+			return nil, err
+		}
+		items[item.ID] = item
+	}
+	return items, rows.Err()
+}
+
+// scanItem reads an item row selecting id, product_id, name, category, quantity, price_minor, currency
+// and tax_minor, followed by the columns scanned into extra.
+func scanItem(rows *sql.Rows, item *entity.Item, extra ...any) error {
+	dest := append([]any{&item.ID, &item.ProductID, &item.Name, &item.Category, &item.Quantity, &item.Price.Amount, &item.Price.Currency, &item.Tax.Amount}, extra...)
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+	item.Tax.Currency = item.Price.Currency
+	return nil
+}
+
+// saveDiscounts replaces the discounts of an order, which are few and always rewritten together.
+func saveDiscounts(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	if order.Version > 0 {
+		deleteQuery := `DELETE FROM order_discounts WHERE order_id = $1`
+		if _, err := tx.ExecContext(ctx, deleteQuery, order.ID); err != nil {
+			return err
+		}
+	}
+	if len(order.Discounts) == 0 {
+		return nil
+	}
+
+	positions := make([]int64, len(order.Discounts))
+	codes := make([]string, len(order.Discounts))
+	itemIDs := make([]string, len(order.Discounts))
+	descriptions := make([]string, len(order.Discounts))
+	amounts := make([]int64, len(order.Discounts))
+	currencies := make([]string, len(order.Discounts))
+	for i, discount := range order.Discounts {
+		positions[i] = int64(i)
+		codes[i] = discount.Code
+		itemIDs[i] = discount.ItemID
+		descriptions[i] = discount.Description
+		amounts[i] = discount.Amount.Amount
+		currencies[i] = discount.Amount.Currency
+	}
+
+	insertQuery := `
+		INSERT INTO order_discounts (order_id, position, code, item_id, description, amount_minor, currency)
+		SELECT $1, d.position, d.code, NULLIF(d.item_id, ''), d.description, d.amount_minor, d.currency
+		FROM unnest($2::int[], $3::varchar[], $4::varchar[], $5::varchar[], $6::bigint[], $7::char(3)[])
+			AS d(position, code, item_id, description, amount_minor, currency)
+	`
+	_, err := tx.ExecContext(ctx, insertQuery, order.ID, pq.Array(positions), pq.Array(codes), pq.Array(itemIDs), pq.Array(descriptions), pq.Array(amounts), pq.Array(currencies))
+	return err
+}
+
+func saveStatusHistory(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	historyInsertQuery := `
+		INSERT INTO order_status_history (order_id, from_status, to_status, changed_by, reason, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, event := range order.Events() {
+		change, ok := event.StatusChange()
+		if !ok {
+			continue
+		}
+
+		var from sql.NullString
+		if change.From != nil {
+			from = sql.NullString{String: change.From.String(), Valid: true}
+		}
+
+		_, err := tx.ExecContext(ctx, historyInsertQuery, order.ID, from, change.To.String(), nullString(change.ChangedBy), nullString(change.Reason), change.ChangedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func saveOutboxEvents(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	outboxInsertQuery := `
+		INSERT INTO outbox (id, aggregate_id, routing_key, payload, status, attempts, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, 0, $6, $6)
+	`
+	for _, event := range order.Events() {
+		// Events describe the order as stored by this save, which bumps the version.
+		event.Current.Version = order.Version + 1
+		payload, err := event.Payload()
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event: %w", event.Type, err)
+		}
+
+		_, err = tx.ExecContext(ctx, outboxInsertQuery, uuid.New().String(), order.ID, string(event.Type), payload, outbox.StatusPending, event.OccurredAt)
 		if err != nil {
 			return err
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (r *OrderRepositorySql) FindByID(ctx context.Context, id string) (*entity.Order, error) {
 	orderQuery := `
-		SELECT id, customer_name, status, created_at, updated_at
+		SELECT id, COALESCE(customer_id, ''), customer_name, currency, COALESCE(coupon_code, ''), COALESCE(tax_jurisdiction, ''), status, created_at, updated_at, version
 		FROM orders
 		WHERE id = $1
 	`
@@ -70,7 +362,7 @@ func (r *OrderRepositorySql) FindByID(ctx context.Context, id string) (*entity.O
 
 	var order entity.Order
 	var status string
-	if err := row.Scan(&order.ID, &order.CustomerName, &status, &order.CreatedAt, &order.UpdatedAt); err != nil {
+	if err := row.Scan(&order.ID, &order.CustomerID, &order.CustomerName, &order.Currency, &order.CouponCode, &order.TaxJurisdiction, &status, &order.CreatedAt, &order.UpdatedAt, &order.Version); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrNotFound
 		}
@@ -79,7 +371,7 @@ func (r *OrderRepositorySql) FindByID(ctx context.Context, id string) (*entity.O
 	order.Status = parseOrderStatus(status)
 
 	itemQuery := `
-		SELECT id, name, quantity, price
+		SELECT id, COALESCE(product_id, ''), name, COALESCE(category, ''), quantity, price_minor, currency, tax_minor
 		FROM order_items
 		WHERE order_id = $1
 	`
@@ -91,62 +383,298 @@ func (r *OrderRepositorySql) FindByID(ctx context.Context, id string) (*entity.O
 
 	for rows.Next() {
 		var item entity.Item
-		if err := rows.Scan(&item.ID, &item.Name, &item.Quantity, &item.Price); err != nil {
+		if err := scanItem(rows, &item); err != nil {
 			return nil, err
 		}
 		order.Items = append(order.Items, item)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orders := []entity.Order{order}
+	if err := r.loadDiscounts(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadDeliveryDetails(ctx, orders); err != nil {
+		return nil, err
+	}
 
-	return &order, nil
+	return &orders[0], nil
 }
 
-func (r *OrderRepositorySql) List(ctx context.Context) ([]entity.Order, error) {
-	orderQuery := `
-		SELECT id, customer_name, status, created_at, updated_at
-		FROM orders
-	`
-	rows, err := r.db.QueryContext(ctx, orderQuery)
+func (r *OrderRepositorySql) List(ctx context.Context, query repository.OrderQuery) (repository.OrderPage, error) {
+	if query.Keyset {
+		return r.listKeyset(ctx, query)
+	}
+
+	where, args := buildOrderFilter(query)
+
+	countQuery := `SELECT COUNT(*) FROM orders` + where
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return repository.OrderPage{}, err
+	}
+
+	orderQuery := fmt.Sprintf(`
+		SELECT id, COALESCE(customer_id, ''), customer_name, currency, COALESCE(coupon_code, ''), COALESCE(tax_jurisdiction, ''), status, created_at, updated_at, version
+		FROM orders%s
+		ORDER BY %s %s, id %s
+		LIMIT $%d OFFSET $%d
+	`, where, sortColumn(query.SortBy), sortDirection(query.SortDirection), sortDirection(query.SortDirection), len(args)+1, len(args)+2)
+	args = append(args, query.Size, query.Offset())
+
+	orders, err := r.queryOrders(ctx, orderQuery, args...)
+	if err != nil {
+		return repository.OrderPage{}, err
+	}
+
+	return repository.OrderPage{Orders: orders, Total: total}, nil
+}
+
+func (r *OrderRepositorySql) listKeyset(ctx context.Context, query repository.OrderQuery) (repository.OrderPage, error) {
+	where, args := buildOrderFilter(query)
+
+	direction := sortDirection(query.SortDirection)
+	if query.After != nil {
+		comparison := "<"
+		if direction == "ASC" {
+			comparison = ">"
+		}
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		condition := fmt.Sprintf("(created_at, id) %s ($%d, $%d)", comparison, len(args)-1, len(args))
+		if where == "" {
+			where = " WHERE " + condition
+		} else {
+			where += " AND " + condition
+		}
+	}
+
+	orderQuery := fmt.Sprintf(`
+		SELECT id, COALESCE(customer_id, ''), customer_name, currency, COALESCE(coupon_code, ''), COALESCE(tax_jurisdiction, ''), status, created_at, updated_at, version
+		FROM orders%s
+		ORDER BY created_at %s, id %s
+		LIMIT $%d
+	`, where, direction, direction, len(args)+1)
+	args = append(args, query.Size+1)
+
+	orders, err := r.queryOrders(ctx, orderQuery, args...)
+	if err != nil {
+		return repository.OrderPage{}, err
+	}
+
+	page := repository.OrderPage{Orders: orders}
+	if len(orders) > query.Size {
+		page.Orders = orders[:query.Size]
+		last := page.Orders[len(page.Orders)-1]
+		page.NextCursor = &repository.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return page, nil
+}
+
+func (r *OrderRepositorySql) queryOrders(ctx context.Context, query string, args ...interface{}) ([]entity.Order, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var orders []entity.Order
-	orderMap := make(map[string]*entity.Order)
-
+	orders := []entity.Order{}
 	for rows.Next() {
 		var order entity.Order
 		var status string
-		if err := rows.Scan(&order.ID, &order.CustomerName, &status, &order.CreatedAt, &order.UpdatedAt); err != nil {
+		if err := rows.Scan(&order.ID, &order.CustomerID, &order.CustomerName, &order.Currency, &order.CouponCode, &order.TaxJurisdiction, &status, &order.CreatedAt, &order.UpdatedAt, &order.Version); err != nil {
 			return nil, err
 		}
 		order.Status = parseOrderStatus(status)
-		orderMap[order.ID] = &order
 		orders = append(orders, order)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadItems(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadDiscounts(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadDeliveryDetails(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (r *OrderRepositorySql) loadItems(ctx context.Context, orders []entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(orders))
+	orderIndex := make(map[string]int, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+		orderIndex[order.ID] = i
+	}
 
 	itemQuery := `
-		SELECT id, order_id, name, quantity, price
+		SELECT id, COALESCE(product_id, ''), name, COALESCE(category, ''), quantity, price_minor, currency, tax_minor, order_id
 		FROM order_items
+		WHERE order_id = ANY($1)
 	`
-	itemRows, err := r.db.QueryContext(ctx, itemQuery)
+	itemRows, err := r.db.QueryContext(ctx, itemQuery, pq.Array(ids))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer itemRows.Close()
 
 	for itemRows.Next() {
 		var item entity.Item
 		var orderID string
-		if err := itemRows.Scan(&item.ID, &orderID, &item.Name, &item.Quantity, &item.Price); err != nil {
+		if err := scanItem(itemRows, &item, &orderID); err != nil {
+			return err
+		}
+		if i, exists := orderIndex[orderID]; exists {
+			orders[i].Items = append(orders[i].Items, item)
+		}
+	}
+
+	return itemRows.Err()
+}
+
+func (r *OrderRepositorySql) loadDiscounts(ctx context.Context, orders []entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(orders))
+	orderIndex := make(map[string]int, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+		orderIndex[order.ID] = i
+	}
+
+	discountQuery := `
+		SELECT order_id, code, COALESCE(item_id, ''), description, amount_minor, currency
+		FROM order_discounts
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, position
+	`
+	rows, err := r.db.QueryContext(ctx, discountQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var discount entity.Discount
+		var orderID string
+		if err := rows.Scan(&orderID, &discount.Code, &discount.ItemID, &discount.Description, &discount.Amount.Amount, &discount.Amount.Currency); err != nil {
+			return err
+		}
+		if i, exists := orderIndex[orderID]; exists {
+			orders[i].Discounts = append(orders[i].Discounts, discount)
+		}
+	}
+
+	return rows.Err()
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, so searched text only matches itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func buildOrderFilter(query repository.OrderQuery) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if query.Status != nil {
+		args = append(args, query.Status.String())
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if query.CustomerID != "" {
+		args = append(args, query.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+	if query.CustomerName != "" {
+		args = append(args, "%"+likeEscaper.Replace(query.CustomerName)+"%")
+		conditions = append(conditions, fmt.Sprintf(`customer_name ILIKE $%d ESCAPE '\'`, len(args)))
+	}
+	if query.CreatedFrom != nil {
+		args = append(args, *query.CreatedFrom)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if query.CreatedTo != nil {
+		args = append(args, *query.CreatedTo)
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func sortColumn(field repository.SortField) string {
+	switch field {
+	case repository.SortByUpdatedAt:
+		return "updated_at"
+	case repository.SortByCustomerName:
+		return "customer_name"
+	case repository.SortByStatus:
+		return "status"
+	default:
+		return "created_at"
+	}
+}
+
+func sortDirection(direction repository.SortDirection) string {
+	if direction == repository.SortAsc {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+func (r *OrderRepositorySql) FindStatusHistory(ctx context.Context, orderID string) ([]entity.StatusChange, error) {
+	historyQuery := `
+		SELECT from_status, to_status, changed_by, reason, changed_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY changed_at, id
+	`
+	rows, err := r.db.QueryContext(ctx, historyQuery, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []entity.StatusChange{}
+	for rows.Next() {
+		var change entity.StatusChange
+		var from, changedBy, reason sql.NullString
+		var to string
+		if err := rows.Scan(&from, &to, &changedBy, &reason, &change.ChangedAt); err != nil {
 			return nil, err
 		}
-		if order, exists := orderMap[orderID]; exists {
-			order.Items = append(order.Items, item)
+		if from.Valid {
+			status := parseOrderStatus(from.String)
+			change.From = &status
 		}
+		change.To = parseOrderStatus(to)
+		change.ChangedBy = changedBy.String
+		change.Reason = reason.String
+		history = append(history, change)
 	}
 
-	return orders, nil
+	return history, rows.Err()
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
 }
 
 func parseOrderStatus(status string) entity.OrderStatus {
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"order-service/internal/infrastructure/outbox"
+)
+
+type OutboxRepositorySql struct {
+	db *sql.DB
+}
+
+func NewOutboxRepositorySql(db *sql.DB) *OutboxRepositorySql {
+	return &OutboxRepositorySql{db: db}
+}
+
+// outboxClaimLock is the advisory lock key that serializes claims, so a relay cannot claim an order's
+// later event while another relay's claim of an earlier one is still uncommitted.
+const outboxClaimLock = 7_000_001
+
+// ClaimPending leases up to limit due messages in insertion order. A message is held back while an
+// earlier pending message of the same aggregate is leased or waiting to be retried, so the events
+// of an order are published in the order they were raised.
+func (r *OutboxRepositorySql) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, outboxClaimLock); err != nil {
+		return nil, err
+	}
+
+	claimQuery := `
+		WITH claimed AS (
+			UPDATE outbox
+			SET next_attempt_at = $1
+			WHERE id IN (
+				SELECT o.id
+				FROM outbox o
+				WHERE o.status = $2 AND o.next_attempt_at <= $3
+					AND NOT EXISTS (
+						SELECT 1
+						FROM outbox earlier
+						WHERE earlier.aggregate_id = o.aggregate_id
+							AND earlier.status = $2
+							AND earlier.sequence < o.sequence
+							AND earlier.next_attempt_at > $3
+					)
+				ORDER BY o.sequence
+				LIMIT $4
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, aggregate_id, routing_key, payload, attempts, created_at, sequence
+		)
+		SELECT id, aggregate_id, routing_key, payload, attempts, created_at
+		FROM claimed
+		ORDER BY sequence
+	`
+	now := time.Now()
+	rows, err := tx.QueryContext(ctx, claimQuery, now.Add(lease), outbox.StatusPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []outbox.Message
+	for rows.Next() {
+		var message outbox.Message
+		if err := rows.Scan(&message.ID, &message.AggregateID, &message.RoutingKey, &message.Payload, &message.Attempts, &message.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *OutboxRepositorySql) MarkSent(ctx context.Context, id string) error {
+	query := `
+		UPDATE outbox
+		SET status = $2, sent_at = $3, last_error = NULL
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, id, outbox.StatusSent, time.Now())
+	return err
+}
+
+func (r *OutboxRepositorySql) MarkFailed(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, dead bool, cause error) error {
+	status := outbox.StatusPending
+	if dead {
+		status = outbox.StatusFailed
+	}
+
+	query := `
+		UPDATE outbox
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, id, status, attempts, nextAttemptAt, cause.Error())
+	return err
+}
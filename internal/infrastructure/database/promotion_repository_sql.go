@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+)
+
+type PromotionRepositorySql struct {
+	db *sql.DB
+}
+
+func NewPromotionRepositorySql(db *sql.DB) *PromotionRepositorySql {
+	return &PromotionRepositorySql{db: db}
+}
+
+func (r *PromotionRepositorySql) FindByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	query := `
+		SELECT code, description, rule, COALESCE(product_id, ''), COALESCE(percent_off, 0),
+			COALESCE(amount_off_minor, 0), COALESCE(currency, ''), COALESCE(buy_quantity, 0), COALESCE(free_quantity, 0),
+			valid_from, valid_until, COALESCE(usage_limit, 0), times_redeemed
+		FROM promotions
+		WHERE code = $1
+	`
+	var promotion entity.Promotion
+	var rule string
+	var validFrom, validUntil sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, code).Scan(
+		&promotion.Code, &promotion.Description, &rule, &promotion.ProductID, &promotion.PercentOff,
+		&promotion.AmountOff.Amount, &promotion.AmountOff.Currency, &promotion.BuyQuantity, &promotion.FreeQuantity,
+		&validFrom, &validUntil, &promotion.UsageLimit, &promotion.TimesRedeemed,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", repository.ErrPromotionNotFound, code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	promotion.Rule = entity.PromotionRule(rule)
+	if validFrom.Valid {
+		promotion.ValidFrom = &validFrom.Time
+	}
+	if validUntil.Valid {
+		promotion.ValidUntil = &validUntil.Time
+	}
+	return &promotion, nil
+}
+
+// Redeem records the redemption and counts it in one transaction, or in the transaction of the order
+// save it is a side effect of; the conditional update makes concurrent redemptions of the last use
+// fail rather than exceed the limit.
+func (r *PromotionRepositorySql) Redeem(ctx context.Context, code, orderID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insertQuery := `
+		INSERT INTO promotion_redemptions (code, order_id, redeemed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (code, order_id) DO NOTHING
+	`
+	result, err := tx.ExecContext(ctx, insertQuery, code, orderID, time.Now())
+	if err != nil {
+		return err
+	}
+	if inserted, err := result.RowsAffected(); err != nil || inserted == 0 {
+		return err
+	}
+
+	countQuery := `
+		UPDATE promotions SET times_redeemed = times_redeemed + 1
+		WHERE code = $1 AND (usage_limit IS NULL OR times_redeemed < usage_limit)
+	`
+	result, err = tx.ExecContext(ctx, countQuery, code)
+	if err != nil {
+		return err
+	}
+	if counted, err := result.RowsAffected(); err != nil {
+		return err
+	} else if counted == 0 {
+		return fmt.Errorf("%w: %s", entity.ErrPromotionExhausted, code)
+	}
+
+	return tx.Commit()
+}
+
+func (r *PromotionRepositorySql) Release(ctx context.Context, code, orderID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		WITH released AS (
+			DELETE FROM promotion_redemptions WHERE code = $1 AND order_id = $2
+			RETURNING code
+		)
+		UPDATE promotions SET times_redeemed = times_redeemed - 1
+		WHERE code IN (SELECT code FROM released)
+	`
+	if _, err := tx.ExecContext(ctx, query, code, orderID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,66 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/repository"
+	"order-service/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepositorySql_SaveAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewAPIKeyRepositorySql(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	key, secret, err := auth.NewAPIKey(uuid.New().String(), "nightly export", []auth.Scope{auth.ScopeOrdersRead, auth.ScopeOrdersCancel}, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), key))
+
+	found, err := repo.FindByHash(context.Background(), auth.HashAPIKey(secret))
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, found.ID)
+	assert.Equal(t, key.Prefix, found.Prefix)
+	assert.Equal(t, []auth.Scope{auth.ScopeOrdersRead, auth.ScopeOrdersCancel}, found.Scopes)
+	assert.Nil(t, found.RevokedAt)
+
+	key.Revoke(now)
+	require.NoError(t, repo.Save(context.Background(), key))
+	found, err = repo.FindByID(context.Background(), key.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.RevokedAt)
+	assert.True(t, found.RevokedAt.Equal(now))
+
+	_, err = repo.FindByHash(context.Background(), auth.HashAPIKey("osk_unknown"))
+	assert.ErrorIs(t, err, repository.ErrAPIKeyNotFound)
+	_, err = repo.FindByID(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, repository.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyRepositorySql_TouchLastUsed(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewAPIKeyRepositorySql(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	key, _, err := auth.NewAPIKey(uuid.New().String(), "nightly export", []auth.Scope{auth.ScopeOrdersRead}, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), key))
+
+	require.NoError(t, repo.TouchLastUsed(context.Background(), key.ID, now.Add(time.Minute)))
+	require.NoError(t, repo.TouchLastUsed(context.Background(), key.ID, now), "an earlier use does not move the time back")
+	require.NoError(t, repo.Save(context.Background(), key), "saving does not clear the time")
+
+	keys, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.NotNil(t, keys[0].LastUsedAt)
+	assert.True(t, keys[0].LastUsedAt.Equal(now.Add(time.Minute)))
+}
@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+	"order-service/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomerRepositorySql_SaveAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewCustomerRepositorySql(db)
+	customer, err := entity.NewCustomer(uuid.New().String(), "John Doe", "john@example.com")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), customer))
+
+	found, err := repo.FindByID(context.Background(), customer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, customer.Name, found.Name)
+	assert.Equal(t, customer.Email, found.Email)
+
+	_, err = repo.FindByID(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, repository.ErrCustomerNotFound)
+}
+
+func TestCustomerRepositorySql_List(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewCustomerRepositorySql(db)
+	for _, name := range []string{"John Doe", "Jane Doe", "Mary Smith"} {
+		customer, err := entity.NewCustomer(uuid.New().String(), name, "")
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(context.Background(), customer))
+	}
+
+	page, err := repo.List(context.Background(), repository.CustomerQuery{Page: 1, Size: 10, Name: "  DOE "})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	require.Len(t, page.Customers, 2)
+	assert.Equal(t, "Jane Doe", page.Customers[0].Name)
+	assert.Equal(t, "John Doe", page.Customers[1].Name)
+}
+
+func TestOrderRepositorySql_ListByCustomer(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	customers := database.NewCustomerRepositorySql(db)
+	customer, err := entity.NewCustomer(uuid.New().String(), "John Doe", "")
+	require.NoError(t, err)
+	require.NoError(t, customers.Save(context.Background(), customer))
+
+	orders := database.NewOrderRepositorySql(db)
+	items := []entity.Item{{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}}
+	linked, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, items)
+	require.NoError(t, err)
+	require.NoError(t, linked.AssignCustomer(customer.ID))
+	require.NoError(t, orders.Save(context.Background(), linked))
+	other, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, items)
+	require.NoError(t, err)
+	require.NoError(t, orders.Save(context.Background(), other))
+
+	page, err := orders.List(context.Background(), repository.OrderQuery{Page: 1, Size: 10, CustomerID: customer.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	require.Len(t, page.Orders, 1)
+	assert.Equal(t, linked.ID, page.Orders[0].ID)
+	assert.Equal(t, customer.ID, page.Orders[0].CustomerID)
+}
@@ -0,0 +1,119 @@
+package database_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/repository"
+	"order-service/internal/infrastructure/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepositorySql_ReserveAndComplete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewIdempotencyRepositorySql(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	record := repository.IdempotencyRecord{
+		Key:         "key-1",
+		RequestHash: strings.Repeat("a", 64),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+		LockedUntil: now.Add(time.Minute),
+	}
+
+	_, reserved, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	existing, reserved, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.False(t, existing.Completed())
+
+	require.NoError(t, repo.Complete(ctx, "", "key-1", []byte(`{"order_id": "123"}`)))
+
+	existing, reserved, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.True(t, existing.Completed())
+	assert.Equal(t, record.RequestHash, existing.RequestHash)
+
+	require.NoError(t, repo.Release(ctx, "", "key-1"))
+	_, reserved, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.False(t, reserved, "completed keys are not released")
+}
+
+func TestIdempotencyRepositorySql_ReplacesExpiredKeys(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewIdempotencyRepositorySql(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	_, reserved, err := repo.Reserve(ctx, repository.IdempotencyRecord{
+		Key:         "key-1",
+		RequestHash: strings.Repeat("a", 64),
+		CreatedAt:   now.Add(-2 * time.Hour),
+		ExpiresAt:   now.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	_, reserved, err = repo.Reserve(ctx, repository.IdempotencyRecord{
+		Key:         "key-1",
+		RequestHash: strings.Repeat("b", 64),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestIdempotencyRepositorySql_KeysOfCallersAndAbandonedRequests(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewIdempotencyRepositorySql(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	record := repository.IdempotencyRecord{
+		Owner:       "user-a",
+		Key:         "key-1",
+		RequestHash: strings.Repeat("a", 64),
+		CreatedAt:   now.Add(-2 * time.Minute),
+		ExpiresAt:   now.Add(time.Hour),
+		LockedUntil: now.Add(-time.Minute),
+	}
+	_, reserved, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	other := record
+	other.Owner = "user-b"
+	other.RequestHash = strings.Repeat("b", 64)
+	_, reserved, err = repo.Reserve(ctx, other)
+	require.NoError(t, err)
+	assert.True(t, reserved, "another caller may use the same key")
+
+	retry := record
+	retry.CreatedAt, retry.LockedUntil = now, now.Add(time.Minute)
+	_, reserved, err = repo.Reserve(ctx, retry)
+	require.NoError(t, err)
+	assert.True(t, reserved, "a request past its lease loses the key")
+
+	existing, reserved, err := repo.Reserve(ctx, retry)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, "user-a", existing.Owner)
+}
@@ -0,0 +1,155 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+	"order-service/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reservedStock(t *testing.T, db *sql.DB, productID string) int {
+	var reserved int
+	require.NoError(t, db.QueryRow(`SELECT reserved FROM stock WHERE product_id = $1`, productID).Scan(&reserved))
+	return reserved
+}
+
+func TestInventoryServiceSql_ReserveAdjustRelease(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO stock (product_id, on_hand) VALUES ('sku-a', 5), ('sku-b', 2)`)
+	require.NoError(t, err)
+
+	service := database.NewInventoryServiceSql(db)
+	ctx := context.Background()
+
+	require.NoError(t, service.Reserve(ctx, "order-1", []inventory.Reservation{
+		{ProductID: "sku-a", Quantity: 3},
+		{ProductID: "sku-b", Quantity: 2},
+		{ProductID: "untracked", Quantity: 100},
+	}))
+	assert.Equal(t, 3, reservedStock(t, db, "sku-a"))
+	assert.Equal(t, 2, reservedStock(t, db, "sku-b"))
+
+	require.NoError(t, service.Adjust(ctx, "order-1", []inventory.Reservation{{ProductID: "sku-a", Quantity: 5}}))
+	assert.Equal(t, 5, reservedStock(t, db, "sku-a"))
+	assert.Equal(t, 0, reservedStock(t, db, "sku-b"))
+
+	require.NoError(t, service.Release(ctx, "order-1"))
+	assert.Equal(t, 0, reservedStock(t, db, "sku-a"))
+
+	var reservations int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM stock_reservations`).Scan(&reservations))
+	assert.Zero(t, reservations)
+}
+
+func TestInventoryServiceSql_InsufficientStock(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO stock (product_id, on_hand) VALUES ('sku-a', 5), ('sku-b', 2)`)
+	require.NoError(t, err)
+
+	service := database.NewInventoryServiceSql(db)
+	ctx := context.Background()
+	require.NoError(t, service.Reserve(ctx, "order-1", []inventory.Reservation{{ProductID: "sku-a", Quantity: 4}}))
+
+	err = service.Reserve(ctx, "order-2", []inventory.Reservation{
+		{ProductID: "sku-b", Quantity: 1},
+		{ProductID: "sku-a", Quantity: 2},
+	})
+
+	var stockErr *inventory.InsufficientStockError
+	require.ErrorAs(t, err, &stockErr)
+	assert.Equal(t, inventory.InsufficientStockError{ProductID: "sku-a", Requested: 2, Available: 1}, *stockErr)
+	assert.Equal(t, 0, reservedStock(t, db, "sku-b"), "no reservation of a failed call is kept")
+
+	err = service.Adjust(ctx, "order-1", []inventory.Reservation{{ProductID: "sku-a", Quantity: 6}})
+	require.ErrorAs(t, err, &stockErr)
+	assert.Equal(t, 5, stockErr.Available, "the stock already held by the order counts as available")
+}
+
+func TestInventoryServiceSql_Consume(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO stock (product_id, on_hand) VALUES ('sku-a', 5)`)
+	require.NoError(t, err)
+
+	service := database.NewInventoryServiceSql(db)
+	ctx := context.Background()
+	reservations := []inventory.Reservation{{ProductID: "sku-a", Quantity: 3}, {ProductID: "untracked", Quantity: 1}}
+	require.NoError(t, service.Reserve(ctx, "order-1", reservations))
+
+	onHand := func() int {
+		var onHand int
+		require.NoError(t, db.QueryRow(`SELECT on_hand FROM stock WHERE product_id = 'sku-a'`).Scan(&onHand))
+		return onHand
+	}
+
+	require.NoError(t, service.Consume(ctx, "order-1"))
+	assert.Equal(t, 2, onHand())
+	assert.Equal(t, 0, reservedStock(t, db, "sku-a"))
+
+	require.NoError(t, service.Consume(ctx, "order-1"))
+	assert.Equal(t, 2, onHand())
+}
+
+func TestOrderRepositorySql_Save_SideEffectsShareTheTransaction(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO stock (product_id, on_hand) VALUES ('sku-a', 5)`)
+	require.NoError(t, err)
+
+	repo := database.NewOrderRepositorySql(db)
+	service := database.NewInventoryServiceSql(db)
+	ctx := context.Background()
+
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: uuid.New().String(), ProductID: "sku-a", Name: "Item 1", Quantity: 3, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	reserve := func(order *entity.Order) repository.SideEffect {
+		return func(ctx context.Context) error {
+			return service.Reserve(ctx, order.ID, []inventory.Reservation{{ProductID: "sku-a", Quantity: 3}})
+		}
+	}
+	release := func(order *entity.Order) repository.SideEffect {
+		return func(ctx context.Context) error { return service.Release(ctx, order.ID) }
+	}
+	require.NoError(t, repo.Save(ctx, order, reserve(order)))
+	assert.Equal(t, 3, reservedStock(t, db, "sku-a"))
+
+	first, err := repo.FindByID(ctx, order.ID)
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, order.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, first.ChangeStatus(entity.Canceled, "test", ""))
+	require.NoError(t, repo.Save(ctx, first, release(first)))
+	assert.Equal(t, 0, reservedStock(t, db, "sku-a"))
+
+	ran := false
+	require.NoError(t, second.ChangeStatus(entity.Canceled, "test", ""))
+	err = repo.Save(ctx, second, func(ctx context.Context) error { ran = true; return nil })
+	assert.ErrorIs(t, err, repository.ErrConcurrentModification)
+	assert.False(t, ran, "a save that lost the race applies no side effects")
+
+	failing, err := entity.NewOrder(uuid.New().String(), "Jane Doe", entity.DefaultCurrency, order.Items)
+	require.NoError(t, err)
+	err = repo.Save(ctx, failing, reserve(failing), func(context.Context) error { return errors.New("failed") })
+	assert.Error(t, err)
+	assert.Equal(t, 0, reservedStock(t, db, "sku-a"), "the reservation of a failed save is rolled back")
+	_, err = repo.FindByID(ctx, failing.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
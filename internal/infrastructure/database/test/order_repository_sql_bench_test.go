@@ -0,0 +1,85 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const benchmarkItems = 500
+
+func newLargeOrder(b *testing.B) *entity.Order {
+	items := make([]entity.Item, benchmarkItems)
+	for i := range items {
+		items[i] = entity.Item{ID: uuid.New().String(), Name: fmt.Sprintf("Item %d", i), Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)}
+	}
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, items)
+	require.NoError(b, err)
+	return order
+}
+
+// Run with: go test -run '^$' -bench OrderItems ./internal/infrastructure/database/test
+func BenchmarkOrderRepositorySql_SaveOrderItems(b *testing.B) {
+	db := setupTestDB(b)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+	ctx := context.Background()
+
+	b.Run("create", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			order := newLargeOrder(b)
+			b.StartTimer()
+
+			require.NoError(b, repo.Save(ctx, order))
+		}
+	})
+
+	b.Run("change one item", func(b *testing.B) {
+		order := newLargeOrder(b)
+		require.NoError(b, repo.Save(ctx, order))
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			quantity := i%10 + 2
+			require.NoError(b, order.UpdateItem(order.Items[i%benchmarkItems].ID, entity.ItemChanges{Quantity: &quantity}))
+			require.NoError(b, repo.Save(ctx, order))
+		}
+	})
+}
+
+// BenchmarkDeleteAndReinsertOrderItems measures the previous strategy of Save, which deleted every
+// item of the order and inserted them back one statement at a time, as a baseline for the above.
+func BenchmarkDeleteAndReinsertOrderItems(b *testing.B) {
+	db := setupTestDB(b)
+	defer db.Close()
+
+	ctx := context.Background()
+	order := newLargeOrder(b)
+	require.NoError(b, database.NewOrderRepositorySql(db).Save(ctx, order))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(b, err)
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM order_items WHERE order_id = $1`, order.ID)
+		require.NoError(b, err)
+		for _, item := range order.Items {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO order_items (id, order_id, name, quantity, price_minor, currency)
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`, item.ID, order.ID, item.Name, item.Quantity, item.Price.Amount, item.Price.Currency)
+			require.NoError(b, err)
+		}
+
+		require.NoError(b, tx.Commit())
+	}
+}
@@ -27,12 +27,13 @@ func TestOrderRepositorySql_Save(t *testing.T) {
 	order := &entity.Order{
 		ID:           orderID,
 		CustomerName: "John Doe",
+		Currency:     entity.DefaultCurrency,
 		Status:       entity.Pending,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 		Items: []entity.Item{
-			{ID: itemID1, Name: "Item 1", Quantity: 1, Price: 10.0},
-			{ID: itemID2, Name: "Item 2", Quantity: 2, Price: 15.0},
+			{ID: itemID1, Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+			{ID: itemID2, Name: "Item 2", Quantity: 2, Price: entity.NewMoney(1500, entity.DefaultCurrency)},
 		},
 	}
 
@@ -45,6 +46,101 @@ func TestOrderRepositorySql_Save(t *testing.T) {
 	assert.Equal(t, order.CustomerName, savedOrder.CustomerName)
 	assert.Equal(t, order.Status, savedOrder.Status)
 	assert.Equal(t, len(order.Items), len(savedOrder.Items))
+	assert.Equal(t, order.Total(), savedOrder.Total())
+}
+
+func TestOrderRepositorySql_SaveDiscounts(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	itemID := uuid.New().String()
+	order := &entity.Order{
+		ID:           uuid.New().String(),
+		CustomerName: "John Doe",
+		Currency:     entity.DefaultCurrency,
+		Status:       entity.Pending,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		Items: []entity.Item{
+			{ID: itemID, ProductID: "sku-a", Name: "Item 1", Quantity: 3, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		},
+	}
+	discounts := []entity.Discount{
+		{Code: "3FOR2", ItemID: itemID, Description: "Third one free", Amount: entity.NewMoney(1000, entity.DefaultCurrency)},
+	}
+	require.NoError(t, order.ApplyDiscounts("3FOR2", discounts))
+
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	savedOrder, err := repo.FindByID(context.Background(), order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "3FOR2", savedOrder.CouponCode)
+	assert.Equal(t, discounts, savedOrder.Discounts)
+	assert.Equal(t, entity.NewMoney(2000, entity.DefaultCurrency), savedOrder.Total())
+}
+
+func TestOrderRepositorySql_SaveTaxes(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: "item1", Name: "Book", Category: "books", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		{ID: "item2", Name: "Radio", Quantity: 1, Price: entity.NewMoney(5000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, order.ApplyTaxes("BR-SP", []entity.Money{
+		entity.NewMoney(0, entity.DefaultCurrency),
+		entity.NewMoney(900, entity.DefaultCurrency),
+	}))
+
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	savedOrder, err := repo.FindByID(context.Background(), order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "BR-SP", savedOrder.TaxJurisdiction)
+	for _, item := range savedOrder.Items {
+		if item.ID == "item1" {
+			assert.Equal(t, "books", item.Category)
+		}
+	}
+	assert.Equal(t, entity.NewMoney(900, entity.DefaultCurrency), savedOrder.Tax())
+	assert.Equal(t, entity.NewMoney(6900, entity.DefaultCurrency), savedOrder.TotalWithTax())
+}
+
+func TestOrderRepositorySql_SaveDeliveryDetails(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: "item1", Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	start := time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)
+	details := entity.DeliveryDetails{
+		ShippingAddress: &entity.Address{Line1: "Av. Paulista, 1000", City: "São Paulo", State: "SP", PostalCode: "01310-100", Country: "BR"},
+		BillingAddress:  &entity.Address{Line1: "Rua Augusta, 500", Line2: "Apto 12", City: "São Paulo", PostalCode: "01305-000", Country: "BR"},
+		DeliveryMethod:  entity.DeliveryExpress,
+		DeliveryWindow:  &entity.DeliveryWindow{Start: start, End: start.Add(4 * time.Hour)},
+	}
+	require.NoError(t, order.UpdateDeliveryDetails(details))
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	savedOrder, err := repo.FindByID(context.Background(), order.ID)
+	require.NoError(t, err)
+	assert.True(t, details.Equal(savedOrder.DeliveryDetails))
+
+	require.NoError(t, savedOrder.UpdateDeliveryDetails(entity.DeliveryDetails{DeliveryMethod: entity.DeliveryPickup}))
+	require.NoError(t, repo.Save(context.Background(), savedOrder))
+
+	savedOrder, err = repo.FindByID(context.Background(), order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DeliveryDetails{DeliveryMethod: entity.DeliveryPickup}, savedOrder.DeliveryDetails)
 }
 
 func TestOrderRepositorySql_FindByID(t *testing.T) {
@@ -59,11 +155,12 @@ func TestOrderRepositorySql_FindByID(t *testing.T) {
 	order := &entity.Order{
 		ID:           orderID,
 		CustomerName: "John Doe",
+		Currency:     entity.DefaultCurrency,
 		Status:       entity.Pending,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 		Items: []entity.Item{
-			{ID: itemID, Name: "Item 1", Quantity: 1, Price: 10.0},
+			{ID: itemID, Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
 		},
 	}
 
@@ -92,21 +189,23 @@ func TestOrderRepositorySql_List(t *testing.T) {
 	order1 := &entity.Order{
 		ID:           orderID1,
 		CustomerName: "John Doe",
+		Currency:     entity.DefaultCurrency,
 		Status:       entity.Pending,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 		Items: []entity.Item{
-			{ID: itemID1, Name: "Item 1", Quantity: 1, Price: 10.0},
+			{ID: itemID1, Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
 		},
 	}
 	order2 := &entity.Order{
 		ID:           orderID2,
 		CustomerName: "Jane Doe",
+		Currency:     entity.DefaultCurrency,
 		Status:       entity.Completed,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 		Items: []entity.Item{
-			{ID: itemID2, Name: "Item 2", Quantity: 2, Price: 15.0},
+			{ID: itemID2, Name: "Item 2", Quantity: 2, Price: entity.NewMoney(1500, entity.DefaultCurrency)},
 		},
 	}
 
@@ -115,9 +214,147 @@ func TestOrderRepositorySql_List(t *testing.T) {
 	err = repo.Save(context.Background(), order2)
 	require.NoError(t, err)
 
-	orders, err := repo.List(context.Background())
+	page, err := repo.List(context.Background(), repository.OrderQuery{Page: 1, Size: 10})
 	require.NoError(t, err)
-	assert.Len(t, orders, 2)
+	assert.Len(t, page.Orders, 2)
+	assert.Equal(t, 2, page.Total)
+	for _, order := range page.Orders {
+		assert.Len(t, order.Items, 1)
+	}
+}
+
+func TestOrderRepositorySql_List_PaginationAndFilters(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		status := entity.Pending
+		if i%2 == 1 {
+			status = entity.Canceled
+		}
+		order := &entity.Order{
+			ID:           uuid.New().String(),
+			CustomerName: "Customer " + string(rune('A'+i)),
+			Status:       status,
+			CreatedAt:    base.Add(time.Duration(i) * time.Minute),
+			UpdatedAt:    base.Add(time.Duration(i) * time.Minute),
+			Items: []entity.Item{
+				{ID: uuid.New().String(), Name: "Item", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+			},
+		}
+		require.NoError(t, repo.Save(context.Background(), order))
+	}
+
+	page, err := repo.List(context.Background(), repository.OrderQuery{
+		Page:          2,
+		Size:          2,
+		SortBy:        repository.SortByCreatedAt,
+		SortDirection: repository.SortAsc,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, page.Total)
+	require.Len(t, page.Orders, 2)
+	assert.Equal(t, "Customer C", page.Orders[0].CustomerName)
+	assert.Equal(t, "Customer D", page.Orders[1].CustomerName)
+
+	canceled := entity.Canceled
+	page, err = repo.List(context.Background(), repository.OrderQuery{Page: 1, Size: 10, Status: &canceled})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+
+	page, err = repo.List(context.Background(), repository.OrderQuery{Page: 1, Size: 10, CustomerName: "customer e"})
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 1)
+	assert.Equal(t, "Customer E", page.Orders[0].CustomerName)
+
+	for _, wildcard := range []string{"%", "customer_e", `\`} {
+		page, err = repo.List(context.Background(), repository.OrderQuery{Page: 1, Size: 10, CustomerName: wildcard})
+		require.NoError(t, err)
+		assert.Zero(t, page.Total, "%q only matches itself", wildcard)
+	}
+
+	from := base.Add(3 * time.Minute)
+	page, err = repo.List(context.Background(), repository.OrderQuery{Page: 1, Size: 10, CreatedFrom: &from})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+}
+
+func TestOrderRepositorySql_List_Keyset(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	for i := 0; i < 5; i++ {
+		order := &entity.Order{
+			ID:           uuid.New().String(),
+			CustomerName: "John Doe",
+			Currency:     entity.DefaultCurrency,
+			Status:       entity.Pending,
+			CreatedAt:    createdAt,
+			UpdatedAt:    createdAt,
+			Items: []entity.Item{
+				{ID: uuid.New().String(), Name: "Item", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+			},
+		}
+		require.NoError(t, repo.Save(context.Background(), order))
+	}
+
+	seen := make(map[string]bool)
+	query := repository.OrderQuery{Size: 2, Keyset: true, SortDirection: repository.SortAsc}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5)
+
+		page, err := repo.List(context.Background(), query)
+		require.NoError(t, err)
+		for _, order := range page.Orders {
+			assert.False(t, seen[order.ID])
+			seen[order.ID] = true
+		}
+
+		if page.NextCursor == nil {
+			break
+		}
+		query.After = page.NextCursor
+	}
+
+	assert.Len(t, seen, 5)
+}
+
+func TestOrderRepositorySql_StatusHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: uuid.New().String(), Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	require.NoError(t, order.UpdateOrderDetails("Jane Doe", order.Items))
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	require.NoError(t, order.ChangeStatus(entity.Canceled, "api", "duplicate order"))
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	history, err := repo.FindStatusHistory(context.Background(), order.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	assert.Nil(t, history[0].From)
+	assert.Equal(t, entity.Pending, history[0].To)
+
+	require.NotNil(t, history[1].From)
+	assert.Equal(t, entity.Pending, *history[1].From)
+	assert.Equal(t, entity.Canceled, history[1].To)
+	assert.Equal(t, "api", history[1].ChangedBy)
+	assert.Equal(t, "duplicate order", history[1].Reason)
 }
 
 func TestOrderRepositorySql_FindByID_NotFound(t *testing.T) {
@@ -131,3 +368,86 @@ func TestOrderRepositorySql_FindByID_NotFound(t *testing.T) {
 	assert.Nil(t, order)
 	assert.Equal(t, repository.ErrNotFound, err)
 }
+
+func TestOrderRepositorySql_Save_ConcurrentModification(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: uuid.New().String(), Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), order))
+	assert.Equal(t, int64(1), order.Version)
+
+	first, err := repo.FindByID(context.Background(), order.ID)
+	require.NoError(t, err)
+	second, err := repo.FindByID(context.Background(), order.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, first.UpdateOrderDetails("Jane Doe", first.Items))
+	require.NoError(t, repo.Save(context.Background(), first))
+	assert.Equal(t, int64(2), first.Version)
+
+	require.NoError(t, second.UpdateOrderDetails("Jim Doe", second.Items))
+	assert.ErrorIs(t, repo.Save(context.Background(), second), repository.ErrConcurrentModification)
+
+	saved, err := repo.FindByID(context.Background(), order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", saved.CustomerName)
+	assert.Equal(t, int64(2), saved.Version)
+}
+
+func TestOrderRepositorySql_Save_ItemChanges(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	keptID, changedID, removedID, addedID := uuid.New().String(), uuid.New().String(), uuid.New().String(), uuid.New().String()
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: keptID, Name: "Kept", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		{ID: changedID, Name: "Changed", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		{ID: removedID, Name: "Removed", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	quantity := 5
+	require.NoError(t, order.UpdateItem(changedID, entity.ItemChanges{Quantity: &quantity}))
+	require.NoError(t, order.RemoveItem(removedID))
+	require.NoError(t, order.AddItem(entity.Item{ID: addedID, Name: "Added", Quantity: 2, Price: entity.NewMoney(500, entity.DefaultCurrency)}))
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	saved, err := repo.FindByID(context.Background(), order.ID)
+	require.NoError(t, err)
+	quantities := make(map[string]int)
+	for _, item := range saved.Items {
+		quantities[item.ID] = item.Quantity
+	}
+	assert.Equal(t, map[string]int{keptID: 1, changedID: 5, addedID: 2}, quantities)
+	assert.Equal(t, order.Total(), saved.Total())
+}
+
+func TestOrderRepositorySql_Save_SameItemIDInTwoOrders(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	for _, customer := range []string{"John Doe", "Jane Doe"} {
+		order, err := entity.NewOrder(uuid.New().String(), customer, entity.DefaultCurrency, []entity.Item{
+			{ID: "1", ProductID: "123459", Name: "Product A", Quantity: 2, Price: entity.NewMoney(1999, entity.DefaultCurrency)},
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(context.Background(), order))
+
+		saved, err := repo.FindByID(context.Background(), order.ID)
+		require.NoError(t, err)
+		require.Len(t, saved.Items, 1)
+		assert.Equal(t, "1", saved.Items[0].ID)
+		assert.Equal(t, "123459", saved.Items[0].ProductID)
+	}
+}
@@ -0,0 +1,127 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/infrastructure/database"
+	"order-service/internal/infrastructure/outbox"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRepositorySql_Save_WritesOutbox(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := database.NewOrderRepositorySql(db)
+
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: uuid.New().String(), Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(context.Background(), order))
+	assert.Empty(t, order.Events())
+
+	var routingKey string
+	var status string
+	err = db.QueryRow(`SELECT routing_key, status FROM outbox WHERE aggregate_id = $1`, order.ID).Scan(&routingKey, &status)
+	require.NoError(t, err)
+	assert.Equal(t, "order_created", routingKey)
+	assert.Equal(t, "pending", status)
+
+	require.NoError(t, repo.Save(context.Background(), order))
+
+	var count int
+	err = db.QueryRow(`SELECT COUNT(*) FROM outbox WHERE aggregate_id = $1`, order.ID).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestOutboxRepositorySql_ClaimAndMark(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	orderRepo := database.NewOrderRepositorySql(db)
+	outboxRepo := database.NewOutboxRepositorySql(db)
+
+	for i := 0; i < 2; i++ {
+		order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+			{ID: uuid.New().String(), Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+		})
+		require.NoError(t, err)
+		require.NoError(t, orderRepo.Save(context.Background(), order))
+	}
+
+	messages, err := outboxRepo.ClaimPending(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "order_created", messages[0].RoutingKey)
+	assert.Contains(t, string(messages[0].Payload), messages[0].AggregateID)
+
+	claimedAgain, err := outboxRepo.ClaimPending(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimedAgain)
+
+	require.NoError(t, outboxRepo.MarkSent(context.Background(), messages[0].ID))
+	require.NoError(t, outboxRepo.MarkFailed(context.Background(), messages[1].ID, 1, time.Now().Add(-time.Second), false, errors.New("broker unavailable")))
+
+	retried, err := outboxRepo.ClaimPending(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, messages[1].ID, retried[0].ID)
+	assert.Equal(t, 1, retried[0].Attempts)
+
+	var status outbox.Status
+	err = db.QueryRow(`SELECT status FROM outbox WHERE id = $1`, messages[0].ID).Scan(&status)
+	require.NoError(t, err)
+	assert.Equal(t, outbox.StatusSent, status)
+}
+
+func TestOutboxRepositorySql_ClaimPending_HoldsBackLaterEventsOfAnOrder(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	outboxRepo := database.NewOutboxRepositorySql(db)
+
+	orderID := uuid.New().String()
+	createdAt := time.Now().Add(-time.Minute)
+	var ids []string
+	for _, routingKey := range []string{"order_created", "order_status_changed", "order_canceled"} {
+		id := uuid.New().String()
+		_, err := db.Exec(`
+			INSERT INTO outbox (id, aggregate_id, routing_key, payload, status, created_at, next_attempt_at)
+			VALUES ($1, $2, $3, '{}', 'pending', $4, $4)
+		`, id, orderID, routingKey, createdAt)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	messages, err := outboxRepo.ClaimPending(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	for i, message := range messages {
+		assert.Equal(t, ids[i], message.ID)
+	}
+
+	require.NoError(t, outboxRepo.MarkFailed(context.Background(), ids[0], 1, time.Now().Add(time.Hour), false, errors.New("broker unavailable")))
+	_, err = db.Exec(`UPDATE outbox SET next_attempt_at = $1 WHERE id = ANY($2::text[])`, time.Now().Add(-time.Second), "{"+ids[1]+","+ids[2]+"}")
+	require.NoError(t, err)
+
+	held, err := outboxRepo.ClaimPending(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, held)
+
+	require.NoError(t, outboxRepo.MarkFailed(context.Background(), ids[0], 1, time.Now().Add(-time.Second), false, errors.New("broker unavailable")))
+
+	retried, err := outboxRepo.ClaimPending(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 3)
+	assert.Equal(t, ids[0], retried[0].ID)
+}
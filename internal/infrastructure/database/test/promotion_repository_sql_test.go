@@ -0,0 +1,95 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
+	"order-service/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromotionRepositorySql_FindByCode(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`
+		INSERT INTO promotions (code, description, rule, product_id, buy_quantity, free_quantity, usage_limit)
+		VALUES ('3FOR2', 'Third one free', 'buy_x_get_y', 'sku-a', 2, 1, 10)
+	`)
+	require.NoError(t, err)
+
+	repo := database.NewPromotionRepositorySql(db)
+	promotion, err := repo.FindByCode(context.Background(), "3FOR2")
+
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Promotion{
+		Code:         "3FOR2",
+		Description:  "Third one free",
+		Rule:         entity.PromotionBuyXGetY,
+		ProductID:    "sku-a",
+		BuyQuantity:  2,
+		FreeQuantity: 1,
+		UsageLimit:   10,
+	}, promotion)
+
+	_, err = repo.FindByCode(context.Background(), "NOPE")
+	assert.ErrorIs(t, err, repository.ErrPromotionNotFound)
+}
+
+func TestPromotionRepositorySql_RedeemRelease(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO promotions (code, rule, percent_off, usage_limit) VALUES ('TEN', 'percentage', 10, 1)`)
+	require.NoError(t, err)
+
+	repo := database.NewPromotionRepositorySql(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Redeem(ctx, "TEN", "order-1"))
+	require.NoError(t, repo.Redeem(ctx, "TEN", "order-1"), "redeeming again for the same order is a no-op")
+	assert.ErrorIs(t, repo.Redeem(ctx, "TEN", "order-2"), entity.ErrPromotionExhausted)
+
+	require.NoError(t, repo.Release(ctx, "TEN", "order-1"))
+	require.NoError(t, repo.Release(ctx, "TEN", "order-1"))
+	require.NoError(t, repo.Redeem(ctx, "TEN", "order-2"))
+
+	promotion, err := repo.FindByCode(ctx, "TEN")
+	require.NoError(t, err)
+	assert.Equal(t, 1, promotion.TimesRedeemed)
+}
+
+func TestPromotionRepositorySql_RedeemInOrderSave(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO promotions (code, rule, percent_off, usage_limit) VALUES ('TEN', 'percentage', 10, 1)`)
+	require.NoError(t, err)
+
+	orders := database.NewOrderRepositorySql(db)
+	repo := database.NewPromotionRepositorySql(db)
+	ctx := context.Background()
+
+	order, err := entity.NewOrder(uuid.New().String(), "John Doe", entity.DefaultCurrency, []entity.Item{
+		{ID: uuid.New().String(), Name: "Item 1", Quantity: 1, Price: entity.NewMoney(1000, entity.DefaultCurrency)},
+	})
+	require.NoError(t, err)
+	redeem := func(ctx context.Context) error { return repo.Redeem(ctx, "TEN", order.ID) }
+	failing := func(context.Context) error { return errors.New("failed") }
+
+	require.Error(t, orders.Save(ctx, order, redeem, failing))
+	promotion, err := repo.FindByCode(ctx, "TEN")
+	require.NoError(t, err)
+	assert.Equal(t, 0, promotion.TimesRedeemed, "the redemption of a failed save is rolled back")
+
+	require.NoError(t, orders.Save(ctx, order, redeem))
+	promotion, err = repo.FindByCode(ctx, "TEN")
+	require.NoError(t, err)
+	assert.Equal(t, 1, promotion.TimesRedeemed)
+}
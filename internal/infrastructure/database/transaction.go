@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// withTx makes the repositories called with the returned context work in tx instead of their own
+// transactions, so the side effects of an order save are committed or rolled back with it.
+func withTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// scopedTx is a transaction begun by beginTx. Commit and Rollback only act on a transaction it began;
+// one it joined is ended by its owner.
+type scopedTx struct {
+	*sql.Tx
+	owned bool
+}
+
+// beginTx joins the transaction of ctx, if there is one, or begins a new one.
+func beginTx(ctx context.Context, db *sql.DB) (scopedTx, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return scopedTx{Tx: tx}, nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return scopedTx{}, err
+	}
+	return scopedTx{Tx: tx, owned: true}, nil
+}
+
+func (t scopedTx) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t scopedTx) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
@@ -0,0 +1,70 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"order-service/internal/domain/exchange"
+)
+
+// rateFile is the on-disk format: every rate is the price of one unit of base in that currency,
+// e.g. {"base": "BRL", "rates": {"USD": "0.18", "EUR": "0.17"}}.
+type rateFile struct {
+	Base  string            `json:"base"`
+	Rates map[string]string `json:"rates"`
+}
+
+type StaticRateProvider struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+func NewStaticRateProvider(base string, rates map[string]string) (*StaticRateProvider, error) {
+	provider := &StaticRateProvider{
+		base:  base,
+		rates: map[string]*big.Rat{base: big.NewRat(1, 1)},
+	}
+
+	for currency, value := range rates {
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %q for %s", value, currency)
+		}
+		provider.rates[currency] = rate
+	}
+
+	return provider, nil
+}
+
+func LoadStaticRateProvider(path string) (*StaticRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rates: %w", err)
+	}
+
+	var file rateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates: %w", err)
+	}
+	if file.Base == "" {
+		return nil, fmt.Errorf("exchange rates file %s has no base currency", path)
+	}
+
+	return NewStaticRateProvider(file.Base, file.Rates)
+}
+
+func (p *StaticRateProvider) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	fromRate, ok := p.rates[from]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s to %s", exchange.ErrRateNotFound, from, to)
+	}
+	toRate, ok := p.rates[to]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s to %s", exchange.ErrRateNotFound, from, to)
+	}
+
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
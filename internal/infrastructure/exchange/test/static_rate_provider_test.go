@@ -0,0 +1,47 @@
+package exchange_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainexchange "order-service/internal/domain/exchange"
+	"order-service/internal/infrastructure/exchange"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticRateProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`{"base": "BRL", "rates": {"USD": "0.2", "EUR": "0.18"}}`), 0o600)
+	require.NoError(t, err)
+
+	provider, err := exchange.LoadStaticRateProvider(path)
+	require.NoError(t, err)
+
+	rate, err := provider.Rate(context.Background(), "BRL", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 5), rate)
+
+	rate, err = provider.Rate(context.Background(), "USD", "BRL")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(5, 1), rate)
+
+	rate, err = provider.Rate(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(9, 10), rate)
+
+	_, err = provider.Rate(context.Background(), "BRL", "JPY")
+	assert.ErrorIs(t, err, domainexchange.ErrRateNotFound)
+}
+
+func TestStaticRateProvider_InvalidRates(t *testing.T) {
+	_, err := exchange.NewStaticRateProvider("BRL", map[string]string{"USD": "zero"})
+	assert.Error(t, err)
+
+	_, err = exchange.NewStaticRateProvider("BRL", map[string]string{"USD": "-0.2"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,5 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS currency;
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_price_minor_positive;
+ALTER TABLE order_items ALTER COLUMN price_minor DROP NOT NULL;
+ALTER TABLE order_items RENAME COLUMN price_minor TO price;
+ALTER TABLE order_items ALTER COLUMN price TYPE NUMERIC USING price / 100.0;
@@ -0,0 +1,5 @@
+ALTER TABLE order_items ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE order_items RENAME COLUMN price TO price_minor;
+ALTER TABLE order_items ALTER COLUMN price_minor SET NOT NULL;
+ALTER TABLE order_items ADD CONSTRAINT order_items_price_minor_positive CHECK (price_minor > 0);
+ALTER TABLE order_items ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
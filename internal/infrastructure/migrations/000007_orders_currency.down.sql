@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_currency_supported;
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+ALTER TABLE orders ADD CONSTRAINT orders_currency_supported CHECK (currency IN ('BRL', 'USD', 'EUR'));
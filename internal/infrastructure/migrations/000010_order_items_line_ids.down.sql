@@ -0,0 +1,9 @@
+-- Irreversible for the item IDs: the up migration moved the client item IDs to product_id and gave
+-- every line a generated ID, and orders saved since may share products, so the old IDs cannot be
+-- put back as a global key. This keeps the generated line IDs and drops product_id; restore a backup
+-- taken before the upgrade to get the old item IDs back.
+DROP INDEX IF EXISTS order_items_product_id_idx;
+ALTER TABLE order_items DROP CONSTRAINT order_items_pkey;
+ALTER TABLE order_items ADD CONSTRAINT order_items_pkey PRIMARY KEY (id);
+ALTER TABLE order_items ALTER COLUMN order_id DROP NOT NULL;
+ALTER TABLE order_items DROP COLUMN IF EXISTS product_id;
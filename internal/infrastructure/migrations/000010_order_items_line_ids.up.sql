@@ -0,0 +1,9 @@
+-- Item IDs used to be the client's product identifiers and a global primary key, so two orders
+-- for the same product collided. Keep them as product IDs and give every line its own ID.
+ALTER TABLE order_items ADD COLUMN product_id VARCHAR(64);
+UPDATE order_items SET product_id = id, id = gen_random_uuid()::text;
+
+ALTER TABLE order_items ALTER COLUMN order_id SET NOT NULL;
+ALTER TABLE order_items DROP CONSTRAINT order_items_pkey;
+ALTER TABLE order_items ADD CONSTRAINT order_items_pkey PRIMARY KEY (order_id, id);
+CREATE INDEX IF NOT EXISTS order_items_product_id_idx ON order_items (product_id);
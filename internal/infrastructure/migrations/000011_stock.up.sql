@@ -0,0 +1,15 @@
+-- Stock is tracked per product; products without a row are not limited. Reservations are made before
+-- an order is first saved, so they do not reference orders.
+CREATE TABLE IF NOT EXISTS stock (
+    product_id VARCHAR(64) PRIMARY KEY,
+    on_hand INT NOT NULL CHECK (on_hand >= 0),
+    reserved INT NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    CHECK (reserved <= on_hand)
+);
+
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    order_id VARCHAR(36) NOT NULL,
+    product_id VARCHAR(64) NOT NULL REFERENCES stock(product_id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (order_id, product_id)
+);
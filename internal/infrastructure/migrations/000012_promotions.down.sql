@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS order_discounts;
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_code;
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotions;
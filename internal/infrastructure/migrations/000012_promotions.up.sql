@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS promotions (
+    code VARCHAR(64) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    rule VARCHAR(20) NOT NULL CHECK (rule IN ('percentage', 'fixed', 'buy_x_get_y')),
+    product_id VARCHAR(64),
+    percent_off INT CHECK (percent_off BETWEEN 1 AND 100),
+    amount_off_minor BIGINT CHECK (amount_off_minor > 0),
+    currency CHAR(3),
+    buy_quantity INT CHECK (buy_quantity > 0),
+    free_quantity INT CHECK (free_quantity > 0),
+    valid_from TIMESTAMP,
+    valid_until TIMESTAMP,
+    usage_limit INT CHECK (usage_limit > 0),
+    times_redeemed INT NOT NULL DEFAULT 0 CHECK (times_redeemed >= 0)
+);
+
+-- Redemptions are made before an order is first saved, so they do not reference orders.
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    code VARCHAR(64) NOT NULL REFERENCES promotions(code) ON DELETE CASCADE,
+    order_id VARCHAR(36) NOT NULL,
+    redeemed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (code, order_id)
+);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS coupon_code VARCHAR(64);
+
+CREATE TABLE IF NOT EXISTS order_discounts (
+    order_id VARCHAR(36) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    code VARCHAR(64) NOT NULL,
+    item_id VARCHAR(36),
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    amount_minor BIGINT NOT NULL CHECK (amount_minor > 0),
+    currency CHAR(3) NOT NULL,
+    PRIMARY KEY (order_id, position)
+);
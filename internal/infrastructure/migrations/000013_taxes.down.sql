@@ -0,0 +1,4 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax_minor;
+ALTER TABLE order_items DROP COLUMN IF EXISTS category;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_jurisdiction;
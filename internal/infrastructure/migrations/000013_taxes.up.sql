@@ -0,0 +1,4 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_jurisdiction VARCHAR(16);
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS category VARCHAR(64);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_minor BIGINT NOT NULL DEFAULT 0 CHECK (tax_minor >= 0);
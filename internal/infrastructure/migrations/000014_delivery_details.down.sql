@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS order_deliveries;
+DROP TABLE IF EXISTS order_addresses;
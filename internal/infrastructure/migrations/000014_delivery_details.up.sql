@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS order_addresses (
+    order_id VARCHAR(36) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('shipping', 'billing')),
+    line1 VARCHAR(255) NOT NULL,
+    line2 VARCHAR(255),
+    city VARCHAR(255) NOT NULL,
+    state VARCHAR(64),
+    postal_code VARCHAR(16),
+    country CHAR(2) NOT NULL,
+    PRIMARY KEY (order_id, kind)
+);
+
+-- Delivery windows are kept with their time zone, as customers ask for them in their own.
+CREATE TABLE IF NOT EXISTS order_deliveries (
+    order_id VARCHAR(36) PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    method VARCHAR(20) CHECK (method IN ('standard', 'express', 'pickup')),
+    window_start TIMESTAMPTZ,
+    window_end TIMESTAMPTZ,
+    CHECK ((window_start IS NULL) = (window_end IS NULL) AND window_start < window_end)
+);
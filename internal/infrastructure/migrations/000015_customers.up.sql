@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS customers (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_customers_normalized_name ON customers (normalized_name);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS customer_id VARCHAR(36) REFERENCES customers(id);
+CREATE INDEX IF NOT EXISTS idx_orders_customer_id_created_at ON orders (customer_id, created_at, id);
+
+-- Orders used to identify customers by name only. Give every name, compared like
+-- entity.NormalizeCustomerName does, a customer named as on its latest order.
+INSERT INTO customers (id, name, normalized_name, created_at, updated_at)
+SELECT gen_random_uuid()::text,
+    (array_agg(btrim(customer_name) ORDER BY created_at DESC NULLS LAST))[1],
+    normalized_name,
+    COALESCE(MIN(created_at), NOW()),
+    COALESCE(MAX(updated_at), NOW())
+FROM (
+    SELECT customer_name, created_at, updated_at,
+        lower(regexp_replace(btrim(customer_name), '\s+', ' ', 'g')) AS normalized_name
+    FROM orders
+    WHERE customer_id IS NULL AND btrim(customer_name) <> ''
+) AS named_orders
+GROUP BY normalized_name;
+
+UPDATE orders AS o
+SET customer_id = c.id
+FROM customers AS c
+WHERE o.customer_id IS NULL
+    AND c.normalized_name = lower(regexp_replace(btrim(o.customer_name), '\s+', ' ', 'g'));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_outbox_pending_aggregate;
+ALTER TABLE outbox DROP COLUMN IF EXISTS sequence;
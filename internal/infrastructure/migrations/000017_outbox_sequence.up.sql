@@ -0,0 +1,5 @@
+-- created_at can tie for events raised together, so the relay orders by insertion sequence and
+-- holds back an order's later events while an earlier one is waiting to be retried.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS sequence BIGSERIAL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending_aggregate ON outbox (aggregate_id, sequence) WHERE status = 'pending';
@@ -0,0 +1,8 @@
+-- Keys used by several callers cannot share the old global key, so only the most recent is kept.
+DELETE FROM idempotency_keys k USING idempotency_keys newer
+WHERE k.key = newer.key AND (k.created_at, k.owner) < (newer.created_at, newer.owner);
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY (key);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS owner;
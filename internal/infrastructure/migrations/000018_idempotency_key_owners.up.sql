@@ -0,0 +1,9 @@
+-- Keys are scoped to the caller that sent them, and a request in progress only holds its key until
+-- locked_until, so a key whose request died is not stuck until it expires. Keys still in progress
+-- when this runs can be taken over at once.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS owner VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP;
+UPDATE idempotency_keys SET locked_until = created_at WHERE response IS NULL;
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY (owner, key);
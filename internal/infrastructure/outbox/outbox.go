@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusSent    Status = "sent"
+	StatusFailed  Status = "failed"
+)
+
+type Message struct {
+	ID          string
+	AggregateID string
+	RoutingKey  string
+	Payload     []byte
+	Attempts    int
+	CreatedAt   time.Time
+}
+
+type Store interface {
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+
+	MarkSent(ctx context.Context, id string) error
+
+	MarkFailed(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, dead bool, cause error) error
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, routingKey string, body []byte) error
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	DefaultPollInterval = time.Second
+	DefaultBatchSize    = 100
+	DefaultMaxAttempts  = 10
+	DefaultLease        = 30 * time.Second
+
+	maxBackoff = 5 * time.Minute
+)
+
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	Lease        time.Duration
+}
+
+type Relay struct {
+	store     Store
+	publisher Publisher
+	config    RelayConfig
+	now       func() time.Time
+}
+
+func NewRelay(store Store, publisher Publisher, config RelayConfig) *Relay {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.Lease <= 0 {
+		config.Lease = DefaultLease
+	}
+
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		config:    config,
+		now:       time.Now,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := r.ProcessBatch(ctx)
+			if err != nil {
+				log.Printf("Error relaying outbox messages: %v", err)
+				break
+			}
+			if processed < r.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	messages, err := r.store.ClaimPending(ctx, r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	// Once a message fails, the later messages of the same aggregate stay leased and are retried
+	// after it, so consumers see the events of an order in the order they were raised.
+	blocked := make(map[string]bool)
+	for _, message := range messages {
+		if blocked[message.AggregateID] {
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, message.RoutingKey, message.Payload); err != nil {
+			blocked[message.AggregateID] = true
+			r.handleFailure(ctx, message, err)
+			continue
+		}
+
+		if err := r.store.MarkSent(ctx, message.ID); err != nil {
+			blocked[message.AggregateID] = true
+			log.Printf("Error marking outbox message %s as sent: %v", message.ID, err)
+		}
+	}
+
+	return len(messages), nil
+}
+
+func (r *Relay) handleFailure(ctx context.Context, message Message, cause error) {
+	attempts := message.Attempts + 1
+	dead := attempts >= r.config.MaxAttempts
+	nextAttemptAt := r.now().Add(backoff(attempts))
+
+	if dead {
+		log.Printf("Outbox message %s failed after %d attempts, giving up: %v", message.ID, attempts, cause)
+	} else {
+		log.Printf("Outbox message %s failed (attempt %d), retrying at %s: %v", message.ID, attempts, nextAttemptAt.Format(time.RFC3339), cause)
+	}
+
+	if err := r.store.MarkFailed(ctx, message.ID, attempts, nextAttemptAt, dead, cause); err != nil {
+		log.Printf("Error marking outbox message %s as failed: %v", message.ID, err)
+	}
+}
+
+func backoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,167 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/infrastructure/outbox"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStore struct {
+	mock.Mock
+}
+
+func (m *mockStore) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	args := m.Called(ctx, limit, lease)
+	return args.Get(0).([]outbox.Message), args.Error(1)
+}
+
+func (m *mockStore) MarkSent(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockStore) MarkFailed(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, dead bool, cause error) error {
+	args := m.Called(ctx, id, attempts, nextAttemptAt, dead, cause)
+	return args.Error(0)
+}
+
+type mockPublisher struct {
+	mock.Mock
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, routingKey string, body []byte) error {
+	args := m.Called(ctx, routingKey, body)
+	return args.Error(0)
+}
+
+func TestRelay_ProcessBatch_PublishesAndMarksSent(t *testing.T) {
+	store := new(mockStore)
+	pub := new(mockPublisher)
+	relay := outbox.NewRelay(store, pub, outbox.RelayConfig{BatchSize: 10})
+
+	messages := []outbox.Message{
+		{ID: "1", RoutingKey: "order_created", Payload: []byte(`{"id":"a"}`)},
+		{ID: "2", RoutingKey: "order_created", Payload: []byte(`{"id":"b"}`)},
+	}
+	store.On("ClaimPending", mock.Anything, 10, outbox.DefaultLease).Return(messages, nil)
+	pub.On("Publish", mock.Anything, "order_created", []byte(`{"id":"a"}`)).Return(nil)
+	pub.On("Publish", mock.Anything, "order_created", []byte(`{"id":"b"}`)).Return(nil)
+	store.On("MarkSent", mock.Anything, "1").Return(nil)
+	store.On("MarkSent", mock.Anything, "2").Return(nil)
+
+	processed, err := relay.ProcessBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	store.AssertExpectations(t)
+	pub.AssertExpectations(t)
+}
+
+func TestRelay_ProcessBatch_SchedulesRetryOnFailure(t *testing.T) {
+	store := new(mockStore)
+	pub := new(mockPublisher)
+	relay := outbox.NewRelay(store, pub, outbox.RelayConfig{MaxAttempts: 3})
+
+	publishErr := errors.New("broker unavailable")
+	store.On("ClaimPending", mock.Anything, outbox.DefaultBatchSize, outbox.DefaultLease).
+		Return([]outbox.Message{{ID: "1", RoutingKey: "order_created", Attempts: 0}}, nil)
+	pub.On("Publish", mock.Anything, "order_created", mock.Anything).Return(publishErr)
+	store.On("MarkFailed", mock.Anything, "1", 1, mock.MatchedBy(func(next time.Time) bool {
+		return next.After(time.Now())
+	}), false, publishErr).Return(nil)
+
+	_, err := relay.ProcessBatch(context.Background())
+
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+	store.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything)
+}
+
+func TestRelay_ProcessBatch_GivesUpAfterMaxAttempts(t *testing.T) {
+	store := new(mockStore)
+	pub := new(mockPublisher)
+	relay := outbox.NewRelay(store, pub, outbox.RelayConfig{MaxAttempts: 3})
+
+	publishErr := errors.New("broker unavailable")
+	store.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).
+		Return([]outbox.Message{{ID: "1", RoutingKey: "order_created", Attempts: 2}}, nil)
+	pub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(publishErr)
+	store.On("MarkFailed", mock.Anything, "1", 3, mock.Anything, true, publishErr).Return(nil)
+
+	_, err := relay.ProcessBatch(context.Background())
+
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+}
+
+func TestRelay_ProcessBatch_HoldsBackLaterMessagesOfAFailedAggregate(t *testing.T) {
+	store := new(mockStore)
+	pub := new(mockPublisher)
+	relay := outbox.NewRelay(store, pub, outbox.RelayConfig{})
+
+	publishErr := errors.New("broker unavailable")
+	store.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).Return([]outbox.Message{
+		{ID: "1", AggregateID: "order-1", RoutingKey: "order_created", Payload: []byte(`{"id":"1"}`)},
+		{ID: "2", AggregateID: "order-1", RoutingKey: "order_canceled", Payload: []byte(`{"id":"2"}`)},
+		{ID: "3", AggregateID: "order-2", RoutingKey: "order_created", Payload: []byte(`{"id":"3"}`)},
+	}, nil)
+	pub.On("Publish", mock.Anything, "order_created", []byte(`{"id":"1"}`)).Return(publishErr)
+	pub.On("Publish", mock.Anything, "order_created", []byte(`{"id":"3"}`)).Return(nil)
+	store.On("MarkFailed", mock.Anything, "1", 1, mock.Anything, false, publishErr).Return(nil)
+	store.On("MarkSent", mock.Anything, "3").Return(nil)
+
+	processed, err := relay.ProcessBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+	store.AssertExpectations(t)
+	pub.AssertExpectations(t)
+	pub.AssertNotCalled(t, "Publish", mock.Anything, "order_canceled", mock.Anything)
+	store.AssertNotCalled(t, "MarkFailed", mock.Anything, "2", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRelay_ProcessBatch_ClaimError(t *testing.T) {
+	store := new(mockStore)
+	pub := new(mockPublisher)
+	relay := outbox.NewRelay(store, pub, outbox.RelayConfig{})
+
+	store.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).
+		Return([]outbox.Message{}, errors.New("database error"))
+
+	processed, err := relay.ProcessBatch(context.Background())
+
+	assert.EqualError(t, err, "database error")
+	assert.Equal(t, 0, processed)
+	pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRelay_Run_StopsWhenContextCanceled(t *testing.T) {
+	store := new(mockStore)
+	pub := new(mockPublisher)
+	relay := outbox.NewRelay(store, pub, outbox.RelayConfig{PollInterval: 10 * time.Millisecond})
+
+	store.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).Return([]outbox.Message{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop after context cancellation")
+	}
+}
@@ -2,30 +2,80 @@ package publisher
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 
-	"order-service/internal/domain/entity"
-
+	"github.com/google/uuid"
 	"github.com/rabbitmq/amqp091-go"
 )
 
+const returnBuffer = 16
+
+// Dialer opens a new connection to the broker. It is used to reconnect after the connection closes.
+type Dialer func() (*amqp091.Connection, error)
+
+type binding struct {
+	queueName  string
+	routingKey string
+}
+
+// RabbitMQPublisher publishes on a channel in confirm mode and reopens the channel, redialing the
+// connection when needed, after the broker closes it.
 type RabbitMQPublisher struct {
+	mu         sync.Mutex
+	dial       Dialer
 	connection *amqp091.Connection
+	ownsConn   bool
 	channel    *amqp091.Channel
+	returns    chan amqp091.Return
 	exchange   string
-	routingKey string
+	bindings   []binding
+}
+
+// NewRabbitMQPublisher publishes to a direct exchange, declaring it. Queues are bound with DeclareQueue.
+func NewRabbitMQPublisher(conn *amqp091.Connection, dial Dialer, exchange string) (*RabbitMQPublisher, error) {
+	publisher := &RabbitMQPublisher{
+		dial:       dial,
+		connection: conn,
+		exchange:   exchange,
+	}
+
+	if err := publisher.openChannel(); err != nil {
+		return nil, err
+	}
+
+	return publisher, nil
 }
 
-func NewRabbitMQPublisher(conn *amqp091.Connection, exchange, routingKey, queueName string) (*RabbitMQPublisher, error) {
-	channel, err := conn.Channel()
+// openChannel opens a confirm mode channel and declares the exchange and the known queues on it.
+// The caller must hold p.mu unless the publisher is not shared yet.
+func (p *RabbitMQPublisher) openChannel() error {
+	if p.connection == nil || p.connection.IsClosed() {
+		if p.dial == nil {
+			return errors.New("connection to broker is closed")
+		}
+		conn, err := p.dial()
+		if err != nil {
+			return err
+		}
+		p.connection = conn
+		p.ownsConn = true
+	}
+
+	channel, err := p.connection.Channel()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open a channel: %w", err)
+		return fmt.Errorf("failed to open a channel: %w", err)
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		channel.Close()
+		return fmt.Errorf("failed to put channel in confirm mode: %w", err)
 	}
 
 	err = channel.ExchangeDeclare(
-		exchange,
+		p.exchange,
 		"direct",
 		true,
 		false,
@@ -34,10 +84,49 @@ func NewRabbitMQPublisher(conn *amqp091.Connection, exchange, routingKey, queueN
 		nil,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to declare an exchange: %w", err)
+		channel.Close()
+		return fmt.Errorf("failed to declare an exchange: %w", err)
+	}
+
+	for _, b := range p.bindings {
+		if err := declareQueue(channel, p.exchange, b.queueName, b.routingKey); err != nil {
+			channel.Close()
+			return err
+		}
+	}
+
+	// Returns are buffered so the connection never blocks on a return a publish stopped waiting for.
+	p.returns = channel.NotifyReturn(make(chan amqp091.Return, returnBuffer))
+	p.channel = channel
+	return nil
+}
+
+func (p *RabbitMQPublisher) ensureChannel() error {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return nil
 	}
 
-	_, err = channel.QueueDeclare(
+	log.Printf("RabbitMQ channel for exchange %s is closed, reopening", p.exchange)
+	return p.openChannel()
+}
+
+func (p *RabbitMQPublisher) DeclareQueue(queueName, routingKey string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.ensureChannel(); err != nil {
+		return err
+	}
+	if err := declareQueue(p.channel, p.exchange, queueName, routingKey); err != nil {
+		return err
+	}
+
+	p.bindings = append(p.bindings, binding{queueName: queueName, routingKey: routingKey})
+	return nil
+}
+
+func declareQueue(channel *amqp091.Channel, exchange, queueName, routingKey string) error {
+	_, err := channel.QueueDeclare(
 		queueName,
 		true,
 		false,
@@ -46,7 +135,7 @@ func NewRabbitMQPublisher(conn *amqp091.Connection, exchange, routingKey, queueN
 		nil,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to declare a queue: %w", err)
+		return fmt.Errorf("failed to declare a queue: %w", err)
 	}
 
 	err = channel.QueueBind(
@@ -57,48 +146,84 @@ func NewRabbitMQPublisher(conn *amqp091.Connection, exchange, routingKey, queueN
 		nil,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to bind queue to exchange: %w", err)
+		return fmt.Errorf("failed to bind queue to exchange: %w", err)
 	}
 
-	return &RabbitMQPublisher{
-		connection: conn,
-		channel:    channel,
-		exchange:   exchange,
-		routingKey: routingKey,
-	}, nil
+	return nil
 }
 
-func (p *RabbitMQPublisher) PublishCreatedOrder(ctx context.Context, order *entity.Order) error {
-	body, err := json.Marshal(order)
-	if err != nil {
-		return fmt.Errorf("failed to marshal order: %w", err)
+// Publish returns once the broker has confirmed the message, so callers only treat it as sent
+// after the broker took responsibility for it. Messages are mandatory: one that no queue is bound
+// for comes back from the broker and fails the publish instead of being dropped.
+func (p *RabbitMQPublisher) Publish(ctx context.Context, routingKey string, body []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.ensureChannel(); err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
-	err = p.channel.PublishWithContext(
+	messageID := uuid.NewString()
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(
 		ctx,
 		p.exchange,
-		p.routingKey,
-		false,
+		routingKey,
+		true,
 		false,
 		amqp091.Publishing{
-			ContentType: "application/json",
-			Body:        body,
+			ContentType:  "application/json",
+			DeliveryMode: amqp091.Persistent,
+			MessageId:    messageID,
+			Body:         body,
 		},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
-	log.Printf("Order %s published to RabbitMQ", order.ID)
-	return nil
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to confirm message: %w", err)
+	}
+	if !acked {
+		return errors.New("message was not confirmed by the broker")
+	}
+
+	return p.checkReturned(messageID, routingKey)
+}
+
+// checkReturned reports whether the broker returned the message. The broker sends the return
+// before the confirmation, so it is already buffered once the message is confirmed; returns of
+// earlier publishes that stopped waiting are skipped.
+func (p *RabbitMQPublisher) checkReturned(messageID, routingKey string) error {
+	for {
+		select {
+		case returned, ok := <-p.returns:
+			if !ok {
+				return nil
+			}
+			if returned.MessageId == messageID {
+				return fmt.Errorf("message with routing key %s was returned by the broker: %s", routingKey, returned.ReplyText)
+			}
+		default:
+			return nil
+		}
+	}
 }
 
 func (p *RabbitMQPublisher) Close() error {
-	if err := p.channel.Close(); err != nil {
-		return fmt.Errorf("failed to close channel: %w", err)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.channel != nil && !p.channel.IsClosed() {
+		if err := p.channel.Close(); err != nil {
+			return fmt.Errorf("failed to close channel: %w", err)
+		}
 	}
-	if err := p.connection.Close(); err != nil {
-		return fmt.Errorf("failed to close connection: %w", err)
+	if p.ownsConn && !p.connection.IsClosed() {
+		if err := p.connection.Close(); err != nil {
+			return fmt.Errorf("failed to close connection: %w", err)
+		}
 	}
 	return nil
 }
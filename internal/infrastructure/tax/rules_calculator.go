@@ -0,0 +1,119 @@
+package tax
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/tax"
+)
+
+// JurisdictionRules are the tax rules of one jurisdiction as written in rule files. Rates are
+// percentages such as "18" or "7.25": Rate applies to every product category without a rate of its
+// own in Categories. Rounding is one of the tax.Rounding modes and defaults to half up.
+type JurisdictionRules struct {
+	Rate       string            `json:"rate"`
+	Categories map[string]string `json:"categories"`
+	Rounding   string            `json:"rounding"`
+}
+
+// rulesFile is the on-disk format, e.g.
+// {"default_jurisdiction": "BR-SP", "jurisdictions": {"BR-SP": {"rate": "18", "categories": {"books": "0"}}}}.
+type rulesFile struct {
+	DefaultJurisdiction string                       `json:"default_jurisdiction"`
+	Jurisdictions       map[string]JurisdictionRules `json:"jurisdictions"`
+}
+
+type jurisdiction struct {
+	rate       *big.Rat
+	categories map[string]*big.Rat
+	rounding   tax.Rounding
+}
+
+// RulesCalculator taxes every line at the rate of its category in the jurisdiction of the order,
+// rounding each line on its own.
+type RulesCalculator struct {
+	defaultJurisdiction string
+	jurisdictions       map[string]jurisdiction
+}
+
+func NewRulesCalculator(defaultJurisdiction string, rules map[string]JurisdictionRules) (*RulesCalculator, error) {
+	calculator := &RulesCalculator{
+		defaultJurisdiction: defaultJurisdiction,
+		jurisdictions:       make(map[string]jurisdiction, len(rules)),
+	}
+
+	for name, rule := range rules {
+		rate, err := parseRate(rule.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("jurisdiction %s: %w", name, err)
+		}
+		rounding, err := tax.ParseRounding(rule.Rounding)
+		if err != nil {
+			return nil, fmt.Errorf("jurisdiction %s: %w", name, err)
+		}
+
+		categories := make(map[string]*big.Rat, len(rule.Categories))
+		for category, value := range rule.Categories {
+			categories[category], err = parseRate(value)
+			if err != nil {
+				return nil, fmt.Errorf("jurisdiction %s, category %s: %w", name, category, err)
+			}
+		}
+
+		calculator.jurisdictions[name] = jurisdiction{rate: rate, categories: categories, rounding: rounding}
+	}
+
+	if _, ok := calculator.jurisdictions[defaultJurisdiction]; !ok {
+		return nil, fmt.Errorf("default tax jurisdiction %q has no rules", defaultJurisdiction)
+	}
+	return calculator, nil
+}
+
+func LoadRulesCalculator(path string) (*RulesCalculator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tax rules: %w", err)
+	}
+
+	var file rulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tax rules: %w", err)
+	}
+
+	return NewRulesCalculator(file.DefaultJurisdiction, file.Jurisdictions)
+}
+
+func (c *RulesCalculator) Calculate(ctx context.Context, name string, lines []tax.Line) (tax.Assessment, error) {
+	if name == "" {
+		name = c.defaultJurisdiction
+	}
+	rules, ok := c.jurisdictions[name]
+	if !ok {
+		return tax.Assessment{}, fmt.Errorf("%w: %s", tax.ErrUnknownJurisdiction, name)
+	}
+
+	assessment := tax.Assessment{Jurisdiction: name, Taxes: make([]entity.Money, len(lines))}
+	for i, line := range lines {
+		rate, ok := rules.categories[line.Category]
+		if !ok {
+			rate = rules.rate
+		}
+
+		amount := new(big.Rat).Mul(big.NewRat(max(line.Amount.Amount, 0), 1), rate)
+		assessment.Taxes[i] = entity.NewMoney(rules.rounding.Round(amount), line.Amount.Currency)
+	}
+	return assessment, nil
+}
+
+// parseRate turns a percentage into the fraction of the amount taxed.
+func parseRate(value string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(value)
+	if !ok || rate.Sign() < 0 {
+		return nil, fmt.Errorf("invalid tax rate %q", value)
+	}
+	return rate.Quo(rate, big.NewRat(100, 1)), nil
+}
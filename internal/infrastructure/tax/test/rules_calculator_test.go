@@ -0,0 +1,101 @@
+package tax_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/domain/entity"
+	domaintax "order-service/internal/domain/tax"
+	"order-service/internal/infrastructure/tax"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesCalculator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tax_rules.json")
+	err := os.WriteFile(path, []byte(`{
+		"default_jurisdiction": "BR-SP",
+		"jurisdictions": {
+			"BR-SP": {"rate": "18", "categories": {"books": "0", "food": "7"}},
+			"US-NY": {"rate": "8.875", "rounding": "half_even"}
+		}
+	}`), 0o600)
+	require.NoError(t, err)
+
+	calculator, err := tax.LoadRulesCalculator(path)
+	require.NoError(t, err)
+
+	lines := []domaintax.Line{
+		{Category: "books", Amount: entity.NewMoney(1999, "BRL")},
+		{Category: "food", Amount: entity.NewMoney(1050, "BRL")},
+		{Category: "toys", Amount: entity.NewMoney(4999, "BRL")},
+	}
+	assessment, err := calculator.Calculate(context.Background(), "", lines)
+	require.NoError(t, err)
+	assert.Equal(t, "BR-SP", assessment.Jurisdiction)
+	assert.Equal(t, []entity.Money{
+		entity.NewMoney(0, "BRL"),
+		entity.NewMoney(74, "BRL"),  // 73.5 rounds half up
+		entity.NewMoney(900, "BRL"), // 899.82
+	}, assessment.Taxes)
+
+	assessment, err = calculator.Calculate(context.Background(), "US-NY", []domaintax.Line{{Amount: entity.NewMoney(400, "USD")}})
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Money{entity.NewMoney(36, "USD")}, assessment.Taxes, "35.5 rounds half to even")
+
+	_, err = calculator.Calculate(context.Background(), "XX", lines)
+	assert.ErrorIs(t, err, domaintax.ErrUnknownJurisdiction)
+}
+
+func TestRulesCalculator_Rounding(t *testing.T) {
+	tests := []struct {
+		rounding string
+		amount   int64
+		expected int64
+	}{
+		{rounding: "half_up", amount: 25, expected: 3},
+		{rounding: "half_up", amount: 24, expected: 2},
+		{rounding: "half_even", amount: 25, expected: 2},
+		{rounding: "half_even", amount: 35, expected: 4},
+		{rounding: "down", amount: 29, expected: 2},
+		{rounding: "up", amount: 21, expected: 3},
+		{rounding: "up", amount: 20, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rounding, func(t *testing.T) {
+			calculator, err := tax.NewRulesCalculator("X", map[string]tax.JurisdictionRules{
+				"X": {Rate: "10", Rounding: tt.rounding},
+			})
+			require.NoError(t, err)
+
+			assessment, err := calculator.Calculate(context.Background(), "X", []domaintax.Line{{Amount: entity.NewMoney(tt.amount, "BRL")}})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, assessment.Taxes[0].Amount)
+		})
+	}
+}
+
+func TestNewRulesCalculator_InvalidRules(t *testing.T) {
+	tests := []struct {
+		name                string
+		rules               map[string]tax.JurisdictionRules
+		defaultJurisdiction string
+	}{
+		{name: "unknown default", defaultJurisdiction: "Y", rules: map[string]tax.JurisdictionRules{"X": {Rate: "10"}}},
+		{name: "invalid rate", defaultJurisdiction: "X", rules: map[string]tax.JurisdictionRules{"X": {Rate: "ten"}}},
+		{name: "negative category rate", defaultJurisdiction: "X", rules: map[string]tax.JurisdictionRules{"X": {Rate: "10", Categories: map[string]string{"food": "-1"}}}},
+		{name: "invalid rounding", defaultJurisdiction: "X", rules: map[string]tax.JurisdictionRules{"X": {Rate: "10", Rounding: "nearest"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tax.NewRulesCalculator(tt.defaultJurisdiction, tt.rules)
+			assert.Error(t, err)
+		})
+	}
+}
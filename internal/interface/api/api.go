@@ -4,26 +4,35 @@ import (
 	"order-service/internal/application/usecase"
 )
 
+// UseCases are the use cases behind the handlers. Only the ones a handler needs have to be set.
+type UseCases struct {
+	CreateOrder           usecase.CreateOrderUseCase
+	UpdateOrder           usecase.UpdateOrderUseCase
+	CancelOrder           usecase.CancelOrderUseCase
+	GetOrder              usecase.GetOrderUseCase
+	ListOrders            usecase.ListOrderUseCase
+	GetOrderTransitions   usecase.GetOrderTransitionsUseCase
+	GetOrderHistory       usecase.GetOrderHistoryUseCase
+	IdempotentCreateOrder usecase.IdempotentCreateOrderUseCase
+	PatchOrder            usecase.PatchOrderUseCase
+	AddOrderItem          usecase.AddOrderItemUseCase
+	UpdateOrderItem       usecase.UpdateOrderItemUseCase
+	RemoveOrderItem       usecase.RemoveOrderItemUseCase
+	CreateCustomer        usecase.CreateCustomerUseCase
+	GetCustomer           usecase.GetCustomerUseCase
+	ListCustomers         usecase.ListCustomersUseCase
+	ListCustomerOrders    usecase.ListCustomerOrdersUseCase
+	IssueAPIKey           usecase.IssueAPIKeyUseCase
+	ListAPIKeys           usecase.ListAPIKeysUseCase
+	GetAPIKey             usecase.GetAPIKeyUseCase
+	RotateAPIKey          usecase.RotateAPIKeyUseCase
+	RevokeAPIKey          usecase.RevokeAPIKeyUseCase
+}
+
 type API struct {
-	createOrderUseCase usecase.CreateOrderUseCase
-	updateOrderUseCase usecase.UpdateOrderUseCase
-	cancelOrderUseCase usecase.CancelOrderUseCase
-	getOrderUseCase    usecase.GetOrderUseCase
-	listOrderUseCase   usecase.ListOrderUseCase
+	useCases UseCases
 }
 
-func NewAPI(
-	createOrderUseCase usecase.CreateOrderUseCase,
-	updateOrderUseCase usecase.UpdateOrderUseCase,
-	cancelOrderUseCase usecase.CancelOrderUseCase,
-	getOrderUseCase usecase.GetOrderUseCase,
-	listOrderUseCase usecase.ListOrderUseCase,
-) *API {
-	return &API{
-		createOrderUseCase: createOrderUseCase,
-		updateOrderUseCase: updateOrderUseCase,
-		cancelOrderUseCase: cancelOrderUseCase,
-		getOrderUseCase:    getOrderUseCase,
-		listOrderUseCase:   listOrderUseCase,
-	}
+func NewAPI(useCases UseCases) *API {
+	return &API{useCases: useCases}
 }
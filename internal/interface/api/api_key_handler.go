@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+
+	"order-service/internal/application/dtos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (api *API) IssueAPIKey(w http.ResponseWriter, r *http.Request) {
+	var input dtos.APIKeyInput
+	if !decodeJSONBody(w, r, &input) {
+		return
+	}
+
+	keyOutput, err := api.useCases.IssueAPIKey.Execute(r.Context(), input)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api-keys/"+keyOutput.ID)
+	respondWithJSON(w, http.StatusCreated, keyOutput)
+}
+
+func (api *API) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	listOutput, err := api.useCases.ListAPIKeys.Execute(r.Context())
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, listOutput)
+}
+
+func (api *API) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondWithInvalidRequest(w, r, "API key ID is required")
+		return
+	}
+
+	keyOutput, err := api.useCases.GetAPIKey.Execute(r.Context(), id)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, keyOutput)
+}
+
+// RotateAPIKey issues the replacement of a key. The body, with the grace period of the old key, is
+// optional.
+func (api *API) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondWithInvalidRequest(w, r, "API key ID is required")
+		return
+	}
+
+	var input dtos.RotateAPIKeyInput
+	if r.ContentLength != 0 && !decodeJSONBody(w, r, &input) {
+		return
+	}
+
+	keyOutput, err := api.useCases.RotateAPIKey.Execute(r.Context(), id, input)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api-keys/"+keyOutput.ID)
+	respondWithJSON(w, http.StatusCreated, keyOutput)
+}
+
+func (api *API) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondWithInvalidRequest(w, r, "API key ID is required")
+		return
+	}
+
+	keyOutput, err := api.useCases.RevokeAPIKey.Execute(r.Context(), id)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, keyOutput)
+}
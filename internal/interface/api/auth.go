@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"order-service/internal/domain/auth"
+)
+
+const (
+	AuthorizationHeader   = "Authorization"
+	WWWAuthenticateHeader = "WWW-Authenticate"
+)
+
+// Authenticate requires every request to carry an Authorization header accepted by the authenticator
+// of its scheme, such as "Bearer", and puts the authenticated principal into the request context.
+// Schemes are matched case-insensitively.
+func Authenticate(authenticators map[string]auth.Authenticator) func(http.Handler) http.Handler {
+	byScheme := make(map[string]auth.Authenticator, len(authenticators))
+	schemes := make([]string, 0, len(authenticators))
+	for scheme, authenticator := range authenticators {
+		byScheme[strings.ToLower(scheme)] = authenticator
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	challenge := strings.Join(schemes, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, credentials, _ := strings.Cut(r.Header.Get(AuthorizationHeader), " ")
+			credentials = strings.TrimSpace(credentials)
+			authenticator, ok := byScheme[strings.ToLower(scheme)]
+			if !ok || credentials == "" {
+				w.Header().Set(WWWAuthenticateHeader, challenge)
+				respondWithError(w, r, auth.ErrUnauthenticated)
+				return
+			}
+
+			principal, err := authenticator.Authenticate(r.Context(), credentials)
+			if err != nil {
+				if errors.Is(err, auth.ErrUnauthenticated) {
+					w.Header().Set(WWWAuthenticateHeader, challenge)
+				}
+				respondWithError(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"order-service/internal/application/dtos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (api *API) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var input dtos.CustomerInput
+	if !decodeJSONBody(w, r, &input) {
+		return
+	}
+
+	customerOutput, err := api.useCases.CreateCustomer.Execute(r.Context(), input)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/customers/"+customerOutput.ID)
+	respondWithJSON(w, http.StatusCreated, customerOutput)
+}
+
+func (api *API) GetCustomer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondWithInvalidRequest(w, r, "Customer ID is required")
+		return
+	}
+
+	customerOutput, err := api.useCases.GetCustomer.Execute(r.Context(), id)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, customerOutput)
+}
+
+func (api *API) ListCustomers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := dtos.ListCustomersInput{Name: query.Get("name")}
+
+	var err error
+	if input.Page, err = parseIntParam(query.Get("page")); err != nil {
+		respondWithInvalidRequest(w, r, fmt.Sprintf("Invalid query: page: %v", err))
+		return
+	}
+	if input.Size, err = parseIntParam(query.Get("size")); err != nil {
+		respondWithInvalidRequest(w, r, fmt.Sprintf("Invalid query: size: %v", err))
+		return
+	}
+
+	listOutput, err := api.useCases.ListCustomers.Execute(r.Context(), input)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, listOutput)
+}
+
+// ListCustomerOrders lists the orders of a customer with the filters and pagination of ListOrders.
+func (api *API) ListCustomerOrders(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondWithInvalidRequest(w, r, "Customer ID is required")
+		return
+	}
+
+	input, err := parseListOrderInput(r)
+	if err != nil {
+		respondWithInvalidRequest(w, r, "Invalid query: "+err.Error())
+		return
+	}
+
+	listOutput, err := api.useCases.ListCustomerOrders.Execute(r.Context(), id, input)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, listOutput)
+}
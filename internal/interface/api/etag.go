@@ -0,0 +1,64 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"order-service/internal/application/usecase"
+)
+
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+var (
+	errPreconditionRequired = errors.New("the If-Match header with the order ETag is required")
+	errInvalidIfMatch       = errors.New(`the If-Match header must be "*" or a single strong ETag such as "3"`)
+)
+
+func formatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseIfMatch returns the order version a conditional request expects.
+func parseIfMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get(IfMatchHeader))
+	switch {
+	case value == "":
+		return 0, errPreconditionRequired
+	case value == "*":
+		return usecase.AnyVersion, nil
+	}
+
+	tag, ok := strings.CutPrefix(value, `"`)
+	if !ok {
+		return 0, errInvalidIfMatch
+	}
+	tag, ok = strings.CutSuffix(tag, `"`)
+	if !ok {
+		return 0, errInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
+
+// requireIfMatch parses the If-Match header, answering the request itself when it is missing or invalid.
+func requireIfMatch(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	version, err := parseIfMatch(r)
+	switch {
+	case errors.Is(err, errPreconditionRequired):
+		respondWithProblem(w, r, NewProblem(http.StatusPreconditionRequired, err.Error()))
+		return 0, false
+	case err != nil:
+		respondWithInvalidRequest(w, r, err.Error())
+		return 0, false
+	}
+	return version, true
+}
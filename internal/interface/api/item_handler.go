@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+
+	"order-service/internal/application/dtos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (api *API) AddOrderItem(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	expectedVersion, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var input dtos.ItemInput
+	if !decodeJSONBody(w, r, &input) {
+		return
+	}
+
+	orderOutput, err := api.useCases.AddOrderItem.Execute(r.Context(), id, expectedVersion, input)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	// Added items are appended, so the new item is the last one.
+	if n := len(orderOutput.Items); n > 0 {
+		w.Header().Set("Location", "/orders/"+url.PathEscape(id)+"/items/"+url.PathEscape(orderOutput.Items[n-1].ID))
+	}
+	w.Header().Set(ETagHeader, formatETag(orderOutput.Version))
+	respondWithJSON(w, http.StatusCreated, orderOutput)
+}
+
+func (api *API) UpdateOrderItem(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	itemID := chi.URLParam(r, "itemId")
+	expectedVersion, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var input dtos.ItemUpdateInput
+	if !decodeJSONBody(w, r, &input) {
+		return
+	}
+
+	orderOutput, err := api.useCases.UpdateOrderItem.Execute(r.Context(), id, itemID, expectedVersion, input)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ETagHeader, formatETag(orderOutput.Version))
+	respondWithJSON(w, http.StatusOK, orderOutput)
+}
+
+func (api *API) RemoveOrderItem(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	itemID := chi.URLParam(r, "itemId")
+	expectedVersion, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	orderOutput, err := api.useCases.RemoveOrderItem.Execute(r.Context(), id, itemID, expectedVersion)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ETagHeader, formatETag(orderOutput.Version))
+	respondWithJSON(w, http.StatusOK, orderOutput)
+}
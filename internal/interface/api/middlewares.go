@@ -2,26 +2,46 @@ package api
 
 import (
 	"net/http"
+	"strings"
+
+	"order-service/internal/domain/auth"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-func RegisterMiddlewares(r *chi.Mux) {
+func RegisterMiddlewares(r *chi.Mux, authenticators map[string]auth.Authenticator) {
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(customCorsMiddleware)
+	if len(authenticators) > 0 {
+		r.Use(Authenticate(authenticators))
+	}
 }
 
+var (
+	corsAllowedMethods = strings.Join([]string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	}, ", ")
+	corsAllowedHeaders = strings.Join([]string{
+		"Content-Type", AuthorizationHeader, IfMatchHeader, IdempotencyKeyHeader,
+	}, ", ")
+	// Browsers only let scripts read these response headers when they are exposed.
+	corsExposedHeaders = strings.Join([]string{
+		ETagHeader, IdempotentReplayedHeader, AcceptPatchHeader, "Location", "Retry-After", WWWAuthenticateHeader,
+	}, ", ")
+)
+
 func customCorsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Methods", corsAllowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
 			w.WriteHeader(http.StatusOK)
 			return
 		}
+		w.Header().Set("Access-Control-Expose-Headers", corsExposedHeaders)
 		next.ServeHTTP(w, r)
 	})
 }
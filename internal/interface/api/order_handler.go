@@ -2,11 +2,30 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"order-service/internal/application/dtos"
+	"order-service/internal/application/patch"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
 
-	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	maxBodyBytes = 1 << 20
+
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	AcceptPatchHeader        = "Accept-Patch"
 )
 
 func respondWithJSON(w http.ResponseWriter, status int, payload interface{}) {
@@ -14,55 +33,85 @@ func respondWithJSON(w http.ResponseWriter, status int, payload interface{}) {
 	w.WriteHeader(status)
 	err := json.NewEncoder(w).Encode(payload)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to encode response: "+err.Error())
+		log.Printf("Error encoding response: %v", err)
 	}
 }
 
-func respondWithError(w http.ResponseWriter, status int, message string) {
-	http.Error(w, message, status)
+// decodeJSONBody decodes the request body into v, answering the request itself when the body is
+// malformed or contains unknown or mistyped fields.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		respondWithInvalidRequest(w, r, "Invalid input: "+err.Error())
+		return false
+	}
+
+	err = validation.Decode(body, v)
+	var fieldErrs validation.Errors
+	switch {
+	case err == nil:
+		return true
+	case errors.As(err, &fieldErrs), errors.Is(err, entity.ErrValidation):
+		respondWithError(w, r, err)
+	default:
+		respondWithInvalidRequest(w, r, "Invalid input: "+err.Error())
+	}
+	return false
 }
 
 func (api *API) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var input dtos.OrderInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid input: "+err.Error())
+	if !decodeJSONBody(w, r, &input) {
 		return
 	}
 
-	if input.CustomerName == "" || len(input.Items) == 0 {
-		respondWithError(w, http.StatusBadRequest, "Customer name and items are required")
-		return
+	var orderOutput dtos.OrderOutput
+	var err error
+	if key := r.Header.Get(IdempotencyKeyHeader); key != "" && api.useCases.IdempotentCreateOrder != nil {
+		var replayed bool
+		orderOutput, replayed, err = api.useCases.IdempotentCreateOrder.Execute(r.Context(), key, input)
+		if replayed {
+			w.Header().Set(IdempotentReplayedHeader, "true")
+		}
+	} else {
+		orderOutput, err = api.useCases.CreateOrder.Execute(r.Context(), input)
 	}
-
-	orderOutput, err := api.createOrderUseCase.Execute(r.Context(), input)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to create order: "+err.Error())
+		respondWithError(w, r, err)
 		return
 	}
 
+	w.Header().Set(ETagHeader, formatETag(orderOutput.Version))
 	respondWithJSON(w, http.StatusCreated, orderOutput)
 }
 
 func (api *API) GetOrder(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		respondWithError(w, http.StatusBadRequest, "Order ID is required")
+		respondWithInvalidRequest(w, r, "Order ID is required")
 		return
 	}
 
-	orderOutput, err := api.getOrderUseCase.Execute(r.Context(), id)
+	orderOutput, err := api.useCases.GetOrder.Execute(r.Context(), id, r.URL.Query().Get("currency"))
 	if err != nil {
-		respondWithError(w, http.StatusNotFound, "Order not found: "+err.Error())
+		respondWithError(w, r, err)
 		return
 	}
 
+	w.Header().Set(ETagHeader, formatETag(orderOutput.Version))
 	respondWithJSON(w, http.StatusOK, orderOutput)
 }
 
 func (api *API) ListOrders(w http.ResponseWriter, r *http.Request) {
-	listOutput, err := api.listOrderUseCase.Execute(r.Context())
+	input, err := parseListOrderInput(r)
+	if err != nil {
+		respondWithInvalidRequest(w, r, "Invalid query: "+err.Error())
+		return
+	}
+
+	listOutput, err := api.useCases.ListOrders.Execute(r.Context(), input)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to list orders")
+		respondWithError(w, r, err)
 		return
 	}
 
@@ -71,34 +120,165 @@ func (api *API) ListOrders(w http.ResponseWriter, r *http.Request) {
 
 func (api *API) UpdateOrder(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	expectedVersion, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	var input dtos.OrderInput
+	if !decodeJSONBody(w, r, &input) {
+		return
+	}
+
+	orderOutput, err := api.useCases.UpdateOrder.Execute(r.Context(), id, expectedVersion, input)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	w.Header().Set(ETagHeader, formatETag(orderOutput.Version))
+	respondWithJSON(w, http.StatusOK, orderOutput)
+}
+
+// PatchOrder applies a JSON Merge Patch or JSON Patch, chosen by the Content-Type, to an order.
+func (api *API) PatchOrder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	format, err := patchFormat(r)
+	if err != nil {
+		w.Header().Set(AcceptPatchHeader, acceptPatch())
+		respondWithError(w, r, err)
+		return
+	}
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid input: "+err.Error())
+	expectedVersion, ok := requireIfMatch(w, r)
+	if !ok {
 		return
 	}
 
-	if input.CustomerName == "" || len(input.Items) == 0 {
-		respondWithError(w, http.StatusBadRequest, "Customer name and items are required")
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		respondWithInvalidRequest(w, r, "Invalid input: "+err.Error())
 		return
 	}
 
-	orderOutput, err := api.updateOrderUseCase.Execute(r.Context(), id, input)
+	orderOutput, err := api.useCases.PatchOrder.Execute(r.Context(), id, expectedVersion, dtos.OrderPatchInput{Format: format, Patch: body})
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to update order")
+		respondWithError(w, r, err)
 		return
 	}
 
+	w.Header().Set(ETagHeader, formatETag(orderOutput.Version))
 	respondWithJSON(w, http.StatusOK, orderOutput)
 }
 
+func patchFormat(r *http.Request) (patch.Format, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", patch.ErrUnsupportedFormat, err)
+	}
+	return patch.ParseFormat(mediaType)
+}
+
+func acceptPatch() string {
+	formats := make([]string, 0, len(patch.Formats))
+	for _, format := range patch.Formats {
+		formats = append(formats, string(format))
+	}
+	return strings.Join(formats, ", ")
+}
+
 func (api *API) CancelOrder(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	orderOutput, err := api.cancelOrderUseCase.Execute(r.Context(), id)
+	expectedVersion, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	orderOutput, err := api.useCases.CancelOrder.Execute(r.Context(), id, expectedVersion, r.URL.Query().Get("reason"))
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to cancel order")
+		respondWithError(w, r, err)
 		return
 	}
 
+	w.Header().Set(ETagHeader, formatETag(orderOutput.Version))
 	respondWithJSON(w, http.StatusOK, orderOutput)
 }
+
+func (api *API) GetOrderTransitions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondWithInvalidRequest(w, r, "Order ID is required")
+		return
+	}
+
+	transitionsOutput, err := api.useCases.GetOrderTransitions.Execute(r.Context(), id)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, transitionsOutput)
+}
+
+func (api *API) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondWithInvalidRequest(w, r, "Order ID is required")
+		return
+	}
+
+	historyOutput, err := api.useCases.GetOrderHistory.Execute(r.Context(), id)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, historyOutput)
+}
+
+func parseListOrderInput(r *http.Request) (dtos.ListOrderInput, error) {
+	query := r.URL.Query()
+	input := dtos.ListOrderInput{
+		Status:        query.Get("status"),
+		CustomerID:    query.Get("customer_id"),
+		CustomerName:  query.Get("customer_name"),
+		SortBy:        query.Get("sort"),
+		SortDirection: query.Get("order"),
+		Keyset:        query.Get("pagination") == "cursor",
+		Cursor:        query.Get("cursor"),
+	}
+
+	var err error
+	if input.Page, err = parseIntParam(query.Get("page")); err != nil {
+		return dtos.ListOrderInput{}, fmt.Errorf("page: %w", err)
+	}
+	if input.Size, err = parseIntParam(query.Get("size")); err != nil {
+		return dtos.ListOrderInput{}, fmt.Errorf("size: %w", err)
+	}
+	if input.CreatedFrom, err = parseTimeParam(query.Get("created_from")); err != nil {
+		return dtos.ListOrderInput{}, fmt.Errorf("created_from: %w", err)
+	}
+	if input.CreatedTo, err = parseTimeParam(query.Get("created_to")); err != nil {
+		return dtos.ListOrderInput{}, fmt.Errorf("created_to: %w", err)
+	}
+
+	return input, nil
+}
+
+func parseIntParam(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+
+	"order-service/internal/application/patch"
+	"order-service/internal/application/usecase"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/catalog"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+
+	ProblemTypeInvalidRequest       = "/problems/invalid-request"
+	ProblemTypeUnauthorized         = "/problems/unauthorized"
+	ProblemTypeForbidden            = "/problems/forbidden"
+	ProblemTypeNotFound             = "/problems/not-found"
+	ProblemTypeConflict             = "/problems/conflict"
+	ProblemTypePreconditionFailed   = "/problems/precondition-failed"
+	ProblemTypePreconditionRequired = "/problems/precondition-required"
+	ProblemTypeUnsupportedMedia     = "/problems/unsupported-media-type"
+	ProblemTypeValidation           = "/problems/validation"
+	ProblemTypeUnavailable          = "/problems/service-unavailable"
+	ProblemTypeInternal             = "about:blank"
+
+	retryAfterSeconds = "5"
+)
+
+// Problem is an RFC 7807 problem details document. Retryable tells clients whether sending the
+// same request again may succeed.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Retryable bool   `json:"retryable"`
+
+	Errors []validation.FieldError `json:"errors,omitempty"`
+}
+
+func NewProblem(status int, detail string) Problem {
+	problem := Problem{
+		Type:   problemType(status),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+	problem.Retryable = status == http.StatusServiceUnavailable
+	return problem
+}
+
+// ProblemFromError maps use case and domain errors to the problem returned to clients.
+func ProblemFromError(err error) Problem {
+	var fieldErrs validation.Errors
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return NewProblem(http.StatusUnauthorized, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		return NewProblem(http.StatusForbidden, err.Error())
+	case errors.Is(err, usecase.ErrOrderNotFound), errors.Is(err, repository.ErrNotFound), errors.Is(err, entity.ErrItemNotFound),
+		errors.Is(err, usecase.ErrCustomerNotFound), errors.Is(err, repository.ErrCustomerNotFound),
+		errors.Is(err, usecase.ErrAPIKeyNotFound), errors.Is(err, repository.ErrAPIKeyNotFound):
+		return NewProblem(http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrConcurrentModification):
+		return NewProblem(http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, usecase.ErrIdempotencyKeyInProgress):
+		problem := NewProblem(http.StatusConflict, err.Error())
+		problem.Retryable = true
+		return problem
+	case errors.Is(err, entity.ErrInvalidTransition), errors.Is(err, entity.ErrOrderNotPending), errors.Is(err, usecase.ErrOrderNotEditable),
+		errors.Is(err, usecase.ErrOrderAlreadyCanceled), errors.Is(err, usecase.ErrAPIKeyInactive):
+		return NewProblem(http.StatusConflict, err.Error())
+	case errors.Is(err, patch.ErrNotApplicable), errors.Is(err, entity.ErrDuplicateItem), errors.Is(err, entity.ErrLastItem),
+		errors.Is(err, inventory.ErrInsufficientStock):
+		return NewProblem(http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrInvalidListQuery), errors.Is(err, usecase.ErrInvalidIdempotencyKey), errors.Is(err, patch.ErrInvalidPatch):
+		return NewProblem(http.StatusBadRequest, err.Error())
+	case errors.Is(err, patch.ErrUnsupportedFormat):
+		return NewProblem(http.StatusUnsupportedMediaType, err.Error())
+	case errors.As(err, &fieldErrs):
+		problem := NewProblem(http.StatusUnprocessableEntity, "The request contains invalid fields")
+		problem.Errors = fieldErrs
+		return problem
+	case errors.Is(err, entity.ErrValidation), errors.Is(err, usecase.ErrIdempotencyKeyReused), errors.Is(err, usecase.ErrConversionUnavailable):
+		return NewProblem(http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, catalog.ErrCatalogUnavailable):
+		return NewProblem(http.StatusServiceUnavailable, "The product catalog is temporarily unavailable, please retry later")
+	case isTransient(err):
+		return NewProblem(http.StatusServiceUnavailable, "The service is temporarily unavailable, please retry later")
+	default:
+		return NewProblem(http.StatusInternalServerError, "An unexpected error occurred")
+	}
+}
+
+func isTransient(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.As(err, &netErr)
+}
+
+func problemType(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ProblemTypeInvalidRequest
+	case http.StatusUnauthorized:
+		return ProblemTypeUnauthorized
+	case http.StatusForbidden:
+		return ProblemTypeForbidden
+	case http.StatusNotFound:
+		return ProblemTypeNotFound
+	case http.StatusConflict:
+		return ProblemTypeConflict
+	case http.StatusPreconditionFailed:
+		return ProblemTypePreconditionFailed
+	case http.StatusPreconditionRequired:
+		return ProblemTypePreconditionRequired
+	case http.StatusUnsupportedMediaType:
+		return ProblemTypeUnsupportedMedia
+	case http.StatusUnprocessableEntity:
+		return ProblemTypeValidation
+	case http.StatusServiceUnavailable:
+		return ProblemTypeUnavailable
+	default:
+		return ProblemTypeInternal
+	}
+}
+
+func respondWithProblem(w http.ResponseWriter, r *http.Request, problem Problem) {
+	problem.Instance = r.URL.Path
+	if problem.Status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", retryAfterSeconds)
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		log.Printf("Error encoding problem response: %v", err)
+	}
+}
+
+// respondWithError answers with the problem mapped from a use case error, logging unexpected ones.
+func respondWithError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := ProblemFromError(err)
+	if problem.Status >= http.StatusInternalServerError {
+		log.Printf("%s %s failed: %v", r.Method, r.URL.Path, err)
+	}
+	respondWithProblem(w, r, problem)
+}
+
+// respondWithInvalidRequest answers with a problem for a request that could not be parsed.
+func respondWithInvalidRequest(w http.ResponseWriter, r *http.Request, detail string) {
+	respondWithProblem(w, r, NewProblem(http.StatusBadRequest, detail))
+}
@@ -1,19 +1,44 @@
 package api
 
 import (
+	"order-service/internal/domain/auth"
+
 	"github.com/go-chi/chi/v5"
 )
 
-func NewRouter(api *API) *chi.Mux {
+// NewRouter routes the API, authenticating every request with the authenticators by scheme. Without
+// authenticators the API is open to anyone, which is only meant for local development.
+func NewRouter(api *API, authenticators map[string]auth.Authenticator) *chi.Mux {
 	r := chi.NewRouter()
-	RegisterMiddlewares(r)
+	RegisterMiddlewares(r, authenticators)
 
 	r.Route("/orders", func(r chi.Router) {
 		r.Post("/", api.CreateOrder)
 		r.Get("/", api.ListOrders)
 		r.Get("/{id}", api.GetOrder)
 		r.Put("/{id}", api.UpdateOrder)
+		r.Patch("/{id}", api.PatchOrder)
 		r.Delete("/{id}", api.CancelOrder)
+		r.Get("/{id}/transitions", api.GetOrderTransitions)
+		r.Get("/{id}/history", api.GetOrderHistory)
+		r.Post("/{id}/items", api.AddOrderItem)
+		r.Patch("/{id}/items/{itemId}", api.UpdateOrderItem)
+		r.Delete("/{id}/items/{itemId}", api.RemoveOrderItem)
+	})
+
+	r.Route("/customers", func(r chi.Router) {
+		r.Post("/", api.CreateCustomer)
+		r.Get("/", api.ListCustomers)
+		r.Get("/{id}", api.GetCustomer)
+		r.Get("/{id}/orders", api.ListCustomerOrders)
+	})
+
+	r.Route("/api-keys", func(r chi.Router) {
+		r.Post("/", api.IssueAPIKey)
+		r.Get("/", api.ListAPIKeys)
+		r.Get("/{id}", api.GetAPIKey)
+		r.Post("/{id}/rotate", api.RotateAPIKey)
+		r.Delete("/{id}", api.RevokeAPIKey)
 	})
 
 	return r
@@ -0,0 +1,129 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	"order-service/internal/interface/api"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockIssueAPIKeyUseCase struct {
+	input  dtos.APIKeyInput
+	output dtos.IssuedAPIKeyOutput
+}
+
+func (m *mockIssueAPIKeyUseCase) Execute(ctx context.Context, input dtos.APIKeyInput) (dtos.IssuedAPIKeyOutput, error) {
+	m.input = input
+	return m.output, nil
+}
+
+type mockRotateAPIKeyUseCase struct {
+	id     string
+	input  dtos.RotateAPIKeyInput
+	output dtos.IssuedAPIKeyOutput
+	err    error
+}
+
+func (m *mockRotateAPIKeyUseCase) Execute(ctx context.Context, id string, input dtos.RotateAPIKeyInput) (dtos.IssuedAPIKeyOutput, error) {
+	m.id = id
+	m.input = input
+	return m.output, m.err
+}
+
+type mockGetAPIKeyUseCase struct {
+	id     string
+	output dtos.APIKeyOutput
+	err    error
+}
+
+func (m *mockGetAPIKeyUseCase) Execute(ctx context.Context, id string) (dtos.APIKeyOutput, error) {
+	m.id = id
+	return m.output, m.err
+}
+
+func TestIssueAPIKey(t *testing.T) {
+	mockUseCase := &mockIssueAPIKeyUseCase{output: dtos.IssuedAPIKeyOutput{APIKeyOutput: dtos.APIKeyOutput{ID: "k1"}, Key: "osk_secret"}}
+	handlers := api.NewAPI(api.UseCases{IssueAPIKey: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewReader([]byte(`{"name": "nightly export", "scopes": ["orders:read"]}`)))
+	rec := httptest.NewRecorder()
+	handlers.IssueAPIKey(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/api-keys/k1", rec.Header().Get("Location"))
+	assert.Equal(t, dtos.APIKeyInput{Name: "nightly export", Scopes: []string{"orders:read"}}, mockUseCase.input)
+	assert.Contains(t, rec.Body.String(), `"key":"osk_secret"`)
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  []byte
+		input dtos.RotateAPIKeyInput
+	}{
+		{name: "without body"},
+		{name: "with grace period", body: []byte(`{"grace_period": "24h"}`), input: dtos.RotateAPIKeyInput{GracePeriod: "24h"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase := &mockRotateAPIKeyUseCase{output: dtos.IssuedAPIKeyOutput{APIKeyOutput: dtos.APIKeyOutput{ID: "k2"}, Key: "osk_secret"}}
+			handlers := api.NewAPI(api.UseCases{RotateAPIKey: mockUseCase})
+
+			req := httptest.NewRequest(http.MethodPost, "/api-keys/k1/rotate", bytes.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			r := chi.NewRouter()
+			r.Post("/api-keys/{id}/rotate", handlers.RotateAPIKey)
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Equal(t, "/api-keys/k2", rec.Header().Get("Location"))
+			assert.Equal(t, "k1", mockUseCase.id)
+			assert.Equal(t, tt.input, mockUseCase.input)
+		})
+	}
+}
+
+func TestRotateAPIKey_Inactive(t *testing.T) {
+	mockUseCase := &mockRotateAPIKeyUseCase{err: usecase.ErrAPIKeyInactive}
+	handlers := api.NewAPI(api.UseCases{RotateAPIKey: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodPost, "/api-keys/k1/rotate", nil)
+	rec := httptest.NewRecorder()
+	r := chi.NewRouter()
+	r.Post("/api-keys/{id}/rotate", handlers.RotateAPIKey)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var problem api.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, api.ProblemTypeConflict, problem.Type)
+}
+
+func TestGetAPIKey(t *testing.T) {
+	mockUseCase := &mockGetAPIKeyUseCase{output: dtos.APIKeyOutput{ID: "k1", Name: "nightly export"}}
+	handlers := api.NewAPI(api.UseCases{GetAPIKey: mockUseCase})
+
+	rec := httptest.NewRecorder()
+	api.NewRouter(handlers, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api-keys/k1", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "k1", mockUseCase.id)
+	assert.Contains(t, rec.Body.String(), `"name":"nightly export"`)
+
+	mockUseCase.err = usecase.ErrAPIKeyNotFound
+	rec = httptest.NewRecorder()
+	api.NewRouter(handlers, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api-keys/k9", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,95 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/domain/auth"
+	"order-service/internal/interface/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAuthenticator struct {
+	principals map[string]auth.Principal
+	err        error
+}
+
+func (s stubAuthenticator) Authenticate(ctx context.Context, credentials string) (auth.Principal, error) {
+	if s.err != nil {
+		return auth.Principal{}, s.err
+	}
+	principal, ok := s.principals[credentials]
+	if !ok {
+		return auth.Principal{}, fmt.Errorf("%w: unknown token", auth.ErrUnauthenticated)
+	}
+	return principal, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	authenticators := map[string]auth.Authenticator{
+		"Bearer": stubAuthenticator{principals: map[string]auth.Principal{"token-1": {Subject: "user-1", CustomerID: "c1"}}},
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+	}{
+		{name: "valid token", authorization: "Bearer token-1", status: http.StatusOK},
+		{name: "scheme is case-insensitive", authorization: "bearer token-1", status: http.StatusOK},
+		{name: "missing header", status: http.StatusUnauthorized},
+		{name: "unknown scheme", authorization: "Basic dXNlcjpwYXNz", status: http.StatusUnauthorized},
+		{name: "missing token", authorization: "Bearer ", status: http.StatusUnauthorized},
+		{name: "invalid token", authorization: "Bearer token-2", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var principal auth.Principal
+			handler := api.Authenticate(authenticators)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, _ = auth.FromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			if tt.authorization != "" {
+				req.Header.Set(api.AuthorizationHeader, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, auth.Principal{Subject: "user-1", CustomerID: "c1"}, principal)
+				return
+			}
+
+			assert.Equal(t, "Bearer", rec.Header().Get(api.WWWAuthenticateHeader))
+			var problem api.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, api.ProblemTypeUnauthorized, problem.Type)
+		})
+	}
+}
+
+func TestAuthenticate_AuthenticatorFailure(t *testing.T) {
+	authenticators := map[string]auth.Authenticator{"Bearer": stubAuthenticator{err: errors.New("key store unreachable")}}
+	handler := api.Authenticate(authenticators)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("the request must not reach the handler")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set(api.AuthorizationHeader, "Bearer token-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Empty(t, rec.Header().Get(api.WWWAuthenticateHeader))
+}
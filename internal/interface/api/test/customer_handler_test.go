@@ -0,0 +1,86 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/application/usecase"
+	"order-service/internal/interface/api"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCreateCustomerUseCase struct {
+	input  dtos.CustomerInput
+	output dtos.CustomerOutput
+}
+
+func (m *mockCreateCustomerUseCase) Execute(ctx context.Context, input dtos.CustomerInput) (dtos.CustomerOutput, error) {
+	m.input = input
+	return m.output, nil
+}
+
+type mockListCustomerOrdersUseCase struct {
+	customerID string
+	input      dtos.ListOrderInput
+	output     dtos.ListOrderOutput
+	err        error
+}
+
+func (m *mockListCustomerOrdersUseCase) Execute(ctx context.Context, customerID string, input dtos.ListOrderInput) (dtos.ListOrderOutput, error) {
+	m.customerID = customerID
+	m.input = input
+	return m.output, m.err
+}
+
+func TestCreateCustomer_Success(t *testing.T) {
+	mockUseCase := &mockCreateCustomerUseCase{output: dtos.CustomerOutput{ID: "c1", Name: "John Doe"}}
+	handlers := api.NewAPI(api.UseCases{CreateCustomer: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodPost, "/customers", bytes.NewReader([]byte(`{"name": "John Doe", "email": "john@example.com"}`)))
+	rec := httptest.NewRecorder()
+	handlers.CreateCustomer(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/customers/c1", rec.Header().Get("Location"))
+	assert.Equal(t, dtos.CustomerInput{Name: "John Doe", Email: "john@example.com"}, mockUseCase.input)
+}
+
+func TestListCustomerOrders(t *testing.T) {
+	mockUseCase := &mockListCustomerOrdersUseCase{output: dtos.ListOrderOutput{Page: 2, Size: 5, Orders: []dtos.OrderOutput{}}}
+	handlers := api.NewAPI(api.UseCases{ListCustomerOrders: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/customers/c1/orders?status=pending&page=2&size=5", nil)
+	rec := httptest.NewRecorder()
+	r := chi.NewRouter()
+	r.Get("/customers/{id}/orders", handlers.ListCustomerOrders)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "c1", mockUseCase.customerID)
+	assert.Equal(t, "pending", mockUseCase.input.Status)
+	assert.Equal(t, 2, mockUseCase.input.Page)
+}
+
+func TestListCustomerOrders_UnknownCustomer(t *testing.T) {
+	mockUseCase := &mockListCustomerOrdersUseCase{err: usecase.ErrCustomerNotFound}
+	handlers := api.NewAPI(api.UseCases{ListCustomerOrders: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/customers/c9/orders", nil)
+	rec := httptest.NewRecorder()
+	r := chi.NewRouter()
+	r.Get("/customers/{id}/orders", handlers.ListCustomerOrders)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	var problem api.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, api.ProblemTypeNotFound, problem.Type)
+}
@@ -0,0 +1,128 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/domain/entity"
+	"order-service/internal/interface/api"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAddOrderItemUseCase struct {
+	output dtos.OrderOutput
+	err    error
+	input  dtos.ItemInput
+}
+
+func (m *mockAddOrderItemUseCase) Execute(ctx context.Context, orderID string, expectedVersion int64, input dtos.ItemInput) (dtos.OrderOutput, error) {
+	m.input = input
+	return m.output, m.err
+}
+
+type mockUpdateOrderItemUseCase struct {
+	output dtos.OrderOutput
+	err    error
+	itemID string
+	input  dtos.ItemUpdateInput
+}
+
+func (m *mockUpdateOrderItemUseCase) Execute(ctx context.Context, orderID, itemID string, expectedVersion int64, input dtos.ItemUpdateInput) (dtos.OrderOutput, error) {
+	m.itemID = itemID
+	m.input = input
+	return m.output, m.err
+}
+
+type mockRemoveOrderItemUseCase struct {
+	output  dtos.OrderOutput
+	err     error
+	orderID string
+	itemID  string
+}
+
+func (m *mockRemoveOrderItemUseCase) Execute(ctx context.Context, orderID, itemID string, expectedVersion int64) (dtos.OrderOutput, error) {
+	m.orderID = orderID
+	m.itemID = itemID
+	return m.output, m.err
+}
+
+// newItemRouter routes like the service, so the handlers read the URL parameters of its router.
+func newItemRouter(handlers *api.API) *chi.Mux {
+	return api.NewRouter(handlers, nil)
+}
+
+func TestAddOrderItem_Success(t *testing.T) {
+	mockUseCase := &mockAddOrderItemUseCase{
+		output: dtos.OrderOutput{ID: "1", Version: 2, Items: []dtos.ItemOutput{{ID: "a"}, {ID: "b"}}},
+	}
+	handlers := api.NewAPI(api.UseCases{AddOrderItem: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/1/items", bytes.NewReader([]byte(`{"id": "b", "name": "Item B", "quantity": 1, "price": 9.99}`)))
+	req.Header.Set("If-Match", `"1"`)
+	rec := httptest.NewRecorder()
+	newItemRouter(handlers).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/orders/1/items/b", rec.Header().Get("Location"))
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	assert.Equal(t, "Item B", mockUseCase.input.Name)
+}
+
+func TestUpdateOrderItem_Success(t *testing.T) {
+	mockUseCase := &mockUpdateOrderItemUseCase{output: dtos.OrderOutput{ID: "1", Version: 2}}
+	handlers := api.NewAPI(api.UseCases{UpdateOrderItem: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodPatch, "/orders/1/items/a", bytes.NewReader([]byte(`{"quantity": 5}`)))
+	req.Header.Set("If-Match", `"1"`)
+	rec := httptest.NewRecorder()
+	newItemRouter(handlers).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "a", mockUseCase.itemID)
+	require.NotNil(t, mockUseCase.input.Quantity)
+	assert.Equal(t, 5, *mockUseCase.input.Quantity)
+	assert.Nil(t, mockUseCase.input.Price)
+}
+
+func TestRemoveOrderItem_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"last item", entity.ErrLastItem, http.StatusConflict},
+		{"unknown item", entity.ErrItemNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase := &mockRemoveOrderItemUseCase{err: tt.err}
+			handlers := api.NewAPI(api.UseCases{RemoveOrderItem: mockUseCase})
+
+			req := httptest.NewRequest(http.MethodDelete, "/orders/1/items/a", nil)
+			req.Header.Set("If-Match", "*")
+			rec := httptest.NewRecorder()
+			newItemRouter(handlers).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "a", mockUseCase.itemID)
+		})
+	}
+}
+
+func TestRemoveOrderItem_RequiresIfMatch(t *testing.T) {
+	handlers := api.NewAPI(api.UseCases{RemoveOrderItem: &mockRemoveOrderItemUseCase{}})
+
+	req := httptest.NewRequest(http.MethodDelete, "/orders/1/items/a", nil)
+	rec := httptest.NewRecorder()
+	newItemRouter(handlers).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+}
@@ -5,15 +5,22 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"order-service/internal/application/dtos"
+	"order-service/internal/application/patch"
+	"order-service/internal/application/usecase"
+	"order-service/internal/application/validation"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/repository"
 	"order-service/internal/interface/api"
 
-	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockCreateOrderUseCase struct {
@@ -27,9 +34,9 @@ func (m *mockCreateOrderUseCase) Execute(ctx context.Context, input dtos.OrderIn
 
 func TestCreateOrder_Success(t *testing.T) {
 	mockUseCase := &mockCreateOrderUseCase{
-		output: dtos.OrderOutput{ID: "1", CustomerName: "John Doe", Status: "pending"},
+		output: dtos.OrderOutput{ID: "1", CustomerName: "John Doe", Status: "pending", Version: 1},
 	}
-	api := api.NewAPI(mockUseCase, nil, nil, nil, nil)
+	api := api.NewAPI(api.UseCases{CreateOrder: mockUseCase})
 
 	body := `{"customer_name": "John Doe", "items": [{"name": "item1", "quantity": 1, "price": 100}]}`
 	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
@@ -42,26 +49,59 @@ func TestCreateOrder_Success(t *testing.T) {
 	err := json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, "1", response.ID)
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
 }
 
 func TestCreateOrder_InvalidInput(t *testing.T) {
-	api := api.NewAPI(nil, nil, nil, nil, nil)
+	mockUseCase := &mockCreateOrderUseCase{}
+	api := api.NewAPI(api.UseCases{CreateOrder: mockUseCase})
 
-	body := `{"customer_name": "", "items": []}`
+	body := `{"customer_name": "John Doe", "items": [{"name": "item1", "quantity": "one", "price": 1}]}`
 	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
 	rec := httptest.NewRecorder()
 
 	api.CreateOrder(rec, req)
 
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `{"pointer":"/items/0/quantity","code":"invalid_type","message":"must be an integer"}`)
+}
+
+func TestCreateOrder_MalformedBody(t *testing.T) {
+	api := api.NewAPI(api.UseCases{CreateOrder: &mockCreateOrderUseCase{}})
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(`{"customer_name": `)))
+	rec := httptest.NewRecorder()
+
+	api.CreateOrder(rec, req)
+
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Customer name and items are required")
+	assert.Contains(t, rec.Body.String(), "Invalid input")
+}
+
+func TestCreateOrder_UnknownFields(t *testing.T) {
+	handlers := api.NewAPI(api.UseCases{CreateOrder: &mockCreateOrderUseCase{}})
+
+	body := `{"customer_name": "John Doe", "discount": 10, "items": [{"name": "item1", "quantity": 1, "price": 1, "colour": "red"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateOrder(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var problem api.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, []validation.FieldError{
+		{Pointer: "/discount", Code: validation.CodeUnknownField, Message: `unknown field "discount"`},
+		{Pointer: "/items/0/colour", Code: validation.CodeUnknownField, Message: `unknown field "colour"`},
+	}, problem.Errors)
 }
 
 func TestCreateOrder_UseCaseError(t *testing.T) {
 	mockUseCase := &mockCreateOrderUseCase{
 		err: errors.New("use case error"),
 	}
-	api := api.NewAPI(mockUseCase, nil, nil, nil, nil)
+	api := api.NewAPI(api.UseCases{CreateOrder: mockUseCase})
 
 	body := `{"customer_name": "John Doe", "items": [{"name": "item1", "quantity": 1, "price": 100}]}`
 	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
@@ -70,23 +110,89 @@ func TestCreateOrder_UseCaseError(t *testing.T) {
 	api.CreateOrder(rec, req)
 
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Failed to create order")
+	assert.Contains(t, rec.Body.String(), "An unexpected error occurred")
+}
+
+type mockIdempotentCreateOrderUseCase struct {
+	output   dtos.OrderOutput
+	replayed bool
+	err      error
+	key      string
+}
+
+func (m *mockIdempotentCreateOrderUseCase) Execute(ctx context.Context, key string, input dtos.OrderInput) (dtos.OrderOutput, bool, error) {
+	m.key = key
+	return m.output, m.replayed, m.err
+}
+
+func TestCreateOrder_IdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		useCase        *mockIdempotentCreateOrderUseCase
+		expectedStatus int
+		expectedReplay string
+		expectedETag   string
+	}{
+		{
+			name:           "first request",
+			useCase:        &mockIdempotentCreateOrderUseCase{output: dtos.OrderOutput{ID: "1", Version: 1}},
+			expectedStatus: http.StatusCreated,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:           "replayed request",
+			useCase:        &mockIdempotentCreateOrderUseCase{output: dtos.OrderOutput{ID: "1", Version: 1}, replayed: true},
+			expectedStatus: http.StatusCreated,
+			expectedReplay: "true",
+			expectedETag:   `"1"`,
+		},
+		{
+			name:           "key reused with another body",
+			useCase:        &mockIdempotentCreateOrderUseCase{err: usecase.ErrIdempotencyKeyReused},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "request in progress",
+			useCase:        &mockIdempotentCreateOrderUseCase{err: usecase.ErrIdempotencyKeyInProgress},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := api.NewAPI(api.UseCases{IdempotentCreateOrder: tt.useCase})
+
+			body := `{"customer_name": "John Doe", "items": [{"name": "item1", "quantity": 1, "price": 100}]}`
+			req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
+			req.Header.Set("Idempotency-Key", "key-1")
+			rec := httptest.NewRecorder()
+
+			api.CreateOrder(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedReplay, rec.Header().Get("Idempotent-Replayed"))
+			assert.Equal(t, tt.expectedETag, rec.Header().Get("ETag"))
+			assert.Equal(t, "key-1", tt.useCase.key)
+		})
+	}
 }
 
 type mockGetOrderUseCase struct {
-	output dtos.OrderOutput
-	err    error
+	output   dtos.OrderOutput
+	err      error
+	currency string
 }
 
-func (m *mockGetOrderUseCase) Execute(ctx context.Context, id string) (dtos.OrderOutput, error) {
+func (m *mockGetOrderUseCase) Execute(ctx context.Context, id string, currency string) (dtos.OrderOutput, error) {
+	m.currency = currency
 	return m.output, m.err
 }
 
 func TestGetOrder_Success(t *testing.T) {
 	mockUseCase := &mockGetOrderUseCase{
-		output: dtos.OrderOutput{ID: "1", CustomerName: "John Doe", Status: "pending"},
+		output: dtos.OrderOutput{ID: "1", CustomerName: "John Doe", Status: "pending", Version: 4},
 	}
-	api := api.NewAPI(nil, nil, nil, mockUseCase, nil)
+	api := api.NewAPI(api.UseCases{GetOrder: mockUseCase})
 
 	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
 	rec := httptest.NewRecorder()
@@ -96,17 +202,50 @@ func TestGetOrder_Success(t *testing.T) {
 	r.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
 	var response dtos.OrderOutput
 	err := json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, "1", response.ID)
 }
 
+func TestGetOrder_ConvertedCurrency(t *testing.T) {
+	mockUseCase := &mockGetOrderUseCase{
+		output: dtos.OrderOutput{ID: "1", Currency: "BRL", ConvertedTotal: &dtos.ConvertedAmount{Currency: "USD", Rate: "0.2", Total: entity.NewMoney(200, "USD")}},
+	}
+	api := api.NewAPI(api.UseCases{GetOrder: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1?currency=USD", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders/{id}", api.GetOrder)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "USD", mockUseCase.currency)
+	assert.Contains(t, rec.Body.String(), `"converted_total":{"currency":"USD","rate":"0.2","total":2.00}`)
+}
+
+func TestGetOrder_UnsupportedCurrency(t *testing.T) {
+	mockUseCase := &mockGetOrderUseCase{err: entity.ErrUnsupportedCurrency}
+	api := api.NewAPI(api.UseCases{GetOrder: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1?currency=JPY", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders/{id}", api.GetOrder)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
 func TestGetOrder_NotFound(t *testing.T) {
 	mockUseCase := &mockGetOrderUseCase{
-		err: errors.New("order not found"),
+		err: usecase.ErrOrderNotFound,
 	}
-	api := api.NewAPI(nil, nil, nil, mockUseCase, nil)
+	api := api.NewAPI(api.UseCases{GetOrder: mockUseCase})
 
 	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
 	rec := httptest.NewRecorder()
@@ -116,15 +255,17 @@ func TestGetOrder_NotFound(t *testing.T) {
 	r.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusNotFound, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Order not found")
+	assert.Contains(t, rec.Body.String(), "order not found")
 }
 
 type mockListOrderUseCase struct {
 	output dtos.ListOrderOutput
 	err    error
+	input  dtos.ListOrderInput
 }
 
-func (m *mockListOrderUseCase) Execute(ctx context.Context) (dtos.ListOrderOutput, error) {
+func (m *mockListOrderUseCase) Execute(ctx context.Context, input dtos.ListOrderInput) (dtos.ListOrderOutput, error) {
+	m.input = input
 	return m.output, m.err
 }
 
@@ -137,7 +278,7 @@ func TestListOrders_Success(t *testing.T) {
 			},
 		},
 	}
-	api := api.NewAPI(nil, nil, nil, nil, mockUseCase)
+	api := api.NewAPI(api.UseCases{ListOrders: mockUseCase})
 
 	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
 	rec := httptest.NewRecorder()
@@ -158,7 +299,7 @@ func TestListOrders_Failure(t *testing.T) {
 	mockUseCase := &mockListOrderUseCase{
 		err: errors.New("failed to fetch orders"),
 	}
-	api := api.NewAPI(nil, nil, nil, nil, mockUseCase)
+	api := api.NewAPI(api.UseCases{ListOrders: mockUseCase})
 
 	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
 	rec := httptest.NewRecorder()
@@ -168,26 +309,102 @@ func TestListOrders_Failure(t *testing.T) {
 	r.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Failed to list orders")
+	assert.Contains(t, rec.Body.String(), "An unexpected error occurred")
+}
+
+func TestListOrders_QueryParams(t *testing.T) {
+	mockUseCase := &mockListOrderUseCase{}
+	api := api.NewAPI(api.UseCases{ListOrders: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders?page=2&size=20&status=pending&customer_name=john&created_from=2024-01-01T00:00:00Z&sort=updated_at&order=asc", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders", api.ListOrders)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, mockUseCase.input.Page)
+	assert.Equal(t, 20, mockUseCase.input.Size)
+	assert.Equal(t, "pending", mockUseCase.input.Status)
+	assert.Equal(t, "john", mockUseCase.input.CustomerName)
+	assert.Equal(t, "updated_at", mockUseCase.input.SortBy)
+	assert.Equal(t, "asc", mockUseCase.input.SortDirection)
+	assert.NotNil(t, mockUseCase.input.CreatedFrom)
+	assert.Nil(t, mockUseCase.input.CreatedTo)
+	assert.False(t, mockUseCase.input.Keyset)
+}
+
+func TestListOrders_CursorParams(t *testing.T) {
+	mockUseCase := &mockListOrderUseCase{
+		output: dtos.ListOrderOutput{Size: 10, NextCursor: "next"},
+	}
+	api := api.NewAPI(api.UseCases{ListOrders: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders?pagination=cursor&cursor=abc", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders", api.ListOrders)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, mockUseCase.input.Keyset)
+	assert.Equal(t, "abc", mockUseCase.input.Cursor)
+	assert.Contains(t, rec.Body.String(), `"next_cursor":"next"`)
+}
+
+func TestListOrders_InvalidQueryParams(t *testing.T) {
+	api := api.NewAPI(api.UseCases{ListOrders: &mockListOrderUseCase{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders?page=abc", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders", api.ListOrders)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid query")
+}
+
+func TestListOrders_InvalidListQuery(t *testing.T) {
+	mockUseCase := &mockListOrderUseCase{
+		err: fmt.Errorf("%w: invalid sort field", usecase.ErrInvalidListQuery),
+	}
+	api := api.NewAPI(api.UseCases{ListOrders: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders?sort=total", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders", api.ListOrders)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid sort field")
 }
 
 type mockUpdateOrderUseCase struct {
-	output dtos.OrderOutput
-	err    error
+	output          dtos.OrderOutput
+	err             error
+	expectedVersion int64
 }
 
-func (m *mockUpdateOrderUseCase) Execute(ctx context.Context, id string, input dtos.OrderInput) (dtos.OrderOutput, error) {
+func (m *mockUpdateOrderUseCase) Execute(ctx context.Context, id string, expectedVersion int64, input dtos.OrderInput) (dtos.OrderOutput, error) {
+	m.expectedVersion = expectedVersion
 	return m.output, m.err
 }
 
 func TestUpdateOrder_Success(t *testing.T) {
 	mockUseCase := &mockUpdateOrderUseCase{
-		output: dtos.OrderOutput{ID: "1", CustomerName: "John Doe", Status: "completed"},
+		output: dtos.OrderOutput{ID: "1", CustomerName: "John Doe", Status: "completed", Version: 2},
 	}
-	api := api.NewAPI(nil, mockUseCase, nil, nil, nil)
+	api := api.NewAPI(api.UseCases{UpdateOrder: mockUseCase})
 
 	body := `{"customer_name": "John Doe", "items": [{"name": "item1", "quantity": 2, "price": 200}]}`
 	req := httptest.NewRequest(http.MethodPut, "/orders/1", bytes.NewReader([]byte(body)))
+	req.Header.Set("If-Match", `"1"`)
 	rec := httptest.NewRecorder()
 
 	r := chi.NewRouter()
@@ -195,37 +412,160 @@ func TestUpdateOrder_Success(t *testing.T) {
 	r.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(1), mockUseCase.expectedVersion)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
 	var response dtos.OrderOutput
 	err := json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, "completed", response.Status)
 }
 
-func TestUpdateOrder_InvalidInput(t *testing.T) {
-	mockUseCase := &mockUpdateOrderUseCase{
-		err: errors.New("use case error"),
+func TestUpdateOrder_Preconditions(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		err         error
+		status      int
+		problemType string
+	}{
+		{name: "missing If-Match", status: http.StatusPreconditionRequired, problemType: api.ProblemTypePreconditionRequired},
+		{name: "weak ETag", ifMatch: `W/"1"`, status: http.StatusBadRequest, problemType: api.ProblemTypeInvalidRequest},
+		{name: "non-numeric ETag", ifMatch: `"abc"`, status: http.StatusBadRequest, problemType: api.ProblemTypeInvalidRequest},
+		{name: "stale version", ifMatch: `"1"`, err: repository.ErrConcurrentModification, status: http.StatusPreconditionFailed, problemType: api.ProblemTypePreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase := &mockUpdateOrderUseCase{err: tt.err}
+			handlers := api.NewAPI(api.UseCases{UpdateOrder: mockUseCase})
+
+			body := `{"customer_name": "John Doe", "items": [{"name": "item1", "quantity": 2, "price": 200}]}`
+			req := httptest.NewRequest(http.MethodPut, "/orders/1", bytes.NewReader([]byte(body)))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Put("/orders/{id}", handlers.UpdateOrder)
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			var problem api.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.problemType, problem.Type)
+		})
 	}
+}
+
+func TestUpdateOrder_InvalidInput(t *testing.T) {
+	var fieldErrs validation.Errors
+	fieldErrs.Add("/customer_name", validation.CodeRequired, "must not be empty")
+	fieldErrs.Add("/items", validation.CodeRequired, "must contain at least one item")
+	mockUseCase := &mockUpdateOrderUseCase{err: fieldErrs}
 
-	api := api.NewAPI(nil, mockUseCase, nil, nil, nil)
+	handlers := api.NewAPI(api.UseCases{UpdateOrder: mockUseCase})
 
 	body := `{"customer_name": "", "items": []}`
 	req := httptest.NewRequest(http.MethodPut, "/orders/1", bytes.NewReader([]byte(body)))
+	req.Header.Set("If-Match", `"1"`)
 	rec := httptest.NewRecorder()
 
 	r := chi.NewRouter()
-	r.Put("/orders/{id}", api.UpdateOrder)
+	r.Put("/orders/{id}", handlers.UpdateOrder)
 	r.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Customer name and items are required")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var problem api.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, []validation.FieldError(fieldErrs), problem.Errors)
+}
+
+type mockPatchOrderUseCase struct {
+	output          dtos.OrderOutput
+	err             error
+	expectedVersion int64
+	input           dtos.OrderPatchInput
+}
+
+func (m *mockPatchOrderUseCase) Execute(ctx context.Context, id string, expectedVersion int64, input dtos.OrderPatchInput) (dtos.OrderOutput, error) {
+	m.expectedVersion = expectedVersion
+	m.input = input
+	return m.output, m.err
+}
+
+func TestPatchOrder_Success(t *testing.T) {
+	mockUseCase := &mockPatchOrderUseCase{
+		output: dtos.OrderOutput{ID: "1", CustomerName: "Jane Doe", Status: "pending", Version: 3},
+	}
+	handlers := api.NewAPI(api.UseCases{PatchOrder: mockUseCase})
+
+	body := `{"customer_name": "Jane Doe"}`
+	req := httptest.NewRequest(http.MethodPatch, "/orders/1", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/merge-patch+json; charset=utf-8")
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Patch("/orders/{id}", handlers.PatchOrder)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	assert.Equal(t, int64(2), mockUseCase.expectedVersion)
+	assert.Equal(t, patch.MergePatch, mockUseCase.input.Format)
+	assert.JSONEq(t, body, string(mockUseCase.input.Patch))
+}
+
+func TestPatchOrder_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		ifMatch     string
+		err         error
+		status      int
+	}{
+		{name: "unsupported media type", contentType: "application/json", ifMatch: `"1"`, status: http.StatusUnsupportedMediaType},
+		{name: "missing If-Match", contentType: "application/json-patch+json", status: http.StatusPreconditionRequired},
+		{name: "invalid patch", contentType: "application/json-patch+json", ifMatch: `"1"`, err: fmt.Errorf("%w: operation 0 has no path", patch.ErrInvalidPatch), status: http.StatusBadRequest},
+		{name: "failed test operation", contentType: "application/json-patch+json", ifMatch: `"1"`, err: fmt.Errorf("%w: test failed", patch.ErrNotApplicable), status: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := api.NewAPI(api.UseCases{PatchOrder: &mockPatchOrderUseCase{err: tt.err}})
+
+			req := httptest.NewRequest(http.MethodPatch, "/orders/1", bytes.NewReader([]byte(`[]`)))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Patch("/orders/{id}", handlers.PatchOrder)
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, api.ProblemContentType, rec.Header().Get("Content-Type"))
+			if tt.status == http.StatusUnsupportedMediaType {
+				assert.Equal(t, "application/merge-patch+json, application/json-patch+json", rec.Header().Get("Accept-Patch"))
+			}
+		})
+	}
 }
 
 type mockCancelOrderUseCase struct {
-	output dtos.OrderOutput
-	err    error
+	output          dtos.OrderOutput
+	err             error
+	reason          string
+	expectedVersion int64
 }
 
-func (m *mockCancelOrderUseCase) Execute(ctx context.Context, id string) (dtos.OrderOutput, error) {
+func (m *mockCancelOrderUseCase) Execute(ctx context.Context, id string, expectedVersion int64, reason string) (dtos.OrderOutput, error) {
+	m.reason = reason
+	m.expectedVersion = expectedVersion
 	return m.output, m.err
 }
 
@@ -233,9 +573,10 @@ func TestCancelOrder_Success(t *testing.T) {
 	mockUseCase := &mockCancelOrderUseCase{
 		output: dtos.OrderOutput{ID: "1", CustomerName: "John Doe", Status: "canceled"},
 	}
-	api := api.NewAPI(nil, nil, mockUseCase, nil, nil)
+	api := api.NewAPI(api.UseCases{CancelOrder: mockUseCase})
 
-	req := httptest.NewRequest(http.MethodDelete, "/orders/1", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/orders/1?reason=duplicate", nil)
+	req.Header.Set("If-Match", `"1"`)
 	rec := httptest.NewRecorder()
 
 	r := chi.NewRouter()
@@ -243,6 +584,8 @@ func TestCancelOrder_Success(t *testing.T) {
 	r.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "duplicate", mockUseCase.reason)
+	assert.Equal(t, int64(1), mockUseCase.expectedVersion)
 	var response dtos.OrderOutput
 	err := json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(t, err)
@@ -253,9 +596,10 @@ func TestCancelOrder_Failure(t *testing.T) {
 	mockUseCase := &mockCancelOrderUseCase{
 		err: errors.New("failed to cancel order"),
 	}
-	api := api.NewAPI(nil, nil, mockUseCase, nil, nil)
+	api := api.NewAPI(api.UseCases{CancelOrder: mockUseCase})
 
 	req := httptest.NewRequest(http.MethodDelete, "/orders/1", nil)
+	req.Header.Set("If-Match", "*")
 	rec := httptest.NewRecorder()
 
 	r := chi.NewRouter()
@@ -263,5 +607,104 @@ func TestCancelOrder_Failure(t *testing.T) {
 	r.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Failed to cancel order")
+	assert.Contains(t, rec.Body.String(), "An unexpected error occurred")
+}
+
+type mockGetOrderTransitionsUseCase struct {
+	output dtos.OrderTransitionsOutput
+	err    error
+}
+
+func (m *mockGetOrderTransitionsUseCase) Execute(ctx context.Context, id string) (dtos.OrderTransitionsOutput, error) {
+	return m.output, m.err
+}
+
+func TestGetOrderTransitions_Success(t *testing.T) {
+	mockUseCase := &mockGetOrderTransitionsUseCase{
+		output: dtos.OrderTransitionsOutput{ID: "1", Status: "pending", Transitions: []string{"processing", "canceled"}},
+	}
+	api := api.NewAPI(api.UseCases{GetOrderTransitions: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1/transitions", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders/{id}/transitions", api.GetOrderTransitions)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response dtos.OrderTransitionsOutput
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"processing", "canceled"}, response.Transitions)
+}
+
+func TestGetOrderTransitions_NotFound(t *testing.T) {
+	mockUseCase := &mockGetOrderTransitionsUseCase{
+		err: usecase.ErrOrderNotFound,
+	}
+	api := api.NewAPI(api.UseCases{GetOrderTransitions: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1/transitions", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders/{id}/transitions", api.GetOrderTransitions)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "order not found")
+}
+
+type mockGetOrderHistoryUseCase struct {
+	output dtos.OrderHistoryOutput
+	err    error
+}
+
+func (m *mockGetOrderHistoryUseCase) Execute(ctx context.Context, id string) (dtos.OrderHistoryOutput, error) {
+	return m.output, m.err
+}
+
+func TestGetOrderHistory_Success(t *testing.T) {
+	from := "pending"
+	mockUseCase := &mockGetOrderHistoryUseCase{
+		output: dtos.OrderHistoryOutput{
+			ID: "1",
+			History: []dtos.StatusChangeOutput{
+				{To: "pending"},
+				{From: &from, To: "canceled", ChangedBy: "api", Reason: "duplicate"},
+			},
+		},
+	}
+	api := api.NewAPI(api.UseCases{GetOrderHistory: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1/history", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders/{id}/history", api.GetOrderHistory)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response dtos.OrderHistoryOutput
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.History, 2)
+	assert.Equal(t, "duplicate", response.History[1].Reason)
+}
+
+func TestGetOrderHistory_NotFound(t *testing.T) {
+	mockUseCase := &mockGetOrderHistoryUseCase{
+		err: usecase.ErrOrderNotFound,
+	}
+	api := api.NewAPI(api.UseCases{GetOrderHistory: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1/history", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders/{id}/history", api.GetOrderHistory)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
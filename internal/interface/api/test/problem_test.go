@@ -0,0 +1,100 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/application/usecase"
+	"order-service/internal/domain/auth"
+	"order-service/internal/domain/catalog"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/inventory"
+	"order-service/internal/domain/repository"
+	"order-service/internal/interface/api"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblemFromError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		retryable bool
+	}{
+		{"order not found", usecase.ErrOrderNotFound, http.StatusNotFound, false},
+		{"repository not found", repository.ErrNotFound, http.StatusNotFound, false},
+		{"invalid transition", &entity.InvalidTransitionError{From: entity.Completed, To: entity.Canceled}, http.StatusConflict, false},
+		{"order not editable", usecase.ErrOrderNotEditable, http.StatusConflict, false},
+		{"insufficient stock", &inventory.InsufficientStockError{ProductID: "123459", Requested: 3, Available: 1}, http.StatusConflict, false},
+		{"concurrent modification", fmt.Errorf("%w: order 1", repository.ErrConcurrentModification), http.StatusPreconditionFailed, false},
+		{"idempotent request in progress", usecase.ErrIdempotencyKeyInProgress, http.StatusConflict, true},
+		{"validation", entity.NewValidationError("item quantity must be greater than zero"), http.StatusUnprocessableEntity, false},
+		{"wrapped validation", fmt.Errorf("%w: USD vs BRL", entity.ErrCurrencyMismatch), http.StatusUnprocessableEntity, false},
+		{"invalid list query", fmt.Errorf("%w: bad sort", usecase.ErrInvalidListQuery), http.StatusBadRequest, false},
+		{"database unreachable", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, http.StatusServiceUnavailable, true},
+		{"catalog unavailable", fmt.Errorf("%w: status 502", catalog.ErrCatalogUnavailable), http.StatusServiceUnavailable, true},
+		{"unauthenticated", fmt.Errorf("%w: token expired", auth.ErrUnauthenticated), http.StatusUnauthorized, false},
+		{"forbidden", auth.ErrForbidden, http.StatusForbidden, false},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := api.ProblemFromError(tt.err)
+
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, http.StatusText(tt.status), problem.Title)
+			assert.Equal(t, tt.retryable, problem.Retryable)
+		})
+	}
+}
+
+func TestProblemResponse(t *testing.T) {
+	mockUseCase := &mockCancelOrderUseCase{
+		err: &entity.InvalidTransitionError{From: entity.Completed, To: entity.Canceled},
+	}
+	handlers := api.NewAPI(api.UseCases{CancelOrder: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodDelete, "/orders/1", nil)
+	req.Header.Set("If-Match", `"1"`)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Delete("/orders/{id}", handlers.CancelOrder)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, api.ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var problem api.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, api.ProblemTypeConflict, problem.Type)
+	assert.Equal(t, "/orders/1", problem.Instance)
+	assert.Equal(t, "cannot change order status from completed to canceled", problem.Detail)
+}
+
+func TestProblemResponse_Unavailable(t *testing.T) {
+	mockUseCase := &mockGetOrderUseCase{
+		err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+	}
+	handlers := api.NewAPI(api.UseCases{GetOrder: mockUseCase})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	rec := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/orders/{id}", handlers.GetOrder)
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+}
@@ -0,0 +1,58 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/application/dtos"
+	"order-service/internal/interface/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The handlers must read the URL parameters of the router the service runs on.
+func TestNewRouter_PassesURLParameters(t *testing.T) {
+	removeItem := &mockRemoveOrderItemUseCase{output: dtos.OrderOutput{ID: "o1", Version: 2}}
+	listCustomerOrders := &mockListCustomerOrdersUseCase{output: dtos.ListOrderOutput{Orders: []dtos.OrderOutput{}}}
+	handlers := api.NewAPI(api.UseCases{RemoveOrderItem: removeItem, ListCustomerOrders: listCustomerOrders})
+	router := api.NewRouter(handlers, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/orders/o1/items/i1", nil)
+	req.Header.Set("If-Match", `"1"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "o1", removeItem.orderID)
+	assert.Equal(t, "i1", removeItem.itemID)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/customers/c1/orders", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "c1", listCustomerOrders.customerID)
+}
+
+func TestNewRouter_CORS(t *testing.T) {
+	router := api.NewRouter(api.NewAPI(api.UseCases{}), nil)
+
+	req := httptest.NewRequest(http.MethodOptions, "/orders/o1", nil)
+	req.Header.Set("Origin", "https://shop.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Methods"), http.MethodPatch)
+	allowedHeaders := rec.Header().Get("Access-Control-Allow-Headers")
+	assert.Contains(t, allowedHeaders, api.IfMatchHeader)
+	assert.Contains(t, allowedHeaders, api.IdempotencyKeyHeader)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	exposedHeaders := rec.Header().Get("Access-Control-Expose-Headers")
+	assert.Contains(t, exposedHeaders, api.ETagHeader)
+	assert.Contains(t, exposedHeaders, api.IdempotentReplayedHeader)
+}